@@ -0,0 +1,41 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/docker/compose/v2/pkg/api"
+)
+
+// alphaCommand groups all experimental subcommands
+func alphaCommand(p *projectOptions, backend api.Service) *cobra.Command {
+	cmd := &cobra.Command{
+		Short: "Experimental commands",
+		Use:   "alpha [COMMAND]",
+		Annotations: map[string]string{
+			"experimentalCLI": "true",
+		},
+	}
+	cmd.AddCommand(
+		dfCommand(p, backend),
+		diffCommand(p, backend),
+		lintVersionCommand(p),
+		vizCommand(p),
+	)
+	return cmd
+}
@@ -25,23 +25,34 @@ import (
 	"github.com/compose-spec/compose-go/cli"
 	"github.com/compose-spec/compose-go/loader"
 	"github.com/compose-spec/compose-go/types"
+	"github.com/docker/buildx/util/buildflags"
 	buildx "github.com/docker/buildx/util/progress"
+	cliopts "github.com/docker/cli/opts"
 	"github.com/docker/compose/v2/pkg/utils"
 	"github.com/spf13/cobra"
 
 	"github.com/docker/compose/v2/pkg/api"
+	"github.com/docker/compose/v2/pkg/compose"
+	"github.com/docker/compose/v2/pkg/progress"
 )
 
 type buildOptions struct {
 	*projectOptions
 	composeOptions
-	quiet    bool
-	pull     bool
-	progress string
-	args     []string
-	noCache  bool
-	memory   string
-	ssh      string
+	quiet           bool
+	pull            bool
+	progress        string
+	args            []string
+	noCache         bool
+	memory          string
+	ssh             string
+	cacheFrom       []string
+	cacheTo         []string
+	tags            []string
+	extraHosts      []string
+	check           bool
+	errorOnWarnings bool
+	print           string
 }
 
 func (opts buildOptions) toAPIBuildOptions(services []string) (api.BuildOptions, error) {
@@ -54,14 +65,33 @@ func (opts buildOptions) toAPIBuildOptions(services []string) (api.BuildOptions,
 		}
 	}
 
+	for _, host := range opts.extraHosts {
+		if _, err := cliopts.ValidateExtraHost(host); err != nil {
+			return api.BuildOptions{}, err
+		}
+	}
+
+	if _, err := buildflags.ParseCacheEntry(opts.cacheFrom); err != nil {
+		return api.BuildOptions{}, fmt.Errorf("invalid --cache-from: %s", err)
+	}
+	if _, err := buildflags.ParseCacheEntry(opts.cacheTo); err != nil {
+		return api.BuildOptions{}, fmt.Errorf("invalid --cache-to: %s", err)
+	}
+
 	return api.BuildOptions{
-		Pull:     opts.pull,
-		Progress: opts.progress,
-		Args:     types.NewMappingWithEquals(opts.args),
-		NoCache:  opts.noCache,
-		Quiet:    opts.quiet,
-		Services: services,
-		SSHs:     SSHKeys,
+		Pull:            opts.pull,
+		Progress:        opts.progress,
+		Args:            types.NewMappingWithEquals(opts.args),
+		NoCache:         opts.noCache,
+		Quiet:           opts.quiet,
+		Services:        services,
+		SSHs:            SSHKeys,
+		CacheFrom:       opts.cacheFrom,
+		CacheTo:         opts.cacheTo,
+		ExtraHosts:      opts.extraHosts,
+		Check:           opts.check,
+		ErrorOnWarnings: opts.errorOnWarnings,
+		Print:           opts.print,
 	}, nil
 }
 
@@ -70,6 +100,7 @@ var printerModes = []string{
 	buildx.PrinterModeTty,
 	buildx.PrinterModePlain,
 	buildx.PrinterModeQuiet,
+	compose.PrinterModeRawJSON,
 }
 
 func buildCommand(p *projectOptions, backend api.Service) *cobra.Command {
@@ -79,7 +110,10 @@ func buildCommand(p *projectOptions, backend api.Service) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "build [SERVICE...]",
 		Short: "Build or rebuild services",
-		PreRunE: Adapt(func(ctx context.Context, args []string) error {
+		PreRunE: AdaptCmd(func(ctx context.Context, cmd *cobra.Command, args []string) error {
+			if !cmd.Flags().Changed("progress") && progress.Mode == progress.ModePlain {
+				opts.progress = buildx.PrinterModePlain
+			}
 			if opts.memory != "" {
 				fmt.Println("WARNING --memory is ignored as not supported in buildkit.")
 			}
@@ -91,6 +125,17 @@ func buildCommand(p *projectOptions, backend api.Service) *cobra.Command {
 				}
 				os.Stdout = devnull
 			}
+			if opts.errorOnWarnings && !opts.check {
+				return fmt.Errorf("--error-on-warnings requires --check")
+			}
+			if opts.print != "" {
+				if opts.print != "yaml" && opts.print != "json" {
+					return fmt.Errorf("invalid --print option %q. Should be yaml or json", opts.print)
+				}
+				if opts.check {
+					return fmt.Errorf("--print and --check are incompatible")
+				}
+			}
 			if !utils.StringContains(printerModes, opts.progress) {
 				return fmt.Errorf("unsupported --progress value %q", opts.progress)
 			}
@@ -116,6 +161,14 @@ func buildCommand(p *projectOptions, backend api.Service) *cobra.Command {
 	cmd.Flags().Bool("force-rm", true, "Always remove intermediate containers. DEPRECATED")
 	cmd.Flags().MarkHidden("force-rm") //nolint:errcheck
 	cmd.Flags().BoolVar(&opts.noCache, "no-cache", false, "Do not use cache when building the image")
+	cmd.Flags().StringArrayVar(&opts.cacheFrom, "cache-from", []string{}, "External cache sources (e.g. type=registry,ref=user/app:cache)")
+	cmd.Flags().StringArrayVar(&opts.cacheTo, "cache-to", []string{}, "Cache export destinations (e.g. type=registry,ref=user/app:cache)")
+	cmd.Flags().StringArrayVar(&opts.extraHosts, "add-host", []string{}, "Add a custom host-to-IP mapping (host:ip) available during the build, in addition to the ones declared by services.")
+	cmd.Flags().StringArrayVar(&opts.tags, "tag", []string{}, "Tag the image built for a service as IMAGE, or SERVICE=IMAGE when building multiple services. Overrides the Compose file.")
+	cmd.Flags().BoolVar(&opts.check, "check", false, "Check the services Dockerfile for issues, without building images.")
+	cmd.Flags().BoolVar(&opts.errorOnWarnings, "error-on-warnings", false, "Exit with an error when --check finds issues.")
+	cmd.Flags().StringVar(&opts.print, "print", "", "Print the resolved build configuration of services, without building them. Values: [yaml | json].")
+	cmd.Flags().Lookup("print").NoOptDefVal = "yaml"
 	cmd.Flags().Bool("no-rm", false, "Do not remove intermediate containers after a successful build. DEPRECATED")
 	cmd.Flags().MarkHidden("no-rm") //nolint:errcheck
 	cmd.Flags().StringVarP(&opts.memory, "memory", "m", "", "Set memory limit for the build container. Not supported on buildkit yet.")
@@ -125,14 +178,56 @@ func buildCommand(p *projectOptions, backend api.Service) *cobra.Command {
 }
 
 func runBuild(ctx context.Context, backend api.Service, opts buildOptions, services []string) error {
-	project, err := opts.toProject(services, cli.WithResolvedPaths(true))
+	project, err := opts.toProject(ctx, services, cli.WithResolvedPaths(true))
 	if err != nil {
 		return err
 	}
 
+	for _, tag := range opts.tags {
+		err = applyTag(project, services, tag)
+		if err != nil {
+			return err
+		}
+	}
+
 	apiBuildOptions, err := opts.toAPIBuildOptions(services)
 	if err != nil {
 		return err
 	}
 	return backend.Build(ctx, project, apiBuildOptions)
 }
+
+// applyTag sets the image built for a service from a [SERVICE=]IMAGE option, service being required unless a single
+// service is built
+func applyTag(project *types.Project, services []string, tag string) error {
+	var service, image string
+	if split := strings.SplitN(tag, "=", 2); len(split) == 2 {
+		service, image = split[0], split[1]
+	} else {
+		var built []string
+		for _, s := range project.Services {
+			if s.Build != nil && (len(services) == 0 || utils.StringContains(services, s.Name)) {
+				built = append(built, s.Name)
+			}
+		}
+		if len(built) != 1 {
+			return fmt.Errorf("invalid --tag option %q. Should be SERVICE=IMAGE when building multiple services", tag)
+		}
+		service, image = built[0], tag
+	}
+	if service == "" || image == "" {
+		return fmt.Errorf("invalid --tag option %q. Should be [SERVICE=]IMAGE", tag)
+	}
+	for i, s := range project.Services {
+		if s.Name != service {
+			continue
+		}
+		if s.Build == nil {
+			return fmt.Errorf("invalid --tag option %q. Service %q has no build section", tag, service)
+		}
+		s.Image = image
+		project.Services[i] = s
+		return nil
+	}
+	return fmt.Errorf("unknown service %q", service)
+}
@@ -0,0 +1,67 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"testing"
+
+	"github.com/compose-spec/compose-go/types"
+	"gotest.tools/v3/assert"
+)
+
+func TestApplyTag(t *testing.T) {
+	newProject := func() *types.Project {
+		return &types.Project{
+			Services: []types.ServiceConfig{
+				{Name: "web", Build: &types.BuildConfig{Context: "web"}},
+				{Name: "worker", Image: "worker:latest", Build: &types.BuildConfig{Context: "worker"}},
+				{Name: "db", Image: "postgres"},
+			},
+		}
+	}
+
+	p := newProject()
+	assert.NilError(t, applyTag(p, nil, "web=registry/web:ci-123"))
+	assert.NilError(t, applyTag(p, nil, "worker=registry/worker:ci-123"))
+	assert.Equal(t, p.Services[0].Image, "registry/web:ci-123")
+	assert.Equal(t, p.Services[1].Image, "registry/worker:ci-123")
+
+	p = newProject()
+	assert.NilError(t, applyTag(p, []string{"worker"}, "registry/worker:ci-123"))
+	assert.Equal(t, p.Services[0].Image, "")
+	assert.Equal(t, p.Services[1].Image, "registry/worker:ci-123")
+
+	err := applyTag(newProject(), nil, "registry/web:ci-123")
+	assert.ErrorContains(t, err, `invalid --tag option "registry/web:ci-123". Should be SERVICE=IMAGE when building multiple services`)
+
+	err = applyTag(newProject(), nil, "db=registry/db:ci-123")
+	assert.ErrorContains(t, err, `Service "db" has no build section`)
+
+	err = applyTag(newProject(), nil, "unknown=registry/unknown:ci-123")
+	assert.ErrorContains(t, err, `unknown service "unknown"`)
+}
+
+func TestBuildOptionsExtraHosts(t *testing.T) {
+	opts := buildOptions{extraHosts: []string{"registry.internal:10.0.0.5"}}
+	apiOpts, err := opts.toAPIBuildOptions(nil)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, apiOpts.ExtraHosts, []string{"registry.internal:10.0.0.5"})
+
+	opts = buildOptions{extraHosts: []string{"registry.internal"}}
+	_, err = opts.toAPIBuildOptions(nil)
+	assert.ErrorContains(t, err, `bad format for add-host: "registry.internal"`)
+}
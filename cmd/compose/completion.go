@@ -33,7 +33,7 @@ func noCompletion() validArgsFn {
 
 func serviceCompletion(p *projectOptions) validArgsFn {
 	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-		project, err := p.toProject(nil)
+		project, err := p.toProject(cmd.Context(), nil)
 		if err != nil {
 			return nil, cobra.ShellCompDirectiveNoFileComp
 		}
@@ -17,20 +17,27 @@
 package compose
 
 import (
+	"bufio"
 	"context"
 	"fmt"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"sort"
 	"strings"
 	"syscall"
 
+	"github.com/cnabio/cnab-to-oci/remotes"
 	"github.com/compose-spec/compose-go/cli"
+	"github.com/compose-spec/compose-go/loader"
+	"github.com/compose-spec/compose-go/template"
 	"github.com/compose-spec/compose-go/types"
 	composegoutils "github.com/compose-spec/compose-go/utils"
+	containerdremotes "github.com/containerd/containerd/remotes"
 	dockercli "github.com/docker/cli/cli"
 	"github.com/docker/cli/cli-plugins/manager"
 	"github.com/docker/cli/cli/command"
+	cliconfig "github.com/docker/cli/cli/config"
 	"github.com/morikuni/aec"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
@@ -41,6 +48,7 @@ import (
 	"github.com/docker/compose/v2/pkg/api"
 	"github.com/docker/compose/v2/pkg/compose"
 	"github.com/docker/compose/v2/pkg/progress"
+	"github.com/docker/compose/v2/pkg/remote"
 	"github.com/docker/compose/v2/pkg/utils"
 )
 
@@ -98,6 +106,10 @@ type projectOptions struct {
 	ProjectDir    string
 	EnvFile       string
 	Compatibility bool
+	// StrictProjectName rejects invalid project names instead of normalizing them
+	StrictProjectName bool
+	// skipEnvFile prevents the environment file from being loaded, so that only the process environment is used
+	skipEnvFile bool
 }
 
 // ProjectFunc does stuff within a types.Project
@@ -116,7 +128,7 @@ func (o *projectOptions) WithProject(fn ProjectFunc) func(cmd *cobra.Command, ar
 // WithServices creates a cobra run command from a ProjectFunc based on configured project options and selected services
 func (o *projectOptions) WithServices(fn ProjectServicesFunc) func(cmd *cobra.Command, args []string) error {
 	return Adapt(func(ctx context.Context, args []string) error {
-		project, err := o.toProject(args, cli.WithResolvedPaths(true))
+		project, err := o.toProject(ctx, args, cli.WithResolvedPaths(true))
 		if err != nil {
 			return err
 		}
@@ -133,37 +145,50 @@ func (o *projectOptions) addProjectFlags(f *pflag.FlagSet) {
 	f.StringVar(&o.ProjectDir, "project-directory", "", "Specify an alternate working directory\n(default: the path of the, first specified, Compose file)")
 	f.StringVar(&o.WorkDir, "workdir", "", "DEPRECATED! USE --project-directory INSTEAD.\nSpecify an alternate working directory\n(default: the path of the, first specified, Compose file)")
 	f.BoolVar(&o.Compatibility, "compatibility", false, "Run compose in backward compatibility mode")
+	f.BoolVar(&o.StrictProjectName, "strict-project-name", false, "Fail on an invalid project name instead of normalizing it")
 	_ = f.MarkHidden("workdir")
 }
 
-func (o *projectOptions) toProjectName() (string, error) {
+func (o *projectOptions) toProjectName(ctx context.Context) (string, error) {
 	if o.ProjectName != "" {
-		return o.ProjectName, nil
+		return checkProjectName(o.ProjectName, o.StrictProjectName)
 	}
 
 	envProjectName := os.Getenv("COMPOSE_PROJECT_NAME")
 	if envProjectName != "" {
-		return envProjectName, nil
+		return checkProjectName(envProjectName, o.StrictProjectName)
 	}
 
-	project, err := o.toProject(nil)
+	project, err := o.toProject(ctx, nil)
 	if err != nil {
 		return "", err
 	}
 	return project.Name, nil
 }
 
-func (o *projectOptions) toProject(services []string, po ...cli.ProjectOptionsFn) (*types.Project, error) {
-	options, err := o.toProjectOptions(po...)
+func (o *projectOptions) toProject(ctx context.Context, services []string, po ...cli.ProjectOptionsFn) (*types.Project, error) {
+	options, err := o.toProjectOptions(ctx, po...)
 	if err != nil {
 		return nil, compose.WrapComposeError(err)
 	}
 
+	name, imperativelySet, err := o.declaredProjectName(options)
+	if err != nil {
+		return nil, err
+	}
+
 	project, err := cli.ProjectFromOptions(options)
 	if err != nil {
 		return nil, compose.WrapComposeError(err)
 	}
 
+	// the loader silently normalizes the project name, unless it is set by the Compose file it is checked here
+	if imperativelySet || project.Name == "" || project.Name == loader.NormalizeProjectName(name) {
+		if _, err := checkProjectName(name, o.StrictProjectName); err != nil {
+			return nil, err
+		}
+	}
+
 	if o.Compatibility || utils.StringToBool(project.Environment["COMPOSE_COMPATIBILITY"]) {
 		compose.Separator = "_"
 	}
@@ -191,11 +216,11 @@ func (o *projectOptions) toProject(services []string, po ...cli.ProjectOptionsFn
 	}
 
 	if len(services) > 0 {
-		s, err := project.GetServices(services...)
+		profiles, err := requiredProfiles(project, services)
 		if err != nil {
 			return nil, err
 		}
-		o.Profiles = append(o.Profiles, s.GetProfiles()...)
+		o.Profiles = append(o.Profiles, profiles...)
 	}
 
 	if profiles, ok := options.Environment["COMPOSE_PROFILES"]; ok {
@@ -210,18 +235,210 @@ func (o *projectOptions) toProject(services []string, po ...cli.ProjectOptionsFn
 	return project, err
 }
 
-func (o *projectOptions) toProjectOptions(po ...cli.ProjectOptionsFn) (*cli.ProjectOptions, error) {
-	return cli.NewProjectOptions(o.ConfigPaths,
-		append(po,
-			cli.WithWorkingDirectory(o.ProjectDir),
-			cli.WithOsEnv,
+// requiredProfiles returns the profiles of the selected services and of their dependencies, transitively, so that a
+// service targeted on the command line is enabled along with the services it depends on, whatever their profiles.
+// Dependencies which are not services of the project are left to be reported when the project is filtered.
+func requiredProfiles(project *types.Project, services []string) ([]string, error) {
+	selected, err := project.GetServices(services...)
+	if err != nil {
+		return nil, err
+	}
+	var profiles []string
+	seen := map[string]bool{}
+	for len(selected) > 0 {
+		service := selected[0]
+		selected = selected[1:]
+		if seen[service.Name] {
+			continue
+		}
+		seen[service.Name] = true
+		profiles = append(profiles, service.Profiles...)
+		for _, dependency := range service.GetDependencies() {
+			if s, err := project.GetService(dependency); err == nil {
+				selected = append(selected, s)
+			}
+		}
+	}
+	return profiles, nil
+}
+
+// checkProjectName normalizes a project name, which must only contain lowercase letters, digits, dashes and
+// underscores, and start with a letter or a digit. An invalid name is reported as a warning, or rejected when strict.
+func checkProjectName(name string, strict bool) (string, error) {
+	normalized := loader.NormalizeProjectName(name)
+	if normalized == name {
+		return name, nil
+	}
+	if normalized == "" {
+		return "", fmt.Errorf("invalid project name %q: must contain at least one lowercase letter or digit", name)
+	}
+	if strict {
+		return "", fmt.Errorf("invalid project name %q: must only contain lowercase letters, digits, dashes and underscores, and start with a letter or digit", name)
+	}
+	logrus.Warnf("project name %q is not valid, using %q instead", name, normalized)
+	return normalized, nil
+}
+
+// declaredProjectName returns the project name as set by the user, or else derived from the project directory
+func (o *projectOptions) declaredProjectName(options *cli.ProjectOptions) (string, bool, error) {
+	if o.ProjectName != "" {
+		return o.ProjectName, true, nil
+	}
+	if name := options.Environment["COMPOSE_PROJECT_NAME"]; name != "" {
+		return name, true, nil
+	}
+	workingDir, err := options.GetWorkingDir()
+	if err != nil {
+		return "", false, err
+	}
+	workingDir, err = filepath.Abs(workingDir)
+	if err != nil {
+		return "", false, err
+	}
+	return filepath.Base(workingDir), false, nil
+}
+
+// missingVariables collects the required variables found unset while interpolating compose files, so they can be
+// reported all at once rather than failing on the first one
+type missingVariables struct {
+	messages map[string]bool
+}
+
+// substitute is a template substitution function which records missing required variables instead of failing
+func (m *missingVariables) substitute(value string, mapping template.Mapping) (string, error) {
+	result, err := template.Substitute(value, mapping)
+	if invalid, ok := err.(*template.InvalidTemplateError); ok && strings.HasPrefix(invalid.Template, "required variable ") {
+		if m.messages == nil {
+			m.messages = map[string]bool{}
+		}
+		m.messages[invalid.Template] = true
+		return "", nil
+	}
+	return result, err
+}
+
+func (m *missingVariables) err() error {
+	if len(m.messages) == 0 {
+		return nil
+	}
+	var messages []string
+	for msg := range m.messages {
+		messages = append(messages, msg)
+	}
+	sort.Strings(messages)
+	return fmt.Errorf("%d required variable(s) not set:\n%s", len(messages), strings.Join(messages, "\n"))
+}
+
+// toProjectCheckingVariables loads the project like toProject does, but reports all the missing required variables
+// instead of the first one
+func (o *projectOptions) toProjectCheckingVariables(ctx context.Context, services []string, po ...cli.ProjectOptionsFn) (*types.Project, error) {
+	missing := &missingVariables{}
+	po = append(po, cli.WithLoadOptions(func(options *loader.Options) {
+		if options.Interpolate != nil {
+			options.Interpolate.Substitute = missing.substitute
+		}
+	}))
+	project, err := o.toProject(ctx, services, po...)
+	if missingErr := missing.err(); missingErr != nil {
+		// other errors may be a consequence of missing values
+		return nil, missingErr
+	}
+	return project, err
+}
+
+func (o *projectOptions) toProjectOptions(ctx context.Context, po ...cli.ProjectOptionsFn) (*cli.ProjectOptions, error) {
+	configPaths, err := o.remoteConfigPaths(ctx)
+	if err != nil {
+		return nil, err
+	}
+	po = append(po,
+		cli.WithWorkingDirectory(o.ProjectDir),
+		cli.WithOsEnv)
+	if !o.skipEnvFile {
+		po = append(po,
 			cli.WithEnvFile(o.EnvFile),
-			cli.WithDotEnv,
+			cli.WithDotEnv)
+	}
+	return cli.NewProjectOptions(configPaths,
+		append(po,
 			cli.WithConfigFileEnv,
 			cli.WithDefaultConfigPath,
 			cli.WithName(o.ProjectName))...)
 }
 
+// remoteConfigPaths returns the Compose file paths, fetching the projects published as OCI artifacts, referenced as
+// `oci://REPOSITORY[:TAG]`, to the local cache where their Compose file is loaded from. The references are replaced
+// by the paths they were fetched to, so they are resolved on the registry once per invocation.
+func (o *projectOptions) remoteConfigPaths(ctx context.Context) ([]string, error) {
+	var (
+		paths    []string
+		resolver containerdremotes.Resolver
+		fetched  bool
+	)
+	for _, path := range o.ConfigPaths {
+		if !remote.IsOCI(path) {
+			paths = append(paths, path)
+			continue
+		}
+		if resolver == nil {
+			resolver = remotes.CreateResolver(cliconfig.LoadDefaultConfigFile(os.Stderr))
+		}
+		cacheDir, err := os.UserCacheDir()
+		if err != nil {
+			return nil, err
+		}
+		composeFile, err := remote.Load(ctx, resolver, path, filepath.Join(cacheDir, "docker-compose", "oci"))
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, composeFile)
+		fetched = true
+	}
+	if fetched {
+		o.ConfigPaths = paths
+	}
+	return paths, nil
+}
+
+// withServicesFromFile adds services listed in a file, one per line with `#` starting a comment, to the ones passed
+// as arguments, checking they are all defined by the project
+func (o *projectOptions) withServicesFromFile(ctx context.Context, services []string, path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close() // nolint: errcheck
+
+	listed := len(services)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		if line = strings.TrimSpace(line); line != "" {
+			services = append(services, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrapf(err, "reading services from %s", path)
+	}
+	if len(services) == listed {
+		return nil, fmt.Errorf("no service listed in %s", path)
+	}
+
+	project, err := o.toProject(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range services {
+		if _, err := project.GetService(name); err != nil {
+			return nil, errors.Wrapf(err, "invalid service in %s", path)
+		}
+	}
+	return services, nil
+}
+
 // PluginName is the name of the plugin
 const PluginName = "compose"
 
@@ -230,14 +447,23 @@ func RunningAsStandalone() bool {
 	return len(os.Args) < 2 || os.Args[1] != manager.MetadataSubcommandName && os.Args[1] != PluginName
 }
 
+var progressModes = []string{
+	progress.ModeAuto,
+	progress.ModeTTY,
+	progress.ModePlain,
+}
+
 // RootCommand returns the compose command with its child commands
 func RootCommand(dockerCli command.Cli, backend api.Service) *cobra.Command {
 	opts := projectOptions{}
 	var (
-		ansi    string
-		noAnsi  bool
-		verbose bool
-		version bool
+		ansi         string
+		noAnsi       bool
+		verbose      bool
+		logLevel     string
+		version      bool
+		progressMode string
+		dryRun       bool
 	)
 	command := &cobra.Command{
 		Short:            "Docker Compose",
@@ -258,7 +484,7 @@ func RootCommand(dockerCli command.Cli, backend api.Service) *cobra.Command {
 			}
 		},
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-			err := setEnvWithDotEnv(&opts)
+			err := setEnvWithDotEnv(cmd.Context(), &opts)
 			if err != nil {
 				return err
 			}
@@ -282,6 +508,15 @@ func RootCommand(dockerCli command.Cli, backend api.Service) *cobra.Command {
 			if verbose {
 				logrus.SetLevel(logrus.TraceLevel)
 			}
+			if logLevel != "" {
+				level, err := parseLogLevel(logLevel)
+				if err != nil {
+					return err
+				}
+				logrus.SetLevel(level)
+			}
+			compose.DryRun = dryRun
+			progress.DryRun = dryRun
 			formatter.SetANSIMode(ansi)
 			switch ansi {
 			case "never":
@@ -289,6 +524,18 @@ func RootCommand(dockerCli command.Cli, backend api.Service) *cobra.Command {
 			case "tty":
 				progress.Mode = progress.ModeTTY
 			}
+			switch progressMode {
+			case progress.ModeAuto:
+			case progress.ModeTTY:
+				if ansi == "never" {
+					return errors.New(`cannot specify "--progress tty" while ANSI support is disabled`)
+				}
+				progress.Mode = progress.ModeTTY
+			case progress.ModePlain:
+				progress.Mode = progress.ModePlain
+			default:
+				return fmt.Errorf("unsupported --progress value %q", progressMode)
+			}
 			if opts.WorkDir != "" {
 				if opts.ProjectDir != "" {
 					return errors.New(`cannot specify DEPRECATED "--workdir" and "--project-directory". Please use only "--project-directory" instead`)
@@ -323,24 +570,43 @@ func RootCommand(dockerCli command.Cli, backend api.Service) *cobra.Command {
 		versionCommand(),
 		buildCommand(&opts, backend),
 		pushCommand(&opts, backend),
+		publishCommand(&opts, backend),
 		pullCommand(&opts, backend),
 		createCommand(&opts, backend),
 		copyCommand(&opts, backend),
+		watchCommand(&opts, backend),
+		alphaCommand(&opts, backend),
 	)
 	command.Flags().SetInterspersed(false)
 	opts.addProjectFlags(command.Flags())
 	command.Flags().StringVar(&ansi, "ansi", "auto", `Control when to print ANSI control characters ("never"|"always"|"auto")`)
+	command.Flags().StringVar(&progressMode, "progress", progress.ModeAuto, fmt.Sprintf(`Set type of progress output (%s)`, strings.Join(progressModes, ", ")))
 	command.Flags().BoolVarP(&version, "version", "v", false, "Show the Docker Compose version information")
 	command.Flags().MarkHidden("version") //nolint:errcheck
 	command.Flags().BoolVar(&noAnsi, "no-ansi", false, `Do not print ANSI control characters (DEPRECATED)`)
 	command.Flags().MarkHidden("no-ansi") //nolint:errcheck
+	command.Flags().BoolVar(&dryRun, "dry-run", false, "Show the operations commands would run, without changing anything")
+	command.Flags().StringVar(&logLevel, "log-level", "", fmt.Sprintf(`Set the logging level of Compose diagnostics, printed to stderr (%s)`, strings.Join(logLevels, ", ")))
 	command.Flags().BoolVar(&verbose, "verbose", false, "Show more output")
 	command.Flags().MarkHidden("verbose") //nolint:errcheck
 	return command
 }
 
-func setEnvWithDotEnv(prjOpts *projectOptions) error {
-	options, err := prjOpts.toProjectOptions()
+// logLevels are the values accepted by --log-level
+var logLevels = []string{"error", "warn", "info", "debug"}
+
+func parseLogLevel(level string) (logrus.Level, error) {
+	if !utils.StringContains(logLevels, strings.ToLower(level)) {
+		return 0, fmt.Errorf("unsupported --log-level value %q, must be one of %s", level, strings.Join(logLevels, ", "))
+	}
+	return logrus.ParseLevel(level)
+}
+
+func setEnvWithDotEnv(ctx context.Context, prjOpts *projectOptions) error {
+	if prjOpts.skipEnvFile {
+		return nil
+	}
+	options, err := prjOpts.toProjectOptions(ctx)
 	if err != nil {
 		return compose.WrapComposeError(err)
 	}
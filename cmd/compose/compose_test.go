@@ -17,10 +17,16 @@
 package compose
 
 import (
+	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/compose-spec/compose-go/types"
+	"github.com/sirupsen/logrus"
 	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose/v2/pkg/api"
 )
 
 func TestFilterServices(t *testing.T) {
@@ -51,3 +57,146 @@ func TestFilterServices(t *testing.T) {
 	_, err = p.GetService("zot")
 	assert.NilError(t, err)
 }
+
+func TestServicesFromFile(t *testing.T) {
+	dir := t.TempDir()
+	composeFile := filepath.Join(dir, "compose.yaml")
+	err := os.WriteFile(composeFile, []byte("services:\n  web:\n    image: nginx\n  db:\n    image: postgres\n  cache:\n    image: redis\n"), 0o644)
+	assert.NilError(t, err)
+	opts := projectOptions{ProjectName: "test", ConfigPaths: []string{composeFile}}
+
+	servicesFile := filepath.Join(dir, "restart.txt")
+	err = os.WriteFile(servicesFile, []byte("# restarted on deploy\nweb\n\n  db # primary\n"), 0o644)
+	assert.NilError(t, err)
+	services, err := opts.withServicesFromFile(context.Background(), []string{"cache"}, servicesFile)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, services, []string{"cache", "web", "db"})
+
+	err = os.WriteFile(servicesFile, []byte("web\nworker\n"), 0o644)
+	assert.NilError(t, err)
+	_, err = opts.withServicesFromFile(context.Background(), nil, servicesFile)
+	assert.ErrorContains(t, err, "invalid service in "+servicesFile)
+
+	err = os.WriteFile(servicesFile, []byte("# nothing\n"), 0o644)
+	assert.NilError(t, err)
+	_, err = opts.withServicesFromFile(context.Background(), nil, servicesFile)
+	assert.ErrorContains(t, err, "no service listed in "+servicesFile)
+}
+
+func TestToProjectCheckingVariables(t *testing.T) {
+	dir := t.TempDir()
+	composeFile := filepath.Join(dir, "compose.yaml")
+	err := os.WriteFile(composeFile, []byte(`services:
+  web:
+    image: nginx:${COMPOSE_TEST_TAG:?tag must be set}
+    environment:
+      TOKEN: ${COMPOSE_TEST_TOKEN?}
+  db:
+    image: postgres:${COMPOSE_TEST_TAG:?tag must be set}
+`), 0o644)
+	assert.NilError(t, err)
+	opts := projectOptions{ProjectName: "test", ConfigPaths: []string{composeFile}}
+
+	_, err = opts.toProjectCheckingVariables(context.Background(), nil)
+	assert.Error(t, err, `2 required variable(s) not set:
+required variable COMPOSE_TEST_TAG is missing a value: tag must be set
+required variable COMPOSE_TEST_TOKEN is missing a value: `)
+
+	t.Setenv("COMPOSE_TEST_TAG", "1.0")
+	t.Setenv("COMPOSE_TEST_TOKEN", "")
+	project, err := opts.toProjectCheckingVariables(context.Background(), nil)
+	assert.NilError(t, err)
+	web, err := project.GetService("web")
+	assert.NilError(t, err)
+	assert.Equal(t, web.Image, "nginx:1.0")
+}
+
+func TestToProjectEnablesProfilesOfDependencies(t *testing.T) {
+	dir := t.TempDir()
+	composeFile := filepath.Join(dir, "compose.yaml")
+	err := os.WriteFile(composeFile, []byte(`services:
+  web:
+    image: nginx
+    profiles: [frontend]
+    depends_on: [api]
+  api:
+    image: alpine
+    profiles: [backend]
+    links: [db]
+  db:
+    image: postgres
+    profiles: [storage]
+  worker:
+    image: alpine
+    profiles: [backend]
+  debugger:
+    image: alpine
+    profiles: [debug]
+`), 0o644)
+	assert.NilError(t, err)
+
+	opts := projectOptions{ProjectName: "test", ConfigPaths: []string{composeFile}}
+	project, err := opts.toProject(context.Background(), nil)
+	assert.NilError(t, err)
+	assert.Equal(t, len(project.Services), 0)
+
+	opts = projectOptions{ProjectName: "test", ConfigPaths: []string{composeFile}}
+	project, err = opts.toProject(context.Background(), []string{"web"})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, project.ServiceNames(), []string{"api", "db", "web"})
+
+	opts = projectOptions{ProjectName: "test", ConfigPaths: []string{composeFile}, Profiles: []string{"debug"}}
+	project, err = opts.toProject(context.Background(), nil)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, project.ServiceNames(), []string{"debugger"})
+}
+
+func TestCheckProjectName(t *testing.T) {
+	name, err := checkProjectName("my-app_1", false)
+	assert.NilError(t, err)
+	assert.Equal(t, name, "my-app_1")
+
+	name, err = checkProjectName("_My.App", false)
+	assert.NilError(t, err)
+	assert.Equal(t, name, "myapp")
+
+	_, err = checkProjectName("_My.App", true)
+	assert.ErrorContains(t, err, `invalid project name "_My.App": must only contain lowercase letters`)
+
+	_, err = checkProjectName("..", false)
+	assert.ErrorContains(t, err, `invalid project name "..": must contain at least one lowercase letter or digit`)
+}
+
+func TestToProjectNormalizesDirectoryName(t *testing.T) {
+	t.Setenv("COMPOSE_PROJECT_NAME", "")
+	dir := filepath.Join(t.TempDir(), "My.App")
+	assert.NilError(t, os.Mkdir(dir, 0o755))
+	composeFile := filepath.Join(dir, "compose.yaml")
+	err := os.WriteFile(composeFile, []byte("services:\n  web:\n    image: nginx\n"), 0o644)
+	assert.NilError(t, err)
+
+	opts := projectOptions{ConfigPaths: []string{composeFile}}
+	project, err := opts.toProject(context.Background(), nil)
+	assert.NilError(t, err)
+	assert.Equal(t, project.Name, "myapp")
+	web, err := project.GetService("web")
+	assert.NilError(t, err)
+	assert.Equal(t, web.CustomLabels[api.ProjectLabel], "myapp")
+
+	opts.StrictProjectName = true
+	_, err = opts.toProject(context.Background(), nil)
+	assert.ErrorContains(t, err, `invalid project name "My.App"`)
+}
+
+func TestParseLogLevel(t *testing.T) {
+	level, err := parseLogLevel("debug")
+	assert.NilError(t, err)
+	assert.Equal(t, level, logrus.DebugLevel)
+
+	level, err = parseLogLevel("WARN")
+	assert.NilError(t, err)
+	assert.Equal(t, level, logrus.WarnLevel)
+
+	_, err = parseLogLevel("trace")
+	assert.Error(t, err, `unsupported --log-level value "trace", must be one of error, warn, info, debug`)
+}
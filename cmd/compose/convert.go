@@ -17,39 +17,54 @@
 package compose
 
 import (
-	"bufio"
+	"bytes"
 	"context"
 	"fmt"
-	"io"
 	"os"
+	"path/filepath"
 	"sort"
 	"strings"
 
 	"github.com/cnabio/cnab-to-oci/remotes"
 	"github.com/compose-spec/compose-go/cli"
+	"github.com/compose-spec/compose-go/loader"
 	"github.com/compose-spec/compose-go/types"
 	"github.com/distribution/distribution/v3/reference"
 	cliconfig "github.com/docker/cli/cli/config"
+	"github.com/docker/cli/templates"
+	"github.com/docker/docker/pkg/urlutil"
 	"github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
 	"github.com/docker/compose/v2/pkg/api"
 	"github.com/docker/compose/v2/pkg/compose"
+	"github.com/docker/compose/v2/pkg/utils"
 )
 
 type convertOptions struct {
 	*projectOptions
-	Format              string
-	Output              string
-	quiet               bool
-	resolveImageDigests bool
-	noInterpolate       bool
-	noNormalize         bool
-	services            bool
-	volumes             bool
-	profiles            bool
-	images              bool
-	hash                string
+	Format               string
+	Output               string
+	quiet                bool
+	resolveImageDigests  bool
+	noInterpolate        bool
+	noNormalize          bool
+	resolvePaths         bool
+	noPathsNormalization bool
+	checkPaths           bool
+	strict               bool
+	skipValidation       bool
+	onlyBuildable        bool
+	models               bool
+	outputDir            string
+	services             bool
+	filter               string
+	profile              string
+	volumes              bool
+	profiles             bool
+	images               bool
+	hash                 string
 }
 
 func convertCommand(p *projectOptions, backend api.Service) *cobra.Command {
@@ -71,23 +86,39 @@ func convertCommand(p *projectOptions, backend api.Service) *cobra.Command {
 			if p.Compatibility {
 				opts.noNormalize = true
 			}
-			return nil
+			if opts.strict && opts.skipValidation {
+				return errors.New("--strict and --skip-validation are incompatible")
+			}
+			if opts.models {
+				if opts.outputDir == "" {
+					return errors.New("--models requires --output-dir")
+				}
+				if opts.Output != "" {
+					return errors.New("--models and --output are incompatible")
+				}
+				if opts.Format != "yaml" && opts.Format != "json" {
+					return errors.New("--models only supports the yaml and json formats")
+				}
+			} else if opts.outputDir != "" {
+				return errors.New("--output-dir can only be used with --models")
+			}
+			return opts.parseFilter()
 		}),
 		RunE: Adapt(func(ctx context.Context, args []string) error {
 			if opts.services {
-				return runServices(opts)
+				return runServices(ctx, opts)
 			}
 			if opts.volumes {
-				return runVolumes(opts)
+				return runVolumes(ctx, opts)
 			}
 			if opts.hash != "" {
-				return runHash(opts)
+				return runHash(ctx, opts)
 			}
 			if opts.profiles {
-				return runProfiles(opts, args)
+				return runProfiles(ctx, opts, args)
 			}
 			if opts.images {
-				return runConfigImages(opts, args)
+				return runConfigImages(ctx, opts, args)
 			}
 
 			return runConvert(ctx, backend, opts, args)
@@ -95,27 +126,37 @@ func convertCommand(p *projectOptions, backend api.Service) *cobra.Command {
 		ValidArgsFunction: serviceCompletion(p),
 	}
 	flags := cmd.Flags()
-	flags.StringVar(&opts.Format, "format", "yaml", "Format the output. Values: [yaml | json]")
+	flags.StringVar(&opts.Format, "format", "yaml", "Format the output. Values: [yaml | json | TEMPLATE]")
 	flags.BoolVar(&opts.resolveImageDigests, "resolve-image-digests", false, "Pin image tags to digests.")
 	flags.BoolVarP(&opts.quiet, "quiet", "q", false, "Only validate the configuration, don't print anything.")
 	flags.BoolVar(&opts.noInterpolate, "no-interpolate", false, "Don't interpolate environment variables.")
+	flags.BoolVar(&p.skipEnvFile, "no-env-resolution", false, "Don't load the environment file, only interpolate variables from the shell environment.")
 	flags.BoolVar(&opts.noNormalize, "no-normalize", false, "Don't normalize compose model.")
+	flags.BoolVar(&opts.resolvePaths, "resolve-paths", true, "Resolve build contexts and bind mount sources to absolute paths.")
+	flags.BoolVar(&opts.noPathsNormalization, "no-paths-normalization", false, "Keep relative bind mount sources as declared, while still resolving build contexts.")
+	flags.BoolVar(&opts.checkPaths, "check-paths", false, "Check that build contexts and bind mount sources exist.")
+	flags.BoolVar(&opts.strict, "strict", false, "Fail on keys not defined by the Compose specification, instead of ignoring them.")
+	flags.BoolVar(&opts.skipValidation, "skip-validation", false, "Don't validate the Compose file against the Compose specification schema.")
+	flags.BoolVar(&opts.onlyBuildable, "only-buildable", false, "Only keep the services declaring a build section, with the resources they use.")
 
 	flags.BoolVar(&opts.services, "services", false, "Print the service names, one per line.")
+	flags.StringVar(&opts.filter, "filter", "", "Filter services printed by --services by a property (supported filters: profile).")
 	flags.BoolVar(&opts.volumes, "volumes", false, "Print the volume names, one per line.")
 	flags.BoolVar(&opts.profiles, "profiles", false, "Print the profile names, one per line.")
 	flags.BoolVar(&opts.images, "images", false, "Print the image names, one per line.")
-	flags.StringVar(&opts.hash, "hash", "", "Print the service config hash, one per line.")
+	flags.StringVar(&opts.hash, "hash", "", `Print the config hash of the services, "*" for all of them or a comma-separated list of services, one per line.`)
 	flags.StringVarP(&opts.Output, "output", "o", "", "Save to file (default to stdout)")
+	flags.BoolVar(&opts.models, "models", false, "Write the model of each service to its own file, with the resources it uses. Requires --output-dir.")
+	flags.StringVar(&opts.outputDir, "output-dir", "", "Directory to write the service models to with --models.")
 
 	return cmd
 }
 
 func runConvert(ctx context.Context, backend api.Service, opts convertOptions, services []string) error {
 	var json []byte
-	project, err := opts.toProject(services,
+	project, err := opts.toProject(ctx, services,
 		cli.WithInterpolation(!opts.noInterpolate),
-		cli.WithResolvedPaths(true),
+		cli.WithResolvedPaths(opts.resolvePaths),
 		cli.WithNormalization(!opts.noNormalize),
 		cli.WithDiscardEnvFile)
 
@@ -123,6 +164,27 @@ func runConvert(ctx context.Context, backend api.Service, opts convertOptions, s
 		return err
 	}
 
+	if opts.checkPaths {
+		err = checkPaths(project)
+		if err != nil {
+			return err
+		}
+	}
+
+	if opts.noPathsNormalization && opts.resolvePaths {
+		// the model was validated while loading the project, so validation isn't run, nor reported, twice
+		declared, err := opts.projectOptions.toProject(ctx, services,
+			cli.WithInterpolation(!opts.noInterpolate),
+			cli.WithResolvedPaths(false),
+			cli.WithNormalization(!opts.noNormalize),
+			cli.WithDiscardEnvFile,
+			cli.WithLoadOptions(loader.WithSkipValidation))
+		if err != nil {
+			return err
+		}
+		keepDeclaredBindSources(project, declared)
+	}
+
 	if opts.resolveImageDigests {
 		configFile := cliconfig.LoadDefaultConfigFile(os.Stderr)
 
@@ -136,10 +198,19 @@ func runConvert(ctx context.Context, backend api.Service, opts convertOptions, s
 		}
 	}
 
-	json, err = backend.Convert(ctx, project, api.ConvertOptions{
-		Format: opts.Format,
-		Output: opts.Output,
-	})
+	if opts.models {
+		return writeServiceModels(ctx, backend, opts, project)
+	}
+
+	if strings.Contains(opts.Format, "{{") {
+		json, err = executeTemplate(opts.Format, project)
+	} else {
+		json, err = backend.Convert(ctx, project, api.ConvertOptions{
+			Format:           opts.Format,
+			Output:           opts.Output,
+			EscapeDollarSign: !opts.noInterpolate,
+		})
+	}
 	if err != nil {
 		return err
 	}
@@ -148,31 +219,206 @@ func runConvert(ctx context.Context, backend api.Service, opts convertOptions, s
 		return nil
 	}
 
-	var out io.Writer = os.Stdout
 	if opts.Output != "" && len(json) > 0 {
-		file, err := os.Create(opts.Output)
+		return os.WriteFile(opts.Output, json, 0o666)
+	}
+	_, err = fmt.Fprint(os.Stdout, string(json))
+	return err
+}
+
+// writeServiceModels writes the model of each service of the project to its own file in the output directory, named
+// after the service, along with the networks, volumes, secrets and configs the service uses
+func writeServiceModels(ctx context.Context, backend api.Service, opts convertOptions, project *types.Project) error {
+	if opts.quiet {
+		return nil
+	}
+	if err := os.MkdirAll(opts.outputDir, 0o755); err != nil {
+		return err
+	}
+	for _, service := range project.Services {
+		content, err := backend.Convert(ctx, serviceModel(project, service), api.ConvertOptions{
+			Format:           opts.Format,
+			EscapeDollarSign: !opts.noInterpolate,
+		})
 		if err != nil {
 			return err
 		}
-		out = bufio.NewWriter(file)
+		path := filepath.Join(opts.outputDir, service.Name+"."+opts.Format)
+		if err := os.WriteFile(path, content, 0o666); err != nil {
+			return err
+		}
 	}
-	_, err = fmt.Fprint(out, string(json))
-	return err
+	return nil
+}
+
+// serviceModel returns a project only made of the service and the resources it uses
+func serviceModel(project *types.Project, service types.ServiceConfig) *types.Project {
+	model := *project
+	model.Services = types.Services{service}
+	model.DisabledServices = nil
+	model.WithoutUnnecessaryResources()
+	return &model
+}
+
+// toProject loads the project with the schema validation selected by the options, keeping only the services which
+// can be built with --only-buildable
+func (opts convertOptions) toProject(ctx context.Context, services []string, po ...cli.ProjectOptionsFn) (*types.Project, error) {
+	project, err := opts.toValidatedProject(ctx, services, po...)
+	if err != nil {
+		return nil, err
+	}
+	if opts.onlyBuildable {
+		keepBuildableServices(project)
+	}
+	return project, nil
+}
+
+// keepBuildableServices removes the services without a build section from the project, along with the references
+// other services make to them and the networks, volumes, secrets and configs only they use
+func keepBuildableServices(project *types.Project) {
+	removed := map[string]bool{}
+	services := types.Services{}
+	for _, service := range project.Services {
+		if service.Build == nil {
+			removed[service.Name] = true
+			continue
+		}
+		services = append(services, service)
+	}
+	// references may be written SERVICE:ALIAS for links, or SERVICE:MODE for volumes_from
+	isRemoved := func(ref string) bool {
+		return removed[strings.SplitN(ref, ":", 2)[0]]
+	}
+	for i, service := range services {
+		for name := range service.DependsOn {
+			if removed[name] {
+				delete(service.DependsOn, name)
+			}
+		}
+		var links []string
+		for _, link := range service.Links {
+			if !isRemoved(link) {
+				links = append(links, link)
+			}
+		}
+		service.Links = links
+		var volumesFrom []string
+		for _, from := range service.VolumesFrom {
+			if !isRemoved(from) {
+				volumesFrom = append(volumesFrom, from)
+			}
+		}
+		service.VolumesFrom = volumesFrom
+		if strings.HasPrefix(service.NetworkMode, types.ServicePrefix) && removed[service.NetworkMode[len(types.ServicePrefix):]] {
+			service.NetworkMode = ""
+		}
+		services[i] = service
+	}
+	project.Services = services
+	project.WithoutUnnecessaryResources()
+}
+
+// keepDeclaredBindSources restores bind mount sources as declared in the Compose file, so relative ones are resolved
+// against the project directory at runtime
+func keepDeclaredBindSources(project *types.Project, declared *types.Project) {
+	for i, service := range project.Services {
+		declaredService, err := declared.GetService(service.Name)
+		if err != nil {
+			continue
+		}
+		for j, volume := range service.Volumes {
+			if volume.Type == types.VolumeTypeBind && j < len(declaredService.Volumes) {
+				project.Services[i].Volumes[j].Source = declaredService.Volumes[j].Source
+			}
+		}
+	}
+}
+
+// executeTemplate renders the Go template format against the resolved project model
+func executeTemplate(format string, project *types.Project) ([]byte, error) {
+	tmpl, err := templates.Parse(format)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid format template")
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, project); err != nil {
+		return nil, err
+	}
+	if !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+		buf.WriteString("\n")
+	}
+	return buf.Bytes(), nil
+}
+
+// checkPaths checks local build contexts exist, as well as bind mount sources the engine is not allowed to create
+func checkPaths(project *types.Project) error {
+	abs := func(path string) string {
+		if filepath.IsAbs(path) {
+			return path
+		}
+		return filepath.Join(project.WorkingDir, path)
+	}
+	for _, service := range project.Services {
+		if service.Build != nil {
+			buildContext := service.Build.Context
+			if !urlutil.IsGitURL(buildContext) && !urlutil.IsURL(buildContext) {
+				if _, err := os.Stat(abs(buildContext)); err != nil {
+					return fmt.Errorf("build context %q of service %q does not exist", buildContext, service.Name)
+				}
+			}
+		}
+		for _, volume := range service.Volumes {
+			if volume.Type != types.VolumeTypeBind || volume.Bind == nil || volume.Bind.CreateHostPath {
+				continue
+			}
+			if _, err := os.Stat(abs(volume.Source)); err != nil {
+				return fmt.Errorf("bind mount source %q of service %q does not exist", volume.Source, service.Name)
+			}
+		}
+	}
+	return nil
+}
+
+func (opts *convertOptions) parseFilter() error {
+	if opts.filter == "" {
+		return nil
+	}
+	if !opts.services {
+		return errors.New("--filter can only be used with --services")
+	}
+	parts := strings.SplitN(opts.filter, "=", 2)
+	if len(parts) != 2 {
+		return errors.New("arguments to --filter should be in form KEY=VAL")
+	}
+	switch parts[0] {
+	case "profile":
+		opts.profile = parts[1]
+	default:
+		return fmt.Errorf("unknown filter %s", parts[0])
+	}
+	return nil
 }
 
-func runServices(opts convertOptions) error {
-	project, err := opts.toProject(nil)
+func runServices(ctx context.Context, opts convertOptions) error {
+	if opts.profile != "" {
+		// enable the profile, so that its services are part of the model
+		opts.Profiles = append(opts.Profiles, opts.profile)
+	}
+	project, err := opts.toProject(ctx, nil)
 	if err != nil {
 		return err
 	}
 	return project.WithServices(project.ServiceNames(), func(s types.ServiceConfig) error {
+		if opts.profile != "" && !utils.StringContains(s.Profiles, opts.profile) {
+			return nil
+		}
 		fmt.Println(s.Name)
 		return nil
 	})
 }
 
-func runVolumes(opts convertOptions) error {
-	project, err := opts.toProject(nil)
+func runVolumes(ctx context.Context, opts convertOptions) error {
+	project, err := opts.toProject(ctx, nil)
 	if err != nil {
 		return err
 	}
@@ -182,28 +428,32 @@ func runVolumes(opts convertOptions) error {
 	return nil
 }
 
-func runHash(opts convertOptions) error {
+func runHash(ctx context.Context, opts convertOptions) error {
 	var services []string
 	if opts.hash != "*" {
 		services = append(services, strings.Split(opts.hash, ",")...)
 	}
-	project, err := opts.toProject(services)
+	project, err := opts.toProject(ctx, services)
+	if err != nil {
+		return err
+	}
+	hashes, err := compose.ProjectServiceHashes(project)
 	if err != nil {
 		return err
 	}
 	for _, s := range project.Services {
-		hash, err := compose.ServiceHash(s)
-		if err != nil {
-			return err
+		// the project keeps the dependencies of the selected services, which are not printed
+		if len(services) > 0 && !utils.StringContains(services, s.Name) {
+			continue
 		}
-		fmt.Printf("%s %s\n", s.Name, hash)
+		fmt.Printf("%s %s\n", s.Name, hashes[s.Name])
 	}
 	return nil
 }
 
-func runProfiles(opts convertOptions, services []string) error {
+func runProfiles(ctx context.Context, opts convertOptions, services []string) error {
 	set := map[string]struct{}{}
-	project, err := opts.toProject(services)
+	project, err := opts.toProject(ctx, services)
 	if err != nil {
 		return err
 	}
@@ -223,8 +473,8 @@ func runProfiles(opts convertOptions, services []string) error {
 	return nil
 }
 
-func runConfigImages(opts convertOptions, services []string) error {
-	project, err := opts.toProject(services)
+func runConfigImages(ctx context.Context, opts convertOptions, services []string) error {
+	project, err := opts.toProject(ctx, services)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,113 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"testing"
+
+	"github.com/compose-spec/compose-go/types"
+	"gotest.tools/v3/assert"
+)
+
+func TestKeepBuildableServices(t *testing.T) {
+	project := &types.Project{
+		Services: types.Services{
+			{
+				Name:  "app",
+				Build: &types.BuildConfig{Context: "."},
+				DependsOn: types.DependsOnConfig{
+					"db":     {Condition: types.ServiceConditionHealthy},
+					"worker": {Condition: types.ServiceConditionStarted},
+				},
+				Links:       []string{"db:database", "worker"},
+				VolumesFrom: []string{"db:ro", "container:cache"},
+				Networks:    map[string]*types.ServiceNetworkConfig{"back": nil},
+				Volumes: []types.ServiceVolumeConfig{
+					{Type: types.VolumeTypeVolume, Source: "assets", Target: "/assets"},
+				},
+			},
+			{
+				Name:        "worker",
+				Build:       &types.BuildConfig{Context: "worker"},
+				NetworkMode: types.ServicePrefix + "db",
+			},
+			{
+				Name:     "db",
+				Image:    "postgres",
+				Networks: map[string]*types.ServiceNetworkConfig{"data": nil},
+				Volumes: []types.ServiceVolumeConfig{
+					{Type: types.VolumeTypeVolume, Source: "pgdata", Target: "/var/lib/postgresql/data"},
+				},
+			},
+		},
+		Networks: types.Networks{"back": {}, "data": {}},
+		Volumes:  types.Volumes{"assets": {}, "pgdata": {}},
+	}
+
+	keepBuildableServices(project)
+	assert.DeepEqual(t, project.ServiceNames(), []string{"app", "worker"})
+
+	app, err := project.GetService("app")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, app.DependsOn, types.DependsOnConfig{"worker": {Condition: types.ServiceConditionStarted}})
+	assert.DeepEqual(t, app.Links, []string{"worker"})
+	assert.DeepEqual(t, app.VolumesFrom, []string{"container:cache"})
+
+	worker, err := project.GetService("worker")
+	assert.NilError(t, err)
+	assert.Equal(t, worker.NetworkMode, "")
+
+	assert.DeepEqual(t, project.Networks, types.Networks{"back": {}})
+	assert.DeepEqual(t, project.Volumes, types.Volumes{"assets": {}})
+}
+
+func TestServiceModel(t *testing.T) {
+	project := &types.Project{
+		Name: "test",
+		Services: types.Services{
+			{
+				Name:     "web",
+				Image:    "nginx",
+				Networks: map[string]*types.ServiceNetworkConfig{"front": nil},
+				Volumes: []types.ServiceVolumeConfig{
+					{Type: types.VolumeTypeVolume, Source: "assets", Target: "/assets"},
+				},
+			},
+			{
+				Name:     "db",
+				Image:    "postgres",
+				Networks: map[string]*types.ServiceNetworkConfig{"back": nil},
+				Volumes: []types.ServiceVolumeConfig{
+					{Type: types.VolumeTypeVolume, Source: "data", Target: "/var/lib/postgresql/data"},
+				},
+			},
+		},
+		Networks: types.Networks{"front": {Name: "test_front"}, "back": {Name: "test_back"}},
+		Volumes:  types.Volumes{"assets": {Name: "test_assets"}, "data": {Name: "test_data"}},
+	}
+
+	model := serviceModel(project, project.Services[0])
+	assert.Equal(t, model.Name, "test")
+	assert.DeepEqual(t, model.ServiceNames(), []string{"web"})
+	assert.DeepEqual(t, model.NetworkNames(), []string{"front"})
+	assert.DeepEqual(t, model.VolumeNames(), []string{"assets"})
+
+	// the project itself is left untouched
+	assert.DeepEqual(t, project.ServiceNames(), []string{"db", "web"})
+	assert.DeepEqual(t, project.NetworkNames(), []string{"back", "front"})
+	assert.DeepEqual(t, project.VolumeNames(), []string{"assets", "data"})
+}
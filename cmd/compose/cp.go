@@ -64,7 +64,7 @@ func copyCommand(p *projectOptions, backend api.Service) *cobra.Command {
 	}
 
 	flags := copyCmd.Flags()
-	flags.IntVar(&opts.index, "index", 0, "Index of the container if there are multiple instances of a service .")
+	flags.IntVar(&opts.index, "index", 0, "Index of the container if there are multiple instances of a service.")
 	flags.BoolVar(&opts.all, "all", false, "Copy to all the containers of the service.")
 	flags.MarkHidden("all")                                                                                                      //nolint:errcheck
 	flags.MarkDeprecated("all", "By default all the containers of the service will get the source file/directory to be copied.") //nolint:errcheck
@@ -75,7 +75,7 @@ func copyCommand(p *projectOptions, backend api.Service) *cobra.Command {
 }
 
 func runCopy(ctx context.Context, backend api.Service, opts copyOptions) error {
-	name, err := opts.toProjectName()
+	name, err := opts.toProjectName(ctx)
 	if err != nil {
 		return err
 	}
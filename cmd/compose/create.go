@@ -25,20 +25,25 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/docker/compose/v2/pkg/api"
+	"github.com/docker/compose/v2/pkg/utils"
 )
 
 type createOptions struct {
-	Build         bool
-	noBuild       bool
-	removeOrphans bool
-	ignoreOrphans bool
-	forceRecreate bool
-	noRecreate    bool
-	recreateDeps  bool
-	noInherit     bool
-	timeChanged   bool
-	timeout       int
-	quietPull     bool
+	Build                 bool
+	noBuild               bool
+	removeOrphans         bool
+	ignoreOrphans         bool
+	forceRecreate         bool
+	noRecreate            bool
+	recreateDeps          bool
+	recreateOnLabelChange bool
+	noInherit             bool
+	timeChanged           bool
+	timeout               int
+	quietPull             bool
+	quietBuild            bool
+	preferBuild           bool
+	recreateOrder         string
 }
 
 func createCommand(p *projectOptions, backend api.Service) *cobra.Command {
@@ -56,6 +61,7 @@ func createCommand(p *projectOptions, backend api.Service) *cobra.Command {
 			return nil
 		}),
 		RunE: p.WithProject(func(ctx context.Context, project *types.Project) error {
+			opts.ignoreOrphans = utils.StringToBool(project.Environment["COMPOSE_IGNORE_ORPHANS"])
 			return backend.Create(ctx, project, api.CreateOptions{
 				RemoveOrphans:        opts.removeOrphans,
 				IgnoreOrphans:        opts.ignoreOrphans,
@@ -73,6 +79,7 @@ func createCommand(p *projectOptions, backend api.Service) *cobra.Command {
 	flags.BoolVar(&opts.noBuild, "no-build", false, "Don't build an image, even if it's missing.")
 	flags.BoolVar(&opts.forceRecreate, "force-recreate", false, "Recreate containers even if their configuration and image haven't changed.")
 	flags.BoolVar(&opts.noRecreate, "no-recreate", false, "If containers already exist, don't recreate them. Incompatible with --force-recreate.")
+	flags.BoolVar(&opts.removeOrphans, "remove-orphans", false, "Remove containers for services not defined in the Compose file.")
 	return cmd
 }
 
@@ -83,6 +90,9 @@ func (opts createOptions) recreateStrategy() string {
 	if opts.forceRecreate {
 		return api.RecreateForce
 	}
+	if opts.recreateOnLabelChange {
+		return api.RecreateLabelsChanged
+	}
 	return api.RecreateDiverged
 }
 
@@ -93,6 +103,9 @@ func (opts createOptions) dependenciesRecreateStrategy() string {
 	if opts.recreateDeps {
 		return api.RecreateForce
 	}
+	if opts.recreateOnLabelChange {
+		return api.RecreateLabelsChanged
+	}
 	return api.RecreateDiverged
 }
 
@@ -0,0 +1,91 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/docker/go-units"
+	"github.com/spf13/cobra"
+
+	"github.com/docker/compose/v2/cmd/formatter"
+	"github.com/docker/compose/v2/pkg/api"
+)
+
+type dfOptions struct {
+	*projectOptions
+	Format string
+}
+
+func dfCommand(p *projectOptions, backend api.Service) *cobra.Command {
+	opts := dfOptions{
+		projectOptions: p,
+	}
+	dfCmd := &cobra.Command{
+		Use:   "df [SERVICE...]",
+		Short: "Show the disk space used by the project",
+		RunE: Adapt(func(ctx context.Context, args []string) error {
+			return runDf(ctx, backend, opts, args)
+		}),
+		ValidArgsFunction: serviceCompletion(p),
+	}
+	dfCmd.Flags().StringVar(&opts.Format, "format", "pretty", "Format the output. Values: [pretty | json].")
+	return dfCmd
+}
+
+type diskUsageView struct {
+	Resources []api.DiskUsageSummary
+	Total     int64
+}
+
+func runDf(ctx context.Context, backend api.Service, opts dfOptions, services []string) error {
+	projectName, err := opts.toProjectName(ctx)
+	if err != nil {
+		return err
+	}
+
+	resources, err := backend.DiskUsage(ctx, projectName, api.DiskUsageOptions{
+		Services: services,
+	})
+	if err != nil {
+		return err
+	}
+
+	view := diskUsageView{
+		Resources: resources,
+	}
+	for _, r := range resources {
+		view.Total += r.Size
+	}
+	if view.Resources == nil {
+		view.Resources = []api.DiskUsageSummary{}
+	}
+
+	return formatter.Print(view, opts.Format, os.Stdout, func(w io.Writer) {
+		for _, r := range view.Resources {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", r.Type, r.Name, humanSize(r.Size))
+		}
+		_, _ = fmt.Fprintf(w, "\t%s\t%s\n", "TOTAL", humanSize(view.Total))
+	}, "TYPE", "NAME", "SIZE")
+}
+
+func humanSize(size int64) string {
+	return units.HumanSizeWithPrecision(float64(size), 3)
+}
@@ -0,0 +1,70 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/compose-spec/compose-go/types"
+	"github.com/spf13/cobra"
+
+	"github.com/docker/compose/v2/cmd/formatter"
+	"github.com/docker/compose/v2/pkg/api"
+)
+
+type diffOptions struct {
+	*projectOptions
+	Format string
+}
+
+func diffCommand(p *projectOptions, backend api.Service) *cobra.Command {
+	opts := diffOptions{
+		projectOptions: p,
+	}
+	diffCmd := &cobra.Command{
+		Use:   "diff [SERVICE...]",
+		Short: "Show the changes required to bring the running project to the configured state",
+		RunE: p.WithServices(func(ctx context.Context, project *types.Project, services []string) error {
+			return runDiff(ctx, backend, opts, project, services)
+		}),
+		ValidArgsFunction: serviceCompletion(p),
+	}
+	diffCmd.Flags().StringVar(&opts.Format, "format", "pretty", "Format the output. Values: [pretty | json].")
+	return diffCmd
+}
+
+func runDiff(ctx context.Context, backend api.Service, opts diffOptions, project *types.Project, services []string) error {
+	changes, err := backend.Diff(ctx, project, api.DiffOptions{
+		Services: services,
+	})
+	if err != nil {
+		return err
+	}
+	if changes == nil {
+		changes = []api.DiffSummary{}
+	}
+
+	return formatter.Print(changes, opts.Format, os.Stdout, func(w io.Writer) {
+		for _, c := range changes {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", c.Type, c.Name, c.Action, strings.Join(c.Fields, ", "))
+		}
+	}, "TYPE", "NAME", "ACTION", "CHANGES")
+}
@@ -24,6 +24,7 @@ import (
 
 	"github.com/compose-spec/compose-go/types"
 	"github.com/docker/compose/v2/pkg/utils"
+	"github.com/moby/sys/signal"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -37,7 +38,10 @@ type downOptions struct {
 	timeChanged   bool
 	timeout       int
 	volumes       bool
+	preserve      []string
 	images        string
+	networks      bool
+	signal        string
 }
 
 func downCommand(p *projectOptions, backend api.Service) *cobra.Command {
@@ -54,6 +58,14 @@ func downCommand(p *projectOptions, backend api.Service) *cobra.Command {
 					return fmt.Errorf("invalid value for --rmi: %q", opts.images)
 				}
 			}
+			if len(opts.preserve) > 0 && !opts.volumes {
+				return fmt.Errorf("--preserve can only be used with --volumes")
+			}
+			if opts.signal != "" {
+				if _, err := signal.ParseSignal(opts.signal); err != nil {
+					return err
+				}
+			}
 			return nil
 		}),
 		RunE: Adapt(func(ctx context.Context, args []string) error {
@@ -67,6 +79,9 @@ func downCommand(p *projectOptions, backend api.Service) *cobra.Command {
 	flags.BoolVar(&opts.removeOrphans, "remove-orphans", removeOrphans, "Remove containers for services not defined in the Compose file.")
 	flags.IntVarP(&opts.timeout, "timeout", "t", 10, "Specify a shutdown timeout in seconds")
 	flags.BoolVarP(&opts.volumes, "volumes", "v", false, " Remove named volumes declared in the `volumes` section of the Compose file and anonymous volumes attached to containers.")
+	flags.StringArrayVar(&opts.preserve, "preserve", []string{}, "Keep the named volume when used with --volumes.")
+	flags.BoolVar(&opts.networks, "remove-networks", true, "Remove networks created for the project. Use --remove-networks=false to only remove containers.")
+	flags.StringVar(&opts.signal, "signal", "", "Signal sent to stop containers, instead of the stop_signal of their service (default SIGTERM).")
 	flags.StringVar(&opts.images, "rmi", "", `Remove images used by services. "local" remove only images that don't have a custom tag ("local"|"all")`)
 	flags.SetNormalizeFunc(func(f *pflag.FlagSet, name string) pflag.NormalizedName {
 		switch name {
@@ -80,15 +95,21 @@ func downCommand(p *projectOptions, backend api.Service) *cobra.Command {
 }
 
 func runDown(ctx context.Context, backend api.Service, opts downOptions) error {
-	name := opts.ProjectName
+	var name string
 	var project *types.Project
 	if opts.ProjectName == "" {
-		p, err := opts.toProject(nil)
+		p, err := opts.toProject(ctx, nil)
 		if err != nil {
 			return err
 		}
 		project = p
 		name = p.Name
+	} else {
+		n, err := opts.toProjectName(ctx)
+		if err != nil {
+			return err
+		}
+		name = n
 	}
 
 	var timeout *time.Duration
@@ -97,10 +118,13 @@ func runDown(ctx context.Context, backend api.Service, opts downOptions) error {
 		timeout = &timeoutValue
 	}
 	return backend.Down(ctx, name, api.DownOptions{
-		RemoveOrphans: opts.removeOrphans,
-		Project:       project,
-		Timeout:       timeout,
-		Images:        opts.images,
-		Volumes:       opts.volumes,
+		RemoveOrphans:   opts.removeOrphans,
+		Project:         project,
+		Timeout:         timeout,
+		Images:          opts.images,
+		Volumes:         opts.volumes,
+		PreserveVolumes: opts.preserve,
+		KeepNetworks:    !opts.networks,
+		Signal:          opts.signal,
 	})
 }
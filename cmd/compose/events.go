@@ -17,18 +17,90 @@
 package compose
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
+	"text/template"
+	"time"
 
+	"github.com/docker/cli/templates"
 	"github.com/docker/compose/v2/pkg/api"
+	"github.com/docker/compose/v2/pkg/utils"
 
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
 type eventsOpts struct {
 	*composeOptions
-	json bool
+	json     bool
+	format   string
+	template *template.Template
+	filter   []string
+	services []string
+	events   []string
+}
+
+// eventView exposes an event to the --format template
+type eventView struct {
+	Time       time.Time
+	Type       string
+	Service    string
+	Container  string
+	Action     string
+	Attributes map[string]string
+}
+
+func (opts *eventsOpts) parseFormat() error {
+	if opts.format == "" {
+		return nil
+	}
+	if opts.json {
+		return errors.New("--json and --format are incompatible")
+	}
+	tmpl, err := templates.Parse(opts.format)
+	if err != nil {
+		return errors.Wrap(err, "invalid format template")
+	}
+	opts.template = tmpl
+	return nil
+}
+
+// renderEvent renders an event with the --format template, as a single line
+func renderEvent(tmpl *template.Template, event api.Event) (string, error) {
+	var buf bytes.Buffer
+	err := tmpl.Execute(&buf, eventView{
+		Time:       event.Timestamp,
+		Type:       "container",
+		Service:    event.Service,
+		Container:  event.Container,
+		Action:     event.Status,
+		Attributes: event.Attributes,
+	})
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+func (opts *eventsOpts) parseFilters() error {
+	for _, f := range opts.filter {
+		parts := strings.SplitN(f, "=", 2)
+		if len(parts) != 2 {
+			return errors.New("arguments to --filter should be in form KEY=VAL")
+		}
+		switch parts[0] {
+		case "event":
+			opts.events = append(opts.events, parts[1])
+		case "service":
+			opts.services = append(opts.services, parts[1])
+		default:
+			return fmt.Errorf("unknown filter %s", parts[0])
+		}
+	}
+	return nil
 }
 
 func eventsCommand(p *projectOptions, backend api.Service) *cobra.Command {
@@ -40,6 +112,12 @@ func eventsCommand(p *projectOptions, backend api.Service) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "events [options] [--] [SERVICE...]",
 		Short: "Receive real time events from containers.",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.parseFilters(); err != nil {
+				return err
+			}
+			return opts.parseFormat()
+		},
 		RunE: Adapt(func(ctx context.Context, args []string) error {
 			return runEvents(ctx, backend, opts, args)
 		}),
@@ -47,19 +125,45 @@ func eventsCommand(p *projectOptions, backend api.Service) *cobra.Command {
 	}
 
 	cmd.Flags().BoolVar(&opts.json, "json", false, "Output events as a stream of json objects")
+	cmd.Flags().StringVar(&opts.format, "format", "", "Format events using a Go template, one line per event. Fields: Service, Container, Action, Type, Time, Attributes.")
+	cmd.Flags().StringArrayVar(&opts.filter, "filter", []string{}, "Filter events by a property (supported filters: event, service).")
 	return cmd
 }
 
 func runEvents(ctx context.Context, backend api.Service, opts eventsOpts, services []string) error {
-	project, err := opts.toProjectName()
+	project, err := opts.toProjectName(ctx)
 	if err != nil {
 		return err
 	}
 
+	if len(opts.services) > 0 {
+		if len(services) > 0 {
+			var selected []string
+			for _, s := range services {
+				if utils.StringContains(opts.services, s) {
+					selected = append(selected, s)
+				}
+			}
+			if len(selected) == 0 {
+				return fmt.Errorf("no service matches both arguments and --filter service")
+			}
+			services = selected
+		} else {
+			services = opts.services
+		}
+	}
+
 	return backend.Events(ctx, project, api.EventsOptions{
 		Services: services,
+		Events:   opts.events,
 		Consumer: func(event api.Event) error {
-			if opts.json {
+			if opts.template != nil {
+				line, err := renderEvent(opts.template, event)
+				if err != nil {
+					return err
+				}
+				fmt.Println(line)
+			} else if opts.json {
 				marshal, err := json.Marshal(map[string]interface{}{
 					"time":       event.Timestamp,
 					"type":       "container",
@@ -0,0 +1,56 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose/v2/pkg/api"
+)
+
+func TestRenderEvent(t *testing.T) {
+	event := api.Event{
+		Timestamp:  time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC),
+		Service:    "web",
+		Container:  "project-web-1",
+		Status:     "start",
+		Attributes: map[string]string{"image": "nginx"},
+	}
+
+	opts := eventsOpts{format: `{{.Service}} {{.Action}} {{.Attributes.image}} {{.Time.Format "15:04"}}{{println}}`}
+	assert.NilError(t, opts.parseFormat())
+	line, err := renderEvent(opts.template, event)
+	assert.NilError(t, err)
+	assert.Equal(t, line, "web start nginx 10:00")
+
+	opts = eventsOpts{format: "{{json .Type}} {{.Container}}"}
+	assert.NilError(t, opts.parseFormat())
+	line, err = renderEvent(opts.template, event)
+	assert.NilError(t, err)
+	assert.Equal(t, line, `"container" project-web-1`)
+}
+
+func TestParseEventsFormat(t *testing.T) {
+	opts := eventsOpts{format: "{{.Service"}
+	assert.ErrorContains(t, opts.parseFormat(), "invalid format template")
+
+	opts = eventsOpts{format: "{{.Service}}", json: true}
+	assert.Error(t, opts.parseFormat(), "--json and --format are incompatible")
+}
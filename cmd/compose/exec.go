@@ -18,6 +18,8 @@ package compose
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/compose-spec/compose-go/types"
 	"github.com/docker/cli/cli"
@@ -41,6 +43,7 @@ type execOpts struct {
 	index       int
 	privileged  bool
 	interactive bool
+	reuse       bool
 }
 
 func execCommand(p *projectOptions, dockerCli command.Cli, backend api.Service) *cobra.Command {
@@ -56,7 +59,7 @@ func execCommand(p *projectOptions, dockerCli command.Cli, backend api.Service)
 		PreRunE: Adapt(func(ctx context.Context, args []string) error {
 			opts.service = args[0]
 			opts.command = args[1:]
-			return nil
+			return validateUser(opts.user)
 		}),
 		RunE: Adapt(func(ctx context.Context, args []string) error {
 			return runExec(ctx, backend, opts)
@@ -71,6 +74,7 @@ func execCommand(p *projectOptions, dockerCli command.Cli, backend api.Service)
 	runCmd.Flags().StringVarP(&opts.user, "user", "u", "", "Run the command as this user.")
 	runCmd.Flags().BoolVarP(&opts.noTty, "no-TTY", "T", !dockerCli.Out().IsTerminal(), "Disable pseudo-TTY allocation. By default `docker compose exec` allocates a TTY.")
 	runCmd.Flags().StringVarP(&opts.workingDir, "workdir", "w", "", "Path to workdir directory for this command.")
+	runCmd.Flags().BoolVar(&opts.reuse, "session-reuse", false, "Resolve the service container once and reuse it for subsequent execs run with --session-reuse.")
 
 	runCmd.Flags().BoolVarP(&opts.interactive, "interactive", "i", true, "Keep STDIN open even if not attached.")
 	runCmd.Flags().MarkHidden("interactive") //nolint:errcheck
@@ -81,12 +85,29 @@ func execCommand(p *projectOptions, dockerCli command.Cli, backend api.Service)
 	return runCmd
 }
 
+// validateUser checks user is empty or set as USER[:GROUP], each being a name or a numeric id
+func validateUser(user string) error {
+	if user == "" {
+		return nil
+	}
+	parts := strings.Split(user, ":")
+	if len(parts) > 2 {
+		return fmt.Errorf("invalid --user option %q. Should be USER[:GROUP], as names or numeric ids", user)
+	}
+	for _, part := range parts {
+		if part == "" || strings.ContainsAny(part, " \t\n/") {
+			return fmt.Errorf("invalid --user option %q. Should be USER[:GROUP], as names or numeric ids", user)
+		}
+	}
+	return nil
+}
+
 func runExec(ctx context.Context, backend api.Service, opts execOpts) error {
-	projectName, err := opts.toProjectName()
+	projectName, err := opts.toProjectName(ctx)
 	if err != nil {
 		return err
 	}
-	projectOptions, err := opts.composeOptions.toProjectOptions()
+	projectOptions, err := opts.composeOptions.toProjectOptions(ctx)
 	if err != nil {
 		return err
 	}
@@ -95,16 +116,17 @@ func runExec(ctx context.Context, backend api.Service, opts execOpts) error {
 		return v, ok
 	}
 	execOpts := api.RunOptions{
-		Service:     opts.service,
-		Command:     opts.command,
-		Environment: compose.ToMobyEnv(types.NewMappingWithEquals(opts.environment).Resolve(lookupFn)),
-		Tty:         !opts.noTty,
-		User:        opts.user,
-		Privileged:  opts.privileged,
-		Index:       opts.index,
-		Detach:      opts.detach,
-		WorkingDir:  opts.workingDir,
-		Interactive: opts.interactive,
+		Service:      opts.service,
+		Command:      opts.command,
+		Environment:  compose.ToMobyEnv(types.NewMappingWithEquals(opts.environment).Resolve(lookupFn)),
+		Tty:          !opts.noTty,
+		User:         opts.user,
+		Privileged:   opts.privileged,
+		Index:        opts.index,
+		Detach:       opts.detach,
+		WorkingDir:   opts.workingDir,
+		Interactive:  opts.interactive,
+		ReuseSession: opts.reuse,
 	}
 
 	exitCode, err := backend.Exec(ctx, projectName, execOpts)
@@ -0,0 +1,32 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestValidateUser(t *testing.T) {
+	for _, user := range []string{"", "root", "1000", "1000:1000", "app:staff", "www-data:33"} {
+		assert.NilError(t, validateUser(user), user)
+	}
+	for _, user := range []string{":", "1000:", ":1000", "a:b:c", "my user", "../root"} {
+		assert.ErrorContains(t, validateUser(user), "invalid --user option", user)
+	}
+}
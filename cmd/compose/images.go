@@ -21,11 +21,14 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/docker/docker/pkg/stringid"
 	"github.com/docker/go-units"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
 	"github.com/docker/compose/v2/cmd/formatter"
@@ -35,7 +38,65 @@ import (
 
 type imageOptions struct {
 	*projectOptions
-	Quiet bool
+	Quiet      bool
+	Digests    bool
+	Filter     []string
+	dangling   *bool
+	references []string
+}
+
+func (opts *imageOptions) parseFilters() error {
+	for _, f := range opts.Filter {
+		parts := strings.SplitN(f, "=", 2)
+		if len(parts) != 2 {
+			return errors.New("arguments to --filter should be in form KEY=VAL")
+		}
+		switch parts[0] {
+		case "dangling":
+			dangling, err := strconv.ParseBool(parts[1])
+			if err != nil {
+				return fmt.Errorf("invalid filter 'dangling=%s'", parts[1])
+			}
+			opts.dangling = &dangling
+		case "reference":
+			if _, err := path.Match(parts[1], ""); err != nil {
+				return fmt.Errorf("invalid reference filter %q", parts[1])
+			}
+			opts.references = append(opts.references, parts[1])
+		default:
+			return fmt.Errorf("unknown filter %s", parts[0])
+		}
+	}
+	return nil
+}
+
+func (opts imageOptions) filterImages(images []api.ImageSummary) []api.ImageSummary {
+	var filtered []api.ImageSummary
+	for _, img := range images {
+		if opts.dangling != nil && (img.Repository == "") != *opts.dangling {
+			continue
+		}
+		if len(opts.references) > 0 && !matchReference(img, opts.references) {
+			continue
+		}
+		filtered = append(filtered, img)
+	}
+	return filtered
+}
+
+func matchReference(img api.ImageSummary, patterns []string) bool {
+	if img.Repository == "" {
+		return false
+	}
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, img.Repository); ok {
+			return true
+		}
+		if ok, _ := path.Match(pattern, img.Repository+":"+img.Tag); ok {
+			return true
+		}
+	}
+	return false
 }
 
 func imagesCommand(p *projectOptions, backend api.Service) *cobra.Command {
@@ -45,17 +106,22 @@ func imagesCommand(p *projectOptions, backend api.Service) *cobra.Command {
 	imgCmd := &cobra.Command{
 		Use:   "images [SERVICE...]",
 		Short: "List images used by the created containers",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return opts.parseFilters()
+		},
 		RunE: Adapt(func(ctx context.Context, args []string) error {
 			return runImages(ctx, backend, opts, args)
 		}),
 		ValidArgsFunction: serviceCompletion(p),
 	}
 	imgCmd.Flags().BoolVarP(&opts.Quiet, "quiet", "q", false, "Only display IDs")
+	imgCmd.Flags().BoolVar(&opts.Digests, "digests", false, "Show the repository digest of the images")
+	imgCmd.Flags().StringArrayVar(&opts.Filter, "filter", []string{}, "Filter images by a property (supported filters: dangling, reference).")
 	return imgCmd
 }
 
 func runImages(ctx context.Context, backend api.Service, opts imageOptions, services []string) error {
-	projectName, err := opts.toProjectName()
+	projectName, err := opts.toProjectName(ctx)
 	if err != nil {
 		return err
 	}
@@ -67,6 +133,10 @@ func runImages(ctx context.Context, backend api.Service, opts imageOptions, serv
 		return err
 	}
 
+	if len(opts.Filter) > 0 {
+		images = opts.filterImages(images)
+	}
+
 	if opts.Quiet {
 		ids := []string{}
 		for _, img := range images {
@@ -88,21 +158,30 @@ func runImages(ctx context.Context, backend api.Service, opts imageOptions, serv
 		return images[i].ContainerName < images[j].ContainerName
 	})
 
+	headers := []string{"Container", "Repository", "Tag", "Image Id", "Size"}
+	if opts.Digests {
+		headers = []string{"Container", "Repository", "Tag", "Digest", "Image Id", "Size"}
+	}
 	return formatter.Print(images, formatter.PRETTY, os.Stdout,
 		func(w io.Writer) {
 			for _, img := range images {
 				id := stringid.TruncateID(img.ID)
 				size := units.HumanSizeWithPrecision(float64(img.Size), 3)
-				repo := img.Repository
-				if repo == "" {
-					repo = "<none>"
-				}
-				tag := img.Tag
-				if tag == "" {
-					tag = "<none>"
+				repo := orNone(img.Repository)
+				tag := orNone(img.Tag)
+				if opts.Digests {
+					_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", img.ContainerName, repo, tag, orNone(img.Digest), id, size)
+					continue
 				}
 				_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", img.ContainerName, repo, tag, id, size)
 			}
 		},
-		"Container", "Repository", "Tag", "Image Id", "Size")
+		headers...)
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "<none>"
+	}
+	return s
 }
@@ -19,6 +19,8 @@ package compose
 import (
 	"context"
 
+	"github.com/compose-spec/compose-go/errdefs"
+	"github.com/compose-spec/compose-go/types"
 	"github.com/spf13/cobra"
 
 	"github.com/docker/compose/v2/pkg/api"
@@ -26,7 +28,9 @@ import (
 
 type killOptions struct {
 	*projectOptions
-	signal string
+	signal        string
+	removeOrphans bool
+	yes           bool
 }
 
 func killCommand(p *projectOptions, backend api.Service) *cobra.Command {
@@ -44,19 +48,38 @@ func killCommand(p *projectOptions, backend api.Service) *cobra.Command {
 
 	flags := cmd.Flags()
 	flags.StringVarP(&opts.signal, "signal", "s", "SIGKILL", "SIGNAL to send to the container.")
+	flags.BoolVar(&opts.removeOrphans, "remove-orphans", false, "Also kill containers for services not defined in the Compose file.")
+	flags.BoolVarP(&opts.yes, "yes", "y", false, "Don't ask to confirm killing containers for services not defined in the Compose file.")
 
 	return cmd
 }
 
 func runKill(ctx context.Context, backend api.Service, opts killOptions, services []string) error {
-	projectName, err := opts.toProjectName()
-	if err != nil {
-		return err
+	var project *types.Project
+	if opts.ProjectName == "" || len(opts.ConfigPaths) > 0 {
+		// the Compose file, when available, tells the project's containers apart from orphans
+		var err error
+		project, err = opts.toProject(ctx, nil)
+		if err != nil && !errdefs.IsNotFoundError(err) {
+			return err
+		}
+	}
+	var name string
+	if project != nil {
+		name = project.Name
+	} else {
+		projectName, err := opts.toProjectName(ctx)
+		if err != nil {
+			return err
+		}
+		name = projectName
 	}
 
-	return backend.Kill(ctx, projectName, api.KillOptions{
-		Services: services,
-		Signal:   opts.signal,
+	return backend.Kill(ctx, name, api.KillOptions{
+		Project:       project,
+		Services:      services,
+		Signal:        opts.signal,
+		RemoveOrphans: opts.removeOrphans,
+		Force:         opts.yes,
 	})
-
 }
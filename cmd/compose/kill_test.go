@@ -0,0 +1,62 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose/v2/pkg/api"
+	"github.com/docker/compose/v2/pkg/mocks"
+)
+
+func TestKillWithoutComposeFile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	wd, err := os.Getwd()
+	assert.NilError(t, err)
+	defer os.Chdir(wd) // nolint:errcheck
+	assert.NilError(t, os.Chdir(t.TempDir()))
+	t.Setenv("COMPOSE_PROJECT_NAME", "test")
+
+	backend := mocks.NewMockService(ctrl)
+	backend.EXPECT().Kill(gomock.Any(), "test", api.KillOptions{Signal: "SIGKILL"}).Return(nil)
+
+	opts := killOptions{projectOptions: &projectOptions{}, signal: "SIGKILL"}
+	err = runKill(context.Background(), backend, opts, nil)
+	assert.NilError(t, err)
+}
+
+func TestKillInvalidComposeFile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dir := t.TempDir()
+	composeFile := filepath.Join(dir, "compose.yaml")
+	assert.NilError(t, os.WriteFile(composeFile, []byte("services:\n  web: [\n"), 0o600))
+
+	backend := mocks.NewMockService(ctrl)
+	opts := killOptions{projectOptions: &projectOptions{ConfigPaths: []string{composeFile}}, signal: "SIGKILL"}
+	err := runKill(context.Background(), backend, opts, nil)
+	assert.Assert(t, err != nil)
+}
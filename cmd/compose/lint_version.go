@@ -0,0 +1,222 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/compose-spec/compose-go/errdefs"
+	"github.com/compose-spec/compose-go/loader"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/docker/compose/v2/cmd/formatter"
+	"github.com/docker/compose/v2/pkg/compose"
+)
+
+type lintVersionOptions struct {
+	*projectOptions
+	Format string
+}
+
+// legacyAttribute is an attribute of a Compose file which is obsolete or deprecated by the Compose specification,
+// with a suggestion to migrate it
+type legacyAttribute struct {
+	File       string
+	Attribute  string
+	Issue      string
+	Suggestion string
+}
+
+func lintVersionCommand(p *projectOptions) *cobra.Command {
+	opts := lintVersionOptions{
+		projectOptions: p,
+	}
+	lintCmd := &cobra.Command{
+		Use:   "lint-version",
+		Short: "Report the legacy attributes of the Compose files and how to upgrade them",
+		Args:  cobra.NoArgs,
+		RunE: Adapt(func(ctx context.Context, args []string) error {
+			return runLintVersion(ctx, opts)
+		}),
+	}
+	lintCmd.Flags().StringVar(&opts.Format, "format", "pretty", "Format the output. Values: [pretty | json].")
+	return lintCmd
+}
+
+func runLintVersion(ctx context.Context, opts lintVersionOptions) error {
+	options, err := opts.toProjectOptions(ctx)
+	if err != nil {
+		return compose.WrapComposeError(err)
+	}
+	if len(options.ConfigPaths) == 0 {
+		return compose.WrapComposeError(errors.Wrap(errdefs.ErrNotFound, "no configuration file provided"))
+	}
+
+	attributes := []legacyAttribute{}
+	for _, path := range options.ConfigPaths {
+		var content []byte
+		if path == "-" {
+			content, err = io.ReadAll(os.Stdin)
+		} else {
+			content, err = os.ReadFile(path)
+		}
+		if err != nil {
+			return err
+		}
+		dict, err := loader.ParseYAML(content)
+		if err != nil {
+			return err
+		}
+		for _, attribute := range legacyAttributes(dict) {
+			attribute.File = path
+			attributes = append(attributes, attribute)
+		}
+	}
+
+	if format := strings.ToLower(opts.Format); format != formatter.PRETTY && format != "" {
+		return formatter.Print(attributes, opts.Format, os.Stdout, nil)
+	}
+	for _, a := range attributes {
+		fmt.Printf("%s: %s: %s\n    %s\n", a.File, a.Attribute, a.Issue, a.Suggestion)
+	}
+	return nil
+}
+
+// legacyAttributes analyses a parsed Compose file and lists its legacy attributes: the obsolete `version`, `links`,
+// `external_links` and `volumes_from` of services, and the deprecated `external.name` of top-level resources
+func legacyAttributes(dict map[string]interface{}) []legacyAttribute {
+	var attributes []legacyAttribute
+	if version, ok := dict["version"]; ok {
+		attributes = append(attributes, legacyAttribute{
+			Attribute:  "version",
+			Issue:      "`version` is obsolete, the Compose specification applies to all Compose files",
+			Suggestion: fmt.Sprintf("remove `version: %q`, it is ignored", fmt.Sprint(version)),
+		})
+	}
+
+	services, _ := dict["services"].(map[string]interface{})
+	for _, name := range sortedKeys(services) {
+		service, _ := services[name].(map[string]interface{})
+		prefix := "services." + name + "."
+		if links := stringItems(service["links"]); len(links) > 0 {
+			attributes = append(attributes, legacyAttribute{
+				Attribute:  prefix + "links",
+				Issue:      "`links` is legacy, services on a common network reach each other by name",
+				Suggestion: linksSuggestion(links),
+			})
+		}
+		if links := stringItems(service["external_links"]); len(links) > 0 {
+			attributes = append(attributes, legacyAttribute{
+				Attribute: prefix + "external_links",
+				Issue:     "`external_links` is legacy, containers on a common network reach each other by name",
+				Suggestion: fmt.Sprintf("remove `external_links` and connect %s to the network of %s, declared with "+
+					"`external: true`", name, strings.Join(links, ", ")),
+			})
+		}
+		if volumesFrom := stringItems(service["volumes_from"]); len(volumesFrom) > 0 {
+			attributes = append(attributes, legacyAttribute{
+				Attribute:  prefix + "volumes_from",
+				Issue:      "`volumes_from` is legacy, volumes are shared by name",
+				Suggestion: volumesFromSuggestion(name, volumesFrom),
+			})
+		}
+	}
+
+	for _, kind := range []string{"networks", "volumes", "secrets", "configs"} {
+		resources, _ := dict[kind].(map[string]interface{})
+		for _, name := range sortedKeys(resources) {
+			resource, _ := resources[name].(map[string]interface{})
+			external, _ := resource["external"].(map[string]interface{})
+			if externalName, ok := external["name"]; ok {
+				attributes = append(attributes, legacyAttribute{
+					Attribute:  kind + "." + name + ".external.name",
+					Issue:      "`external.name` is deprecated",
+					Suggestion: fmt.Sprintf("replace `external: {name: %s}` with `name: %s` and `external: true`", externalName, externalName),
+				})
+			}
+		}
+	}
+	return attributes
+}
+
+// linksSuggestion suggests the `depends_on` and network aliases replacing links declared as `SERVICE[:ALIAS]`
+func linksSuggestion(links []string) string {
+	var dependencies, aliases []string
+	for _, link := range links {
+		target, alias, hasAlias := strings.Cut(link, ":")
+		dependencies = append(dependencies, target)
+		if hasAlias && alias != target {
+			aliases = append(aliases, fmt.Sprintf("add `%s` to the network `aliases` of %s", alias, target))
+		}
+	}
+	suggestion := fmt.Sprintf("replace `links` with `depends_on: [%s]` to keep the start order", strings.Join(dependencies, ", "))
+	if len(aliases) > 0 {
+		suggestion += ", and " + strings.Join(aliases, ", ")
+	}
+	return suggestion
+}
+
+// volumesFromSuggestion suggests named volumes replacing `volumes_from` entries declared as
+// `[service:|container:]NAME[:ro|rw]`
+func volumesFromSuggestion(service string, volumesFrom []string) string {
+	var services, containers []string
+	for _, source := range volumesFrom {
+		parts := strings.Split(source, ":")
+		switch {
+		case parts[0] == "container" && len(parts) > 1:
+			containers = append(containers, parts[1])
+		case parts[0] == "service" && len(parts) > 1:
+			services = append(services, parts[1])
+		default:
+			services = append(services, parts[0])
+		}
+	}
+	var suggestions []string
+	if len(services) > 0 {
+		suggestions = append(suggestions, fmt.Sprintf("declare the volumes of %s in the top-level `volumes` and mount "+
+			"them in %s too", strings.Join(services, ", "), service))
+	}
+	if len(containers) > 0 {
+		suggestions = append(suggestions, fmt.Sprintf("mount the volumes of container %s by name, declared with "+
+			"`external: true`", strings.Join(containers, ", ")))
+	}
+	return "replace `volumes_from`: " + strings.Join(suggestions, ", and ")
+}
+
+func sortedKeys(dict map[string]interface{}) []string {
+	var keys []string
+	for k := range dict {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func stringItems(value interface{}) []string {
+	items, _ := value.([]interface{})
+	var strs []string
+	for _, item := range items {
+		strs = append(strs, fmt.Sprint(item))
+	}
+	return strs
+}
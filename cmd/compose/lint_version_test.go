@@ -0,0 +1,87 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"testing"
+
+	"github.com/compose-spec/compose-go/loader"
+	"gotest.tools/v3/assert"
+)
+
+func TestLegacyAttributes(t *testing.T) {
+	dict, err := loader.ParseYAML([]byte(`version: "2"
+services:
+  web:
+    image: nginx
+    links:
+      - db:database
+      - cache
+    volumes_from:
+      - data:ro
+      - container:legacy
+  db:
+    image: postgres
+  cache:
+    image: redis
+  data:
+    image: busybox
+networks:
+  front:
+    external:
+      name: shared
+`))
+	assert.NilError(t, err)
+
+	attributes := legacyAttributes(dict)
+	assert.DeepEqual(t, attributes, []legacyAttribute{
+		{
+			Attribute:  "version",
+			Issue:      "`version` is obsolete, the Compose specification applies to all Compose files",
+			Suggestion: "remove `version: \"2\"`, it is ignored",
+		},
+		{
+			Attribute: "services.web.links",
+			Issue:     "`links` is legacy, services on a common network reach each other by name",
+			Suggestion: "replace `links` with `depends_on: [db, cache]` to keep the start order, " +
+				"and add `database` to the network `aliases` of db",
+		},
+		{
+			Attribute: "services.web.volumes_from",
+			Issue:     "`volumes_from` is legacy, volumes are shared by name",
+			Suggestion: "replace `volumes_from`: declare the volumes of data in the top-level `volumes` and mount " +
+				"them in web too, and mount the volumes of container legacy by name, declared with `external: true`",
+		},
+		{
+			Attribute:  "networks.front.external.name",
+			Issue:      "`external.name` is deprecated",
+			Suggestion: "replace `external: {name: shared}` with `name: shared` and `external: true`",
+		},
+	})
+}
+
+func TestLegacyAttributesUpToDate(t *testing.T) {
+	dict, err := loader.ParseYAML([]byte(`services:
+  web:
+    image: nginx
+    depends_on: [db]
+  db:
+    image: postgres
+`))
+	assert.NilError(t, err)
+	assert.Equal(t, len(legacyAttributes(dict)), 0)
+}
@@ -25,6 +25,7 @@ import (
 
 	"github.com/docker/compose/v2/cmd/formatter"
 
+	formatter2 "github.com/docker/cli/cli/command/formatter"
 	"github.com/docker/cli/opts"
 	"github.com/spf13/cobra"
 
@@ -48,7 +49,7 @@ func listCommand(backend api.Service) *cobra.Command {
 		}),
 		ValidArgsFunction: noCompletion(),
 	}
-	lsCmd.Flags().StringVar(&opts.Format, "format", "pretty", "Format the output. Values: [pretty | json].")
+	lsCmd.Flags().StringVar(&opts.Format, "format", "pretty", "Format the output. Values: [pretty | json | TEMPLATE].")
 	lsCmd.Flags().BoolVarP(&opts.Quiet, "quiet", "q", false, "Only display IDs.")
 	lsCmd.Flags().Var(&opts.Filter, "filter", "Filter output based on conditions provided.")
 	lsCmd.Flags().BoolVarP(&opts.All, "all", "a", false, "Show all stopped Compose projects")
@@ -90,9 +91,12 @@ func runList(ctx context.Context, backend api.Service, opts lsOptions) error {
 	}
 
 	view := viewFromStackList(stackList)
+	if strings.Contains(opts.Format, "{{") && opts.Format != formatter.TemplateLegacyJSON {
+		return writeStackTemplate(os.Stdout, opts.Format, view)
+	}
 	return formatter.Print(view, opts.Format, os.Stdout, func(w io.Writer) {
 		for _, stack := range view {
-			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", stack.Name, stack.Status, stack.ConfigFiles)
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", stack.Name, stack.Status, strings.Join(stack.ConfigFiles, ","))
 		}
 	}, "NAME", "STATUS", "CONFIG FILES")
 }
@@ -100,7 +104,7 @@ func runList(ctx context.Context, backend api.Service, opts lsOptions) error {
 type stackView struct {
 	Name        string
 	Status      string
-	ConfigFiles string
+	ConfigFiles []string
 }
 
 func viewFromStackList(stackList []api.Stack) []stackView {
@@ -109,8 +113,63 @@ func viewFromStackList(stackList []api.Stack) []stackView {
 		retList[i] = stackView{
 			Name:        s.Name,
 			Status:      strings.TrimSpace(fmt.Sprintf("%s %s", s.Status, s.Reason)),
-			ConfigFiles: s.ConfigFiles,
+			ConfigFiles: splitConfigFiles(s.ConfigFiles),
 		}
 	}
 	return retList
 }
+
+// splitConfigFiles parses the comma-separated paths of the config_files label
+func splitConfigFiles(configFiles string) []string {
+	files := []string{}
+	for _, f := range strings.Split(configFiles, ",") {
+		if f != "" {
+			files = append(files, f)
+		}
+	}
+	return files
+}
+
+func writeStackTemplate(out io.Writer, format string, stacks []stackView) error {
+	ctx := formatter2.Context{
+		Output: out,
+		Format: formatter2.Format(format),
+	}
+	header := stackContext{}
+	header.Header = formatter2.SubHeaderContext{
+		"Name":        "NAME",
+		"Status":      "STATUS",
+		"ConfigFiles": "CONFIG FILES",
+	}
+	return ctx.Write(&header, func(format func(subContext formatter2.SubContext) error) error {
+		for _, stack := range stacks {
+			if err := format(&stackContext{s: stack}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// stackContext exposes a project to the --format template
+type stackContext struct {
+	formatter2.HeaderContext
+	s stackView
+}
+
+func (c *stackContext) MarshalJSON() ([]byte, error) {
+	return formatter2.MarshalJSON(c)
+}
+
+func (c *stackContext) Name() string {
+	return c.s.Name
+}
+
+func (c *stackContext) Status() string {
+	return c.s.Status
+}
+
+// ConfigFiles returns the project config files, comma-separated as in the table output
+func (c *stackContext) ConfigFiles() string {
+	return strings.Join(c.s.ConfigFiles, ",")
+}
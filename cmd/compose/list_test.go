@@ -0,0 +1,46 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/docker/compose/v2/cmd/formatter"
+	"github.com/docker/compose/v2/pkg/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListConfigFiles(t *testing.T) {
+	view := viewFromStackList([]api.Stack{
+		{Name: "app", Status: "running(2)", ConfigFiles: "/src/app/compose.yaml,/src/app/compose.override.yaml"},
+		{Name: "legacy", Status: "exited(1)", ConfigFiles: ""},
+	})
+	assert.Equal(t, []string{"/src/app/compose.yaml", "/src/app/compose.override.yaml"}, view[0].ConfigFiles)
+	assert.Equal(t, []string{}, view[1].ConfigFiles)
+
+	var out bytes.Buffer
+	err := writeStackTemplate(&out, `{{.Name}} {{.ConfigFiles}}`, view)
+	assert.NoError(t, err)
+	assert.Equal(t, "app /src/app/compose.yaml,/src/app/compose.override.yaml\nlegacy \n", out.String())
+
+	out.Reset()
+	err = formatter.Print(view, formatter.JSON, &out, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, `[{"Name":"app","Status":"running(2)","ConfigFiles":["/src/app/compose.yaml","/src/app/compose.override.yaml"]},`+
+		`{"Name":"legacy","Status":"exited(1)","ConfigFiles":[]}]`+"\n", out.String())
+}
@@ -18,10 +18,18 @@ package compose
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/docker/compose/v2/cmd/formatter"
 
+	"github.com/compose-spec/compose-go/types"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
 	"github.com/docker/compose/v2/pkg/api"
@@ -30,13 +38,21 @@ import (
 type logsOptions struct {
 	*projectOptions
 	composeOptions
-	follow     bool
-	tail       string
-	since      string
-	until      string
-	noColor    bool
-	noPrefix   bool
-	timestamps bool
+	follow        bool
+	tail          string
+	since         string
+	until         string
+	noColor       bool
+	noPrefix      bool
+	timestamps    bool
+	mergeDeps     bool
+	outputDir     string
+	grep          string
+	grepInvert    bool
+	prefixWidth   int
+	format        string
+	flushInterval time.Duration
+	index         int
 }
 
 func logsCommand(p *projectOptions, backend api.Service) *cobra.Command {
@@ -57,23 +73,162 @@ func logsCommand(p *projectOptions, backend api.Service) *cobra.Command {
 	flags.StringVar(&opts.until, "until", "", "Show logs before a timestamp (e.g. 2013-01-02T13:23:37Z) or relative (e.g. 42m for 42 minutes)")
 	flags.BoolVar(&opts.noColor, "no-color", false, "Produce monochrome output.")
 	flags.BoolVar(&opts.noPrefix, "no-log-prefix", false, "Don't print prefix in logs.")
+	flags.IntVar(&opts.prefixWidth, "prefix-width", 0, "Pad log prefixes to this width. 0 pads them to the longest container name.")
 	flags.BoolVarP(&opts.timestamps, "timestamps", "t", false, "Show timestamps.")
 	flags.StringVar(&opts.tail, "tail", "all", "Number of lines to show from the end of the logs for each container.")
+	flags.IntVar(&opts.index, "index", 0, "Only show logs of the container with this index in each service, all replicas if 0.")
+	flags.BoolVar(&opts.mergeDeps, "merge-dependencies", false, "Include logs of the services' dependencies, and sort logs of all containers by time.")
+	flags.StringVar(&opts.outputDir, "output-dir", "", "Write logs of each service to its own file in this directory.")
+	flags.StringVar(&opts.grep, "grep", "", "Only show log lines matching this regular expression.")
+	flags.BoolVar(&opts.grepInvert, "grep-invert", false, "Only show log lines not matching the --grep regular expression.")
+	flags.StringVar(&opts.format, "format", formatter.PRETTY, "Format the output. Values: [pretty | json]. json prints each log line as a JSON object.")
+	flags.DurationVar(&opts.flushInterval, "flush-interval", 0, "Buffer the output and flush it at this interval, for high log volumes. 0 flushes each line immediately.")
 	return logsCmd
 }
 
 func runLogs(ctx context.Context, backend api.Service, opts logsOptions, services []string) error {
-	projectName, err := opts.toProjectName()
+	projectName, err := opts.toProjectName(ctx)
 	if err != nil {
 		return err
 	}
-	consumer := formatter.NewLogConsumer(ctx, os.Stdout, !opts.noColor, !opts.noPrefix)
-	return backend.Logs(ctx, projectName, consumer, api.LogOptions{
+	var pattern *regexp.Regexp
+	if opts.prefixWidth < 0 {
+		return fmt.Errorf("invalid --prefix-width %d, must not be negative", opts.prefixWidth)
+	}
+	if opts.grep != "" {
+		pattern, err = regexp.Compile(opts.grep)
+		if err != nil {
+			return errors.Wrapf(err, "invalid --grep pattern %q", opts.grep)
+		}
+	} else if opts.grepInvert {
+		return fmt.Errorf("--grep-invert requires --grep")
+	}
+	switch opts.format {
+	case formatter.PRETTY:
+	case formatter.JSON:
+		if opts.outputDir != "" {
+			return fmt.Errorf("--format json cannot be combined with --output-dir")
+		}
+		// the timestamp is reported in its own field
+		opts.timestamps = true
+	default:
+		return fmt.Errorf("invalid --format option %q. Should be one of pretty or json", opts.format)
+	}
+	if opts.index < 0 {
+		return fmt.Errorf("invalid --index %d, must not be negative", opts.index)
+	}
+	if opts.flushInterval < 0 {
+		return fmt.Errorf("invalid --flush-interval %s, must not be negative", opts.flushInterval)
+	}
+	if opts.flushInterval > 0 && opts.outputDir != "" {
+		return fmt.Errorf("--flush-interval cannot be combined with --output-dir")
+	}
+	if opts.mergeDeps && len(services) > 0 {
+		project, err := opts.toProject(ctx, nil)
+		if err != nil {
+			return err
+		}
+		services, err = withDependencies(project, services)
+		if err != nil {
+			return err
+		}
+	}
+	var (
+		consumer api.LogConsumer
+		files    *formatter.FileLogConsumer
+		out      io.Writer = os.Stdout
+	)
+	if opts.flushInterval > 0 {
+		w := formatter.NewFlushingWriter(os.Stdout, opts.flushInterval)
+		defer w.Close() // nolint: errcheck
+		out = w
+	}
+	if opts.outputDir != "" {
+		files, err = newLogFilesConsumer(ctx, backend, projectName, services, opts.index, opts.outputDir)
+		if err != nil {
+			return err
+		}
+		consumer = files
+	} else if opts.format == formatter.JSON {
+		consumer = formatter.NewJSONLogConsumer(ctx, out)
+	} else {
+		consumer = formatter.NewLogConsumer(ctx, out, !opts.noColor, !opts.noPrefix, opts.prefixWidth)
+	}
+	if pattern != nil {
+		consumer = formatter.NewFilteredLogConsumer(consumer, pattern, opts.grepInvert)
+	}
+	err = backend.Logs(ctx, projectName, consumer, api.LogOptions{
 		Services:   services,
 		Follow:     opts.follow,
 		Tail:       opts.tail,
 		Since:      opts.since,
 		Until:      opts.until,
 		Timestamps: opts.timestamps,
+		Merge:      opts.mergeDeps,
+		Index:      opts.index,
+	})
+	if files != nil {
+		if closeErr := files.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+// newLogFilesConsumer creates a log consumer writing to <service>.log, or <service>-<index>.log for scaled services,
+// files being created or truncated for all the selected containers, only the replicas with the given index if not zero
+func newLogFilesConsumer(ctx context.Context, backend api.Service, projectName string, services []string, index int, dir string) (*formatter.FileLogConsumer, error) {
+	containers, err := backend.Ps(ctx, projectName, api.PsOptions{
+		All:      true,
+		Services: services,
+	})
+	if err != nil {
+		return nil, err
+	}
+	replicas := map[string]int{}
+	for _, c := range containers {
+		if _, ok := replicaIndex(c.Name); ok {
+			replicas[c.Service]++
+		}
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	consumer := formatter.NewFileLogConsumer(ctx, dir, func(container, service string) string {
+		if index, ok := replicaIndex(container); ok && replicas[service] > 1 {
+			return fmt.Sprintf("%s-%d.log", service, index)
+		}
+		return service + ".log"
+	})
+	for _, c := range containers {
+		if i, ok := replicaIndex(c.Name); !ok || (index > 0 && i != index) {
+			continue
+		}
+		if err := consumer.Open(c.Name, c.Service); err != nil {
+			consumer.Close() // nolint: errcheck
+			return nil, err
+		}
+	}
+	return consumer, nil
+}
+
+// replicaIndex returns the replica number of a service container, one-off containers having none
+func replicaIndex(container string) (int, bool) {
+	i := strings.LastIndexAny(container, "-_")
+	if i < 0 {
+		return 0, false
+	}
+	index, err := strconv.Atoi(container[i+1:])
+	return index, err == nil
+}
+
+// withDependencies returns the selected services along with their dependencies, in dependency order
+func withDependencies(project *types.Project, services []string) ([]string, error) {
+	var names []string
+	err := project.WithServices(services, func(service types.ServiceConfig) error {
+		names = append(names, service.Name)
+		return nil
 	})
+	return names, err
 }
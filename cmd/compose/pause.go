@@ -44,7 +44,7 @@ func pauseCommand(p *projectOptions, backend api.Service) *cobra.Command {
 }
 
 func runPause(ctx context.Context, backend api.Service, opts pauseOptions, services []string) error {
-	project, err := opts.toProjectName()
+	project, err := opts.toProjectName(ctx)
 	if err != nil {
 		return err
 	}
@@ -74,7 +74,7 @@ func unpauseCommand(p *projectOptions, backend api.Service) *cobra.Command {
 }
 
 func runUnPause(ctx context.Context, backend api.Service, opts unpauseOptions, services []string) error {
-	project, err := opts.toProjectName()
+	project, err := opts.toProjectName(ctx)
 	if err != nil {
 		return err
 	}
@@ -19,10 +19,14 @@ package compose
 import (
 	"context"
 	"fmt"
+	"io"
+	"os"
+	"sort"
 	"strconv"
 
 	"github.com/spf13/cobra"
 
+	"github.com/docker/compose/v2/cmd/formatter"
 	"github.com/docker/compose/v2/pkg/api"
 )
 
@@ -31,6 +35,18 @@ type portOptions struct {
 	port     int
 	protocol string
 	index    int
+	all      bool
+	format   string
+}
+
+// publishedPort describes a container port bound on the host
+type publishedPort struct {
+	Service       string
+	Container     string
+	URL           string
+	TargetPort    int
+	PublishedPort int
+	Protocol      string
 }
 
 func portCommand(p *projectOptions, backend api.Service) *cobra.Command {
@@ -40,8 +56,19 @@ func portCommand(p *projectOptions, backend api.Service) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "port [options] [--] SERVICE PRIVATE_PORT",
 		Short: "Print the public port for a port binding.",
-		Args:  cobra.MinimumNArgs(2),
+		Args: func(cmd *cobra.Command, args []string) error {
+			if opts.all {
+				return nil
+			}
+			return cobra.MinimumNArgs(2)(cmd, args)
+		},
 		PreRunE: Adapt(func(ctx context.Context, args []string) error {
+			if opts.index < 0 {
+				return fmt.Errorf("invalid index %d: must be a positive number", opts.index)
+			}
+			if opts.all {
+				return nil
+			}
 			port, err := strconv.Atoi(args[1])
 			if err != nil {
 				return err
@@ -50,17 +77,22 @@ func portCommand(p *projectOptions, backend api.Service) *cobra.Command {
 			return nil
 		}),
 		RunE: Adapt(func(ctx context.Context, args []string) error {
+			if opts.all {
+				return runPortAll(ctx, backend, opts, args)
+			}
 			return runPort(ctx, backend, opts, args[0])
 		}),
 		ValidArgsFunction: serviceCompletion(p),
 	}
 	cmd.Flags().StringVar(&opts.protocol, "protocol", "tcp", "tcp or udp")
-	cmd.Flags().IntVar(&opts.index, "index", 1, "index of the container if service has multiple replicas")
+	cmd.Flags().IntVar(&opts.index, "index", 0, "index of the container if service has multiple replicas (default: the lowest-numbered replica)")
+	cmd.Flags().BoolVar(&opts.all, "all", false, "Print all published ports of the project's running containers")
+	cmd.Flags().StringVar(&opts.format, "format", "pretty", "Format the output, used with --all. Values: [pretty | json]")
 	return cmd
 }
 
 func runPort(ctx context.Context, backend api.Service, opts portOptions, service string) error {
-	projectName, err := opts.toProjectName()
+	projectName, err := opts.toProjectName(ctx)
 	if err != nil {
 		return err
 	}
@@ -75,3 +107,44 @@ func runPort(ctx context.Context, backend api.Service, opts portOptions, service
 	fmt.Printf("%s:%d\n", ip, port)
 	return nil
 }
+
+func runPortAll(ctx context.Context, backend api.Service, opts portOptions, services []string) error {
+	projectName, err := opts.toProjectName(ctx)
+	if err != nil {
+		return err
+	}
+	containers, err := backend.Ps(ctx, projectName, api.PsOptions{
+		Services: services,
+	})
+	if err != nil {
+		return err
+	}
+
+	ports := []publishedPort{}
+	for _, c := range containers {
+		for _, p := range c.Publishers {
+			if p.PublishedPort == 0 {
+				continue
+			}
+			ports = append(ports, publishedPort{
+				Service:       c.Service,
+				Container:     c.Name,
+				URL:           p.URL,
+				TargetPort:    p.TargetPort,
+				PublishedPort: p.PublishedPort,
+				Protocol:      p.Protocol,
+			})
+		}
+	}
+	sort.SliceStable(ports, func(i, j int) bool {
+		return ports[i].Container < ports[j].Container
+	})
+
+	return formatter.Print(ports, opts.format, os.Stdout,
+		func(w io.Writer) {
+			for _, p := range ports {
+				_, _ = fmt.Fprintf(w, "%s\t%s\t%s:%d->%d/%s\n", p.Service, p.Container, p.URL, p.PublishedPort, p.TargetPort, p.Protocol)
+			}
+		},
+		"SERVICE", "CONTAINER", "PORT")
+}
@@ -24,12 +24,14 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/docker/compose/v2/cmd/formatter"
 	"github.com/docker/compose/v2/pkg/utils"
 	"github.com/docker/docker/api/types"
 
 	formatter2 "github.com/docker/cli/cli/command/formatter"
+	"github.com/moby/term"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
@@ -44,6 +46,8 @@ type psOptions struct {
 	Services bool
 	Filter   string
 	Status   []string
+	Watch    bool
+	Interval time.Duration
 }
 
 func (p *psOptions) parseFilter() error {
@@ -73,6 +77,9 @@ func psCommand(p *projectOptions, backend api.Service) *cobra.Command {
 		Use:   "ps [SERVICE...]",
 		Short: "List containers",
 		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if opts.Watch && opts.Interval <= 0 {
+				return fmt.Errorf("invalid --interval %s, must be positive", opts.Interval)
+			}
 			return opts.parseFilter()
 		},
 		RunE: Adapt(func(ctx context.Context, args []string) error {
@@ -81,20 +88,48 @@ func psCommand(p *projectOptions, backend api.Service) *cobra.Command {
 		ValidArgsFunction: serviceCompletion(p),
 	}
 	flags := psCmd.Flags()
-	flags.StringVar(&opts.Format, "format", "pretty", "Format the output. Values: [pretty | json]")
+	flags.StringVar(&opts.Format, "format", "pretty", "Format the output. Values: [pretty | json | TEMPLATE]")
 	flags.StringVar(&opts.Filter, "filter", "", "Filter services by a property (supported filters: status).")
 	flags.StringArrayVar(&opts.Status, "status", []string{}, "Filter services by status. Values: [paused | restarting | removing | running | dead | created | exited]")
 	flags.BoolVarP(&opts.Quiet, "quiet", "q", false, "Only display IDs")
 	flags.BoolVar(&opts.Services, "services", false, "Display services")
 	flags.BoolVarP(&opts.All, "all", "a", false, "Show all stopped containers (including those created by the run command)")
+	flags.BoolVar(&opts.Watch, "watch", false, "Refresh the output at each interval until interrupted. With --format json, print a snapshot per line.")
+	flags.DurationVar(&opts.Interval, "interval", 2*time.Second, "Refresh interval for --watch")
 	return psCmd
 }
 
 func runPs(ctx context.Context, backend api.Service, services []string, opts psOptions) error {
-	projectName, err := opts.toProjectName()
+	projectName, err := opts.toProjectName(ctx)
 	if err != nil {
 		return err
 	}
+	if !opts.Watch {
+		return printPs(ctx, backend, projectName, services, opts, os.Stdout)
+	}
+
+	// re-render the table in place when on a terminal, while other formats are printed as a stream of snapshots
+	redraw := term.IsTerminal(os.Stdout.Fd()) && (opts.Format == "" || opts.Format == formatter.PRETTY || opts.Format == formatter2.TableFormatKey)
+	ticker := time.NewTicker(opts.Interval)
+	defer ticker.Stop()
+	for {
+		if redraw {
+			fmt.Fprint(os.Stdout, "\033[H\033[2J") // nolint:errcheck
+		}
+		err = printPs(ctx, backend, projectName, services, opts, os.Stdout)
+		if err != nil || ctx.Err() != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// printPs prints the containers of the project, as selected by options, to out
+func printPs(ctx context.Context, backend api.Service, projectName string, services []string, opts psOptions, out io.Writer) error {
 	containers, err := backend.Ps(ctx, projectName, api.PsOptions{
 		All:      opts.All,
 		Services: services,
@@ -123,7 +158,7 @@ SERVICES:
 
 	if opts.Quiet {
 		for _, c := range containers {
-			fmt.Println(c.ID)
+			fmt.Fprintln(out, c.ID) // nolint:errcheck
 		}
 		return nil
 	}
@@ -135,11 +170,19 @@ SERVICES:
 				services = append(services, s.Service)
 			}
 		}
-		fmt.Println(strings.Join(services, "\n"))
+		fmt.Fprintln(out, strings.Join(services, "\n")) // nolint:errcheck
 		return nil
 	}
 
-	return formatter.Print(containers, opts.Format, os.Stdout,
+	format := opts.Format
+	switch {
+	case format == formatter2.TableFormatKey:
+		format = formatter.PRETTY
+	case strings.Contains(format, "{{") && format != formatter.TemplateLegacyJSON:
+		return writeTemplate(out, format, containers)
+	}
+
+	return formatter.Print(containers, format, out,
 		writer(containers),
 		"NAME", "COMMAND", "SERVICE", "STATUS", "PORTS")
 }
@@ -147,19 +190,129 @@ SERVICES:
 func writer(containers []api.ContainerSummary) func(w io.Writer) {
 	return func(w io.Writer) {
 		for _, container := range containers {
-			ports := displayablePorts(container)
-			status := container.State
-			if status == "running" && container.Health != "" {
-				status = fmt.Sprintf("%s (%s)", container.State, container.Health)
-			} else if status == "exited" || status == "dead" {
-				status = fmt.Sprintf("%s (%d)", container.State, container.ExitCode)
-			}
 			command := formatter2.Ellipsis(container.Command, 20)
-			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", container.Name, strconv.Quote(command), container.Service, status, ports)
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", container.Name, strconv.Quote(command), container.Service, displayableStatus(container), displayablePorts(container))
 		}
 	}
 }
 
+func writeTemplate(out io.Writer, format string, containers []api.ContainerSummary) error {
+	ctx := formatter2.Context{
+		Output: out,
+		Format: formatter2.Format(format),
+	}
+	header := containerContext{}
+	header.Header = formatter2.SubHeaderContext{
+		"ID":        "CONTAINER ID",
+		"Name":      "NAME",
+		"Image":     "IMAGE",
+		"Command":   "COMMAND",
+		"Project":   "PROJECT",
+		"Service":   "SERVICE",
+		"CreatedAt": "CREATED AT",
+		"State":     "STATE",
+		"Health":    "HEALTH",
+		"ExitCode":  "EXIT CODE",
+		"Status":    "STATUS",
+		"Ports":     "PORTS",
+		"Networks":  "NETWORKS",
+		"Mounts":    "MOUNTS",
+		"Labels":    "LABELS",
+	}
+	return ctx.Write(&header, func(format func(subContext formatter2.SubContext) error) error {
+		for _, container := range containers {
+			if err := format(&containerContext{c: container}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// containerContext exposes a container summary to the --format template
+type containerContext struct {
+	formatter2.HeaderContext
+	c api.ContainerSummary
+}
+
+func (c *containerContext) MarshalJSON() ([]byte, error) {
+	return formatter2.MarshalJSON(c)
+}
+
+func (c *containerContext) ID() string {
+	return c.c.ID
+}
+
+func (c *containerContext) Name() string {
+	return c.c.Name
+}
+
+func (c *containerContext) Image() string {
+	return c.c.Image
+}
+
+func (c *containerContext) Command() string {
+	return strconv.Quote(formatter2.Ellipsis(c.c.Command, 20))
+}
+
+func (c *containerContext) Project() string {
+	return c.c.Project
+}
+
+func (c *containerContext) Service() string {
+	return c.c.Service
+}
+
+// CreatedAt is the creation time of the container, in the same format as `docker ps`
+func (c *containerContext) CreatedAt() string {
+	return time.Unix(c.c.Created, 0).String()
+}
+
+func (c *containerContext) State() string {
+	return c.c.State
+}
+
+func (c *containerContext) Health() string {
+	return c.c.Health
+}
+
+func (c *containerContext) ExitCode() int {
+	return c.c.ExitCode
+}
+
+func (c *containerContext) Status() string {
+	return displayableStatus(c.c)
+}
+
+func (c *containerContext) Ports() string {
+	return displayablePorts(c.c)
+}
+
+// Networks lists the names of the networks the container is connected to, sorted
+func (c *containerContext) Networks() []string {
+	return c.c.Networks
+}
+
+// Mounts lists the names of the volumes mounted in the container, or the source path of bind mounts
+func (c *containerContext) Mounts() []string {
+	return c.c.Mounts
+}
+
+// Labels exposes all labels of the container, so a single one can be selected with `{{index .Labels "key"}}`
+func (c *containerContext) Labels() map[string]string {
+	return c.c.Labels
+}
+
+func displayableStatus(c api.ContainerSummary) string {
+	switch {
+	case c.State == "running" && c.Health != "":
+		return fmt.Sprintf("%s (%s)", c.State, c.Health)
+	case c.State == "exited" || c.State == "dead":
+		return fmt.Sprintf("%s (%d)", c.State, c.ExitCode)
+	}
+	return c.State
+}
+
 func filterByStatus(containers []api.ContainerSummary, statuses []string) []api.ContainerSummary {
 	var filtered []api.ContainerSummary
 	for _, c := range containers {
@@ -17,10 +17,12 @@
 package compose
 
 import (
+	"bytes"
 	"context"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/docker/compose/v2/pkg/api"
 	"github.com/docker/compose/v2/pkg/mocks"
@@ -82,3 +84,120 @@ func TestPsPretty(t *testing.T) {
 
 	assert.Contains(t, string(output), "8080/tcp, 8443/tcp")
 }
+
+func TestPsTableFormats(t *testing.T) {
+	ctx := context.Background()
+	origStdout := os.Stdout
+	t.Cleanup(func() {
+		os.Stdout = origStdout
+	})
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	backend := mocks.NewMockService(ctrl)
+	backend.EXPECT().
+		Ps(gomock.Eq(ctx), gomock.Any(), gomock.Any()).
+		Return([]api.ContainerSummary{{ID: "abc123", Name: "ABC"}}, nil).
+		AnyTimes()
+
+	for _, format := range []string{"pretty", "table"} {
+		f, err := os.Create(filepath.Join(t.TempDir(), "output.txt"))
+		assert.NoError(t, err)
+		os.Stdout = f
+
+		opts := psOptions{projectOptions: &projectOptions{ProjectName: "test"}, Format: format}
+		err = runPs(ctx, backend, nil, opts)
+		assert.NoError(t, err)
+		assert.NoError(t, f.Close())
+
+		output, err := os.ReadFile(f.Name())
+		assert.NoError(t, err)
+		assert.Contains(t, string(output), "NAME", format)
+		assert.Contains(t, string(output), "ABC", format)
+	}
+}
+
+func TestPsTemplate(t *testing.T) {
+	containers := []api.ContainerSummary{
+		{Name: "web-1", Service: "web", State: "running", Health: "healthy"},
+		{Name: "db-1", Service: "db", State: "exited", ExitCode: 1},
+	}
+
+	var out bytes.Buffer
+	err := writeTemplate(&out, `table {{.Name}}\t{{.Health}}\t{{.Status}}`, containers)
+	assert.NoError(t, err)
+	assert.Equal(t, "NAME      HEALTH    STATUS\nweb-1     healthy   running (healthy)\ndb-1                exited (1)\n", out.String())
+
+	out.Reset()
+	err = writeTemplate(&out, `{{.Service}}={{.Health}}`, containers)
+	assert.NoError(t, err)
+	assert.Equal(t, "web=healthy\ndb=\n", out.String())
+
+	containers = []api.ContainerSummary{
+		{
+			Name:     "web-1",
+			Networks: []string{"project_backend", "project_default"},
+			Labels:   map[string]string{"com.docker.compose.service": "web", "my-label": "test"},
+		},
+	}
+	out.Reset()
+	err = writeTemplate(&out, `{{json .Networks}} {{index .Labels "my-label"}}`, containers)
+	assert.NoError(t, err)
+	assert.Equal(t, "[\"project_backend\",\"project_default\"] test\n", out.String())
+
+	out.Reset()
+	err = writeTemplate(&out, `{{json .Labels}}`, containers)
+	assert.NoError(t, err)
+	assert.Equal(t, "{\"com.docker.compose.service\":\"web\",\"my-label\":\"test\"}\n", out.String())
+
+	containers = []api.ContainerSummary{
+		{
+			Name:   "web-1",
+			Image:  "nginx:alpine",
+			Mounts: []string{"project_data", "/src/static"},
+		},
+	}
+	out.Reset()
+	err = writeTemplate(&out, `{{.Image}} {{json .Mounts}}`, containers)
+	assert.NoError(t, err)
+	assert.Equal(t, "nginx:alpine [\"project_data\",\"/src/static\"]\n", out.String())
+}
+
+func TestPsWatch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	origStdout := os.Stdout
+	t.Cleanup(func() {
+		os.Stdout = origStdout
+	})
+	f, err := os.Create(filepath.Join(t.TempDir(), "output.txt"))
+	assert.NoError(t, err)
+	defer func() { _ = f.Close() }()
+	os.Stdout = f
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	states := []string{"created", "running"}
+	calls := 0
+	backend := mocks.NewMockService(ctrl)
+	backend.EXPECT().
+		Ps(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, projectName string, options api.PsOptions) ([]api.ContainerSummary, error) {
+			state := states[calls]
+			calls++
+			if calls == len(states) {
+				cancel()
+			}
+			return []api.ContainerSummary{{Name: "web-1", Service: "web", State: state}}, nil
+		}).Times(2)
+
+	opts := psOptions{projectOptions: &projectOptions{ProjectName: "test"}, Format: "{{.Service}}={{.State}}", Watch: true, Interval: time.Millisecond}
+	err = runPs(ctx, backend, nil, opts)
+	assert.NoError(t, err)
+
+	output, err := os.ReadFile(f.Name())
+	assert.NoError(t, err)
+	assert.Equal(t, "web=created\nweb=running\n", string(output))
+}
@@ -0,0 +1,72 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/compose-spec/compose-go/cli"
+	"github.com/spf13/cobra"
+
+	"github.com/docker/compose/v2/pkg/api"
+)
+
+type publishOptions struct {
+	*projectOptions
+}
+
+func publishCommand(p *projectOptions, backend api.Service) *cobra.Command {
+	opts := publishOptions{
+		projectOptions: p,
+	}
+	publishCmd := &cobra.Command{
+		Use:   "publish REPOSITORY[:TAG]",
+		Short: "Publish the project to a registry as an OCI artifact",
+		Args:  cobra.ExactArgs(1),
+		RunE: Adapt(func(ctx context.Context, args []string) error {
+			return runPublish(ctx, backend, opts, args[0])
+		}),
+	}
+	return publishCmd
+}
+
+func runPublish(ctx context.Context, backend api.Service, opts publishOptions, repository string) error {
+	// variables are published as declared, their defaults being those of the published env file, and paths remain
+	// relative to the project directory, as the referenced local files are published along with the Compose file
+	project, err := opts.toProject(ctx, nil,
+		cli.WithInterpolation(false),
+		cli.WithResolvedPaths(false),
+		cli.WithDiscardEnvFile)
+	if err != nil {
+		return err
+	}
+
+	envFile := ""
+	if !opts.skipEnvFile {
+		envFile = opts.EnvFile
+		if envFile == "" {
+			if _, err := os.Stat(filepath.Join(project.WorkingDir, ".env")); err == nil {
+				envFile = filepath.Join(project.WorkingDir, ".env")
+			}
+		}
+	}
+	return backend.Publish(ctx, project, repository, api.PublishOptions{
+		EnvFile: envFile,
+	})
+}
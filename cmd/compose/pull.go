@@ -68,7 +68,7 @@ func pullCommand(p *projectOptions, backend api.Service) *cobra.Command {
 }
 
 func runPull(ctx context.Context, backend api.Service, opts pullOptions, services []string) error {
-	project, err := opts.toProject(services)
+	project, err := opts.toProject(ctx, services)
 	if err != nil {
 		return err
 	}
@@ -29,6 +29,7 @@ type pushOptions struct {
 	composeOptions
 
 	Ignorefailures bool
+	tags           []string
 }
 
 func pushCommand(p *projectOptions, backend api.Service) *cobra.Command {
@@ -44,17 +45,26 @@ func pushCommand(p *projectOptions, backend api.Service) *cobra.Command {
 		ValidArgsFunction: serviceCompletion(p),
 	}
 	pushCmd.Flags().BoolVar(&opts.Ignorefailures, "ignore-push-failures", false, "Push what it can and ignores images with push failures")
+	pushCmd.Flags().StringArrayVar(&opts.tags, "tag", []string{}, "Push the image built for a service as IMAGE, or SERVICE=IMAGE when pushing multiple services, as set by build --tag.")
 
 	return pushCmd
 }
 
 func runPush(ctx context.Context, backend api.Service, opts pushOptions, services []string) error {
-	project, err := opts.toProject(services)
+	project, err := opts.toProject(ctx, services)
 	if err != nil {
 		return err
 	}
 
+	for _, tag := range opts.tags {
+		err = applyTag(project, services, tag)
+		if err != nil {
+			return err
+		}
+	}
+
 	return backend.Push(ctx, project, api.PushOptions{
+		Services:       services,
 		IgnoreFailures: opts.Ignorefailures,
 	})
 }
@@ -59,7 +59,7 @@ Any data which is not in a volume will be lost.`,
 }
 
 func runRemove(ctx context.Context, backend api.Service, opts removeOptions, services []string) error {
-	project, err := opts.toProjectName()
+	project, err := opts.toProjectName(ctx)
 	if err != nil {
 		return err
 	}
@@ -20,14 +20,18 @@ import (
 	"context"
 	"time"
 
+	"github.com/compose-spec/compose-go/types"
 	"github.com/spf13/cobra"
 
 	"github.com/docker/compose/v2/pkg/api"
+	"github.com/docker/compose/v2/pkg/utils"
 )
 
 type restartOptions struct {
 	*projectOptions
-	timeout int
+	timeout       int
+	servicesFile  string
+	forceRecreate bool
 }
 
 func restartCommand(p *projectOptions, backend api.Service) *cobra.Command {
@@ -44,19 +48,94 @@ func restartCommand(p *projectOptions, backend api.Service) *cobra.Command {
 	}
 	flags := restartCmd.Flags()
 	flags.IntVarP(&opts.timeout, "timeout", "t", 10, "Specify a shutdown timeout in seconds")
+	flags.StringVar(&opts.servicesFile, "services-from-file", "", "Restart the services listed in this file, one per line. Comments start with #.")
+	flags.BoolVar(&opts.forceRecreate, "force-recreate", false, "Recreate containers to apply the current configuration instead of restarting them.")
 
 	return restartCmd
 }
 
 func runRestart(ctx context.Context, backend api.Service, opts restartOptions, services []string) error {
-	projectName, err := opts.toProjectName()
-	if err != nil {
-		return err
+	var err error
+	if opts.servicesFile != "" {
+		services, err = opts.withServicesFromFile(ctx, services, opts.servicesFile)
+		if err != nil {
+			return err
+		}
 	}
 
 	timeout := time.Duration(opts.timeout) * time.Second
+	if opts.forceRecreate {
+		return runRecreate(ctx, backend, opts, services, timeout)
+	}
+
+	projectName, err := opts.toProjectName(ctx)
+	if err != nil {
+		return err
+	}
 	return backend.Restart(ctx, projectName, api.RestartOptions{
 		Timeout:  &timeout,
 		Services: services,
 	})
 }
+
+// runRecreate replaces the containers of the selected services, or of all services having containers, with new ones
+// created from the current configuration. Dependencies are left untouched, as a plain restart would.
+func runRecreate(ctx context.Context, backend api.Service, opts restartOptions, services []string, timeout time.Duration) error {
+	project, err := opts.toProject(ctx, services)
+	if err != nil {
+		return err
+	}
+
+	if len(services) == 0 {
+		services, err = servicesWithContainers(ctx, backend, project)
+		if err != nil {
+			return err
+		}
+		if len(services) == 0 {
+			return nil
+		}
+	}
+
+	enabled, err := project.GetServices(services...)
+	if err != nil {
+		return err
+	}
+	for _, s := range project.Services {
+		if !utils.StringContains(services, s.Name) {
+			project.DisabledServices = append(project.DisabledServices, s)
+		}
+	}
+	project.Services = enabled
+
+	return backend.Up(ctx, project, api.UpOptions{
+		Create: api.CreateOptions{
+			Services:             services,
+			Recreate:             api.RecreateForce,
+			RecreateDependencies: api.RecreateNever,
+			Inherit:              true,
+			Timeout:              &timeout,
+		},
+		Start: api.StartOptions{
+			Project: project,
+		},
+	})
+}
+
+// servicesWithContainers lists the services of the project which have containers, running or not, so a restart
+// doesn't create the services which were never up
+func servicesWithContainers(ctx context.Context, backend api.Service, project *types.Project) ([]string, error) {
+	containers, err := backend.Ps(ctx, project.Name, api.PsOptions{})
+	if err != nil {
+		return nil, err
+	}
+	var services []string
+	for _, name := range project.ServiceNames() {
+		for _, c := range containers {
+			if c.Service == name {
+				services = append(services, name)
+				break
+			}
+		}
+	}
+	return services, nil
+}
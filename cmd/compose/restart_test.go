@@ -0,0 +1,101 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/compose-spec/compose-go/types"
+	"github.com/golang/mock/gomock"
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose/v2/pkg/api"
+	"github.com/docker/compose/v2/pkg/mocks"
+)
+
+func TestRestartForceRecreate(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "compose.yaml")
+	err := os.WriteFile(file, []byte(`services:
+  web:
+    image: nginx
+    depends_on: [db]
+  db:
+    image: postgres
+`), 0o600)
+	assert.NilError(t, err)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	backend := mocks.NewMockService(ctrl)
+	backend.EXPECT().Up(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, project *types.Project, options api.UpOptions) error {
+			assert.DeepEqual(t, project.ServiceNames(), []string{"web"})
+			assert.Equal(t, len(project.DisabledServices), 1)
+			assert.Equal(t, options.Create.Recreate, api.RecreateForce)
+			assert.Equal(t, options.Create.RecreateDependencies, api.RecreateNever)
+			assert.Equal(t, *options.Create.Timeout, 3*time.Second)
+			assert.Assert(t, options.Start.Attach == nil)
+			return nil
+		})
+
+	opts := restartOptions{
+		projectOptions: &projectOptions{ProjectName: "test", ConfigPaths: []string{file}},
+		timeout:        3,
+		forceRecreate:  true,
+	}
+	err = runRestart(context.TODO(), backend, opts, []string{"web"})
+	assert.NilError(t, err)
+}
+
+func TestRestartForceRecreateAllServices(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "compose.yaml")
+	err := os.WriteFile(file, []byte(`services:
+  web:
+    image: nginx
+  worker:
+    image: alpine
+`), 0o600)
+	assert.NilError(t, err)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	backend := mocks.NewMockService(ctrl)
+	backend.EXPECT().Ps(gomock.Any(), "test", api.PsOptions{}).Return([]api.ContainerSummary{
+		{Name: "test-web-1", Service: "web"},
+	}, nil)
+	backend.EXPECT().Up(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, project *types.Project, options api.UpOptions) error {
+			// worker was never up, so it is not created
+			assert.DeepEqual(t, project.ServiceNames(), []string{"web"})
+			assert.DeepEqual(t, options.Create.Services, []string{"web"})
+			return nil
+		})
+
+	opts := restartOptions{
+		projectOptions: &projectOptions{ProjectName: "test", ConfigPaths: []string{file}},
+		timeout:        3,
+		forceRecreate:  true,
+	}
+	err = runRestart(context.TODO(), backend, opts, nil)
+	assert.NilError(t, err)
+}
@@ -19,6 +19,7 @@ package compose
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 	"strings"
 
 	cgo "github.com/compose-spec/compose-go/cli"
@@ -26,12 +27,17 @@ import (
 	"github.com/compose-spec/compose-go/types"
 	"github.com/docker/cli/cli/command"
 	"github.com/mattn/go-shellwords"
+	"github.com/moby/term"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
 	"github.com/docker/cli/cli"
+	cliopts "github.com/docker/cli/opts"
 	"github.com/docker/compose/v2/pkg/api"
 	"github.com/docker/compose/v2/pkg/progress"
+	"github.com/docker/compose/v2/pkg/utils"
 )
 
 type runOptions struct {
@@ -48,6 +54,7 @@ type runOptions struct {
 	entrypoint    string
 	entrypointCmd []string
 	labels        []string
+	labelFiles    []string
 	volumes       []string
 	publish       []string
 	useAliases    bool
@@ -56,6 +63,13 @@ type runOptions struct {
 	noDeps        bool
 	ignoreOrphans bool
 	quietPull     bool
+	quiet         bool
+	capAdd        []string
+	capDrop       []string
+	securityOpts  []string
+	network       string
+	detachKeys    string
+	build         bool
 }
 
 func (opts runOptions) apply(project *types.Project) error {
@@ -64,6 +78,10 @@ func (opts runOptions) apply(project *types.Project) error {
 		return err
 	}
 
+	if opts.build && target.Build != nil {
+		// image is rebuilt when the one-off container is created, dependencies being left as is
+		target.PullPolicy = types.PullPolicyBuild
+	}
 	target.Tty = !opts.noTty
 	target.StdinOpen = opts.interactive
 	if !opts.servicePorts {
@@ -79,13 +97,35 @@ func (opts runOptions) apply(project *types.Project) error {
 			target.Ports = append(target.Ports, config...)
 		}
 	}
-	if len(opts.volumes) > 0 {
-		for _, v := range opts.volumes {
-			volume, err := loader.ParseVolume(v)
-			if err != nil {
-				return err
+	for _, v := range opts.volumes {
+		volume, err := loader.ParseVolume(v)
+		if err != nil {
+			return errors.Wrapf(err, "invalid --volume option %q", v)
+		}
+		if volume.Type == types.VolumeTypeBind && strings.HasPrefix(volume.Source, ".") {
+			volume.Source = filepath.Join(project.WorkingDir, volume.Source)
+		}
+		// ad-hoc mount overrides the service's volume mounted on the same path
+		volumes := make([]types.ServiceVolumeConfig, 0, len(target.Volumes)+1)
+		for _, existing := range target.Volumes {
+			if existing.Target != volume.Target {
+				volumes = append(volumes, existing)
 			}
-			target.Volumes = append(target.Volumes, volume)
+		}
+		target.Volumes = append(volumes, volume)
+	}
+
+	for _, c := range opts.capAdd {
+		target.CapAdd = appendCapability(target.CapAdd, c)
+		target.CapDrop = removeCapability(target.CapDrop, c)
+	}
+	for _, c := range opts.capDrop {
+		target.CapDrop = appendCapability(target.CapDrop, c)
+		target.CapAdd = removeCapability(target.CapAdd, c)
+	}
+	for _, o := range opts.securityOpts {
+		if !utils.StringContains(target.SecurityOpt, o) {
+			target.SecurityOpt = append(target.SecurityOpt, o)
 		}
 	}
 
@@ -107,6 +147,51 @@ func (opts runOptions) apply(project *types.Project) error {
 	return nil
 }
 
+// capabilities are the Linux capabilities which can be set on a container, "ALL" standing for all of them
+var capabilities = []string{
+	"ALL", "AUDIT_CONTROL", "AUDIT_READ", "AUDIT_WRITE", "BLOCK_SUSPEND", "BPF", "CHECKPOINT_RESTORE", "CHOWN",
+	"DAC_OVERRIDE", "DAC_READ_SEARCH", "FOWNER", "FSETID", "IPC_LOCK", "IPC_OWNER", "KILL", "LEASE",
+	"LINUX_IMMUTABLE", "MAC_ADMIN", "MAC_OVERRIDE", "MKNOD", "NET_ADMIN", "NET_BIND_SERVICE", "NET_BROADCAST",
+	"NET_RAW", "PERFMON", "SETFCAP", "SETGID", "SETPCAP", "SETUID", "SYS_ADMIN", "SYS_BOOT", "SYS_CHROOT",
+	"SYS_MODULE", "SYS_NICE", "SYS_PACCT", "SYS_PTRACE", "SYS_RAWIO", "SYS_RESOURCE", "SYS_TIME", "SYS_TTY_CONFIG",
+	"SYSLOG", "WAKE_ALARM",
+}
+
+// normalizeCapability returns the canonical name of a capability, without the optional `CAP_` prefix
+func normalizeCapability(c string) string {
+	return strings.TrimPrefix(strings.ToUpper(c), "CAP_")
+}
+
+func validateCapabilities(flag string, caps []string) error {
+	for _, c := range caps {
+		if !utils.StringContains(capabilities, normalizeCapability(c)) {
+			return fmt.Errorf("invalid --%s option %q. Should be a Linux capability, like SYS_PTRACE, or ALL", flag, c)
+		}
+	}
+	return nil
+}
+
+func appendCapability(caps []string, c string) []string {
+	c = normalizeCapability(c)
+	for _, existing := range caps {
+		if normalizeCapability(existing) == c {
+			return caps
+		}
+	}
+	return append(caps, c)
+}
+
+func removeCapability(caps []string, c string) []string {
+	c = normalizeCapability(c)
+	var kept []string
+	for _, existing := range caps {
+		if normalizeCapability(existing) != c {
+			kept = append(kept, existing)
+		}
+	}
+	return kept
+}
+
 func runCommand(p *projectOptions, dockerCli command.Cli, backend api.Service) *cobra.Command {
 	opts := runOptions{
 		composeOptions: &composeOptions{
@@ -125,6 +210,25 @@ func runCommand(p *projectOptions, dockerCli command.Cli, backend api.Service) *
 			if len(opts.publish) > 0 && opts.servicePorts {
 				return fmt.Errorf("--service-ports and --publish are incompatible")
 			}
+			if err := validateCapabilities("cap-add", opts.capAdd); err != nil {
+				return err
+			}
+			if err := validateCapabilities("cap-drop", opts.capDrop); err != nil {
+				return err
+			}
+			if opts.quiet {
+				// only the output of the one-off container is left
+				progress.Mode = progress.ModeQuiet
+				opts.quietPull = true
+				if !logLevelChanged(cmd) {
+					logrus.SetLevel(logrus.ErrorLevel)
+				}
+			}
+			if opts.detachKeys != "" {
+				if _, err := term.ToBytes(opts.detachKeys); err != nil {
+					return errors.Wrapf(err, "invalid --detach-keys %q", opts.detachKeys)
+				}
+			}
 			if cmd.Flags().Changed("entrypoint") {
 				command, err := shellwords.Parse(opts.entrypoint)
 				if err != nil {
@@ -135,7 +239,7 @@ func runCommand(p *projectOptions, dockerCli command.Cli, backend api.Service) *
 			return nil
 		}),
 		RunE: Adapt(func(ctx context.Context, args []string) error {
-			project, err := p.toProject([]string{opts.Service}, cgo.WithResolvedPaths(true))
+			project, err := p.toProject(ctx, []string{opts.Service}, cgo.WithResolvedPaths(true))
 			if err != nil {
 				return err
 			}
@@ -149,18 +253,26 @@ func runCommand(p *projectOptions, dockerCli command.Cli, backend api.Service) *
 	flags.BoolVarP(&opts.Detach, "detach", "d", false, "Run container in background and print container ID")
 	flags.StringArrayVarP(&opts.environment, "env", "e", []string{}, "Set environment variables")
 	flags.StringArrayVarP(&opts.labels, "label", "l", []string{}, "Add or override a label")
+	flags.StringArrayVar(&opts.labelFiles, "label-file", []string{}, "Read labels from a file of KEY=VALUE lines, overridden by --label")
 	flags.BoolVar(&opts.Remove, "rm", false, "Automatically remove the container when it exits")
 	flags.BoolVarP(&opts.noTty, "no-TTY", "T", !dockerCli.Out().IsTerminal(), "Disable pseudo-TTY allocation (default: auto-detected).")
-	flags.StringVar(&opts.name, "name", "", " Assign a name to the container")
+	flags.StringVar(&opts.name, "name", "", "Assign a name to the container")
 	flags.StringVarP(&opts.user, "user", "u", "", "Run as specified username or uid")
 	flags.StringVarP(&opts.workdir, "workdir", "w", "", "Working directory inside the container")
 	flags.StringVar(&opts.entrypoint, "entrypoint", "", "Override the entrypoint of the image")
 	flags.BoolVar(&opts.noDeps, "no-deps", false, "Don't start linked services.")
+	flags.BoolVar(&opts.build, "build", false, "Build the image of the service before starting the container.")
 	flags.StringArrayVarP(&opts.volumes, "volume", "v", []string{}, "Bind mount a volume.")
 	flags.StringArrayVarP(&opts.publish, "publish", "p", []string{}, "Publish a container's port(s) to the host.")
 	flags.BoolVar(&opts.useAliases, "use-aliases", false, "Use the service's network useAliases in the network(s) the container connects to.")
 	flags.BoolVar(&opts.servicePorts, "service-ports", false, "Run command with the service's ports enabled and mapped to the host.")
 	flags.BoolVar(&opts.quietPull, "quiet-pull", false, "Pull without printing progress information.")
+	flags.BoolVarP(&opts.quiet, "quiet", "q", false, "Don't print Compose messages, such as the creation of dependencies or pull progress, only the output of the command.")
+	flags.StringArrayVar(&opts.capAdd, "cap-add", []string{}, "Add Linux capabilities to the container.")
+	flags.StringArrayVar(&opts.capDrop, "cap-drop", []string{}, "Drop Linux capabilities from the container.")
+	flags.StringArrayVar(&opts.securityOpts, "security-opt", []string{}, "Add security options to the container.")
+	flags.StringVar(&opts.network, "network", "", "Connect the container to a network instead of the service's networks.")
+	flags.StringVar(&opts.detachKeys, "detach-keys", "", "Override the key sequence for detaching from the container.")
 
 	cmd.Flags().BoolVarP(&opts.interactive, "interactive", "i", true, "Keep STDIN open even if not attached.")
 	cmd.Flags().BoolP("tty", "t", true, "Allocate a pseudo-TTY.")
@@ -181,6 +293,18 @@ func normalizeRunFlags(f *pflag.FlagSet, name string) pflag.NormalizedName {
 	return pflag.NormalizedName(name)
 }
 
+// logLevelChanged tells whether the log level has been set on the command line, by compose or by the docker CLI
+func logLevelChanged(cmd *cobra.Command) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		for _, name := range []string{"log-level", "verbose", "debug"} {
+			if f := c.Flags().Lookup(name); f != nil && f.Changed {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func runRun(ctx context.Context, backend api.Service, project *types.Project, opts runOptions) error {
 	err := opts.apply(project)
 	if err != nil {
@@ -188,19 +312,15 @@ func runRun(ctx context.Context, backend api.Service, project *types.Project, op
 	}
 
 	err = progress.Run(ctx, func(ctx context.Context) error {
-		return startDependencies(ctx, backend, *project, opts.Service, opts.ignoreOrphans)
+		return startDependencies(ctx, backend, *project, opts.Service, opts.ignoreOrphans, opts.quietPull)
 	})
 	if err != nil {
 		return err
 	}
 
-	labels := types.Labels{}
-	for _, s := range opts.labels {
-		parts := strings.SplitN(s, "=", 2)
-		if len(parts) != 2 {
-			return fmt.Errorf("label must be set as KEY=VALUE")
-		}
-		labels[parts[0]] = parts[1]
+	labels, err := opts.parseLabels()
+	if err != nil {
+		return err
 	}
 
 	// start container and attach to container streams
@@ -219,8 +339,10 @@ func runRun(ctx context.Context, backend api.Service, project *types.Project, op
 		Labels:            labels,
 		UseNetworkAliases: opts.useAliases,
 		NoDeps:            opts.noDeps,
+		Network:           opts.network,
 		Index:             0,
 		QuietPull:         opts.quietPull,
+		DetachKeys:        opts.detachKeys,
 	}
 
 	for i, service := range project.Services {
@@ -241,7 +363,24 @@ func runRun(ctx context.Context, backend api.Service, project *types.Project, op
 	return err
 }
 
-func startDependencies(ctx context.Context, backend api.Service, project types.Project, requestedServiceName string, ignoreOrphans bool) error {
+// parseLabels reads the labels of the one-off container from the label files, then from --label which takes precedence
+func (opts runOptions) parseLabels() (types.Labels, error) {
+	lines, err := cliopts.ReadKVStrings(opts.labelFiles, opts.labels)
+	if err != nil {
+		return nil, err
+	}
+	labels := types.Labels{}
+	for _, s := range lines {
+		parts := strings.SplitN(s, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("label must be set as KEY=VALUE")
+		}
+		labels[parts[0]] = parts[1]
+	}
+	return labels, nil
+}
+
+func startDependencies(ctx context.Context, backend api.Service, project types.Project, requestedServiceName string, ignoreOrphans bool, quietPull bool) error {
 	dependencies := types.Services{}
 	var requestedService types.ServiceConfig
 	for _, service := range project.Services {
@@ -256,6 +395,7 @@ func startDependencies(ctx context.Context, backend api.Service, project types.P
 	project.DisabledServices = append(project.DisabledServices, requestedService)
 	err := backend.Create(ctx, &project, api.CreateOptions{
 		IgnoreOrphans: ignoreOrphans,
+		QuietPull:     quietPull,
 	})
 	if err != nil {
 		return err
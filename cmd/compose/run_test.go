@@ -0,0 +1,161 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/compose-spec/compose-go/types"
+	"github.com/spf13/cobra"
+	"gotest.tools/v3/assert"
+)
+
+func TestApplyRunVolumes(t *testing.T) {
+	p := types.Project{
+		WorkingDir: "/project",
+		Services: []types.ServiceConfig{
+			{
+				Name: "web",
+				Volumes: []types.ServiceVolumeConfig{
+					{Type: types.VolumeTypeBind, Source: "/project/src", Target: "/workspace"},
+					{Type: types.VolumeTypeVolume, Source: "data", Target: "/data"},
+				},
+			},
+		},
+	}
+	opts := runOptions{
+		Service: "web",
+		volumes: []string{"/tmp/debug:/workspace", "./cache:/cache:ro"},
+	}
+	err := opts.apply(&p)
+	assert.NilError(t, err)
+	web, err := p.GetService("web")
+	assert.NilError(t, err)
+	assert.Equal(t, len(web.Volumes), 3)
+	assert.Equal(t, web.Volumes[0].Target, "/data")
+	assert.Equal(t, web.Volumes[1].Source, "/tmp/debug")
+	assert.Equal(t, web.Volumes[1].Target, "/workspace")
+	assert.Equal(t, web.Volumes[2].Source, "/project/cache")
+	assert.Assert(t, web.Volumes[2].ReadOnly)
+
+	opts.volumes = []string{"/tmp:/a:/b:/c"}
+	err = opts.apply(&p)
+	assert.ErrorContains(t, err, `invalid --volume option "/tmp:/a:/b:/c"`)
+}
+
+func TestApplyRunCapabilities(t *testing.T) {
+	p := types.Project{
+		Services: []types.ServiceConfig{
+			{
+				Name:        "web",
+				CapAdd:      []string{"NET_ADMIN", "SYS_NICE"},
+				CapDrop:     []string{"CAP_SYS_PTRACE"},
+				SecurityOpt: []string{"no-new-privileges:true"},
+			},
+		},
+	}
+	opts := runOptions{
+		Service:      "web",
+		capAdd:       []string{"sys_ptrace", "NET_ADMIN"},
+		capDrop:      []string{"SYS_NICE", "CHOWN"},
+		securityOpts: []string{"no-new-privileges:true", "apparmor=unconfined"},
+	}
+	err := opts.apply(&p)
+	assert.NilError(t, err)
+	web, err := p.GetService("web")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, web.CapAdd, []string{"NET_ADMIN", "SYS_PTRACE"})
+	assert.DeepEqual(t, web.CapDrop, []string{"SYS_NICE", "CHOWN"})
+	assert.DeepEqual(t, web.SecurityOpt, []string{"no-new-privileges:true", "apparmor=unconfined"})
+}
+
+func TestApplyRunBuild(t *testing.T) {
+	newProject := func() *types.Project {
+		return &types.Project{
+			Services: []types.ServiceConfig{
+				{Name: "web", Build: &types.BuildConfig{Context: "web"}, DependsOn: types.DependsOnConfig{"api": {}}},
+				{Name: "api", Build: &types.BuildConfig{Context: "api"}},
+				{Name: "db", Image: "postgres"},
+			},
+		}
+	}
+
+	p := newProject()
+	assert.NilError(t, runOptions{Service: "web"}.apply(p))
+	for _, s := range p.Services {
+		assert.Equal(t, s.PullPolicy, "", s.Name)
+	}
+
+	p = newProject()
+	assert.NilError(t, runOptions{Service: "web", build: true}.apply(p))
+	web, err := p.GetService("web")
+	assert.NilError(t, err)
+	assert.Equal(t, web.PullPolicy, types.PullPolicyBuild)
+	api, err := p.GetService("api")
+	assert.NilError(t, err)
+	assert.Equal(t, api.PullPolicy, "")
+
+	p = newProject()
+	assert.NilError(t, runOptions{Service: "db", build: true}.apply(p))
+	db, err := p.GetService("db")
+	assert.NilError(t, err)
+	assert.Equal(t, db.PullPolicy, "")
+}
+
+func TestValidateCapabilities(t *testing.T) {
+	assert.NilError(t, validateCapabilities("cap-add", []string{"SYS_PTRACE", "cap_net_admin", "ALL"}))
+	err := validateCapabilities("cap-add", []string{"SYS_PTRACE", "PTRACE"})
+	assert.Error(t, err, `invalid --cap-add option "PTRACE". Should be a Linux capability, like SYS_PTRACE, or ALL`)
+}
+
+func TestLogLevelChanged(t *testing.T) {
+	root := &cobra.Command{Use: "compose"}
+	root.PersistentFlags().String("log-level", "", "")
+	run := &cobra.Command{Use: "run"}
+	root.AddCommand(run)
+	assert.NilError(t, run.ParseFlags(nil))
+	assert.Assert(t, !logLevelChanged(run))
+
+	assert.NilError(t, run.ParseFlags([]string{"--log-level", "debug"}))
+	assert.Assert(t, logLevelChanged(run))
+}
+
+func TestParseRunLabels(t *testing.T) {
+	labelFile := filepath.Join(t.TempDir(), "labels.txt")
+	err := os.WriteFile(labelFile, []byte("# generated by CI\nci.build=42\nci.commit=abc123\n\nci.branch=main\n"), 0o644)
+	assert.NilError(t, err)
+
+	opts := runOptions{
+		labelFiles: []string{labelFile},
+		labels:     []string{"ci.branch=feature", "debug=true"},
+	}
+	labels, err := opts.parseLabels()
+	assert.NilError(t, err)
+	assert.DeepEqual(t, labels, types.Labels{
+		"ci.build":  "42",
+		"ci.commit": "abc123",
+		"ci.branch": "feature",
+		"debug":     "true",
+	})
+
+	err = os.WriteFile(labelFile, []byte("ci.build\n"), 0o644)
+	assert.NilError(t, err)
+	_, err = opts.parseLabels()
+	assert.ErrorContains(t, err, "label must be set as KEY=VALUE")
+}
@@ -0,0 +1,203 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/compose-spec/compose-go/cli"
+	"github.com/compose-spec/compose-go/loader"
+	"github.com/compose-spec/compose-go/schema"
+	"github.com/compose-spec/compose-go/types"
+	"github.com/sanathkr/go-yaml"
+	"github.com/sirupsen/logrus"
+	"github.com/xeipuuv/gojsonschema"
+
+	"github.com/docker/compose/v2/pkg/api"
+	"github.com/docker/compose/v2/pkg/compose"
+)
+
+// toValidatedProject loads the project like projectOptions.toProject does, applying the schema validation selected by
+// --strict and --skip-validation. By default, keys not defined by the Compose specification are reported as warnings
+// and ignored, while --strict rejects them.
+func (opts convertOptions) toValidatedProject(ctx context.Context, services []string, po ...cli.ProjectOptionsFn) (*types.Project, error) {
+	if opts.skipValidation {
+		return opts.projectOptions.toProject(ctx, services, append(po, cli.WithLoadOptions(loader.WithSkipValidation))...)
+	}
+	options, err := opts.toProjectOptions(ctx)
+	if err != nil {
+		return nil, compose.WrapComposeError(err)
+	}
+	files, unknown, err := parseUnknownKeys(options.ConfigPaths)
+	if err != nil {
+		return nil, err
+	}
+	if len(unknown) == 0 {
+		return opts.projectOptions.toProject(ctx, services, po...)
+	}
+	if opts.strict {
+		return nil, fmt.Errorf("%d unknown key(s) in Compose file:\n%s", len(unknown), strings.Join(unknown, "\n"))
+	}
+	for _, key := range unknown {
+		logrus.Warnf("%s is not defined by the Compose specification, it will be ignored", key)
+	}
+	return opts.toProjectWithoutUnknownKeys(ctx, options, files, services, po...)
+}
+
+// toProjectWithoutUnknownKeys loads the project from copies of its Compose files without the keys the Compose
+// specification doesn't define, which the loader would reject, so the rest of the schema validation still applies
+func (opts convertOptions) toProjectWithoutUnknownKeys(ctx context.Context, options *cli.ProjectOptions, files []map[string]interface{},
+	services []string, po ...cli.ProjectOptionsFn) (*types.Project, error) {
+	workingDir, err := options.GetWorkingDir()
+	if err != nil {
+		return nil, err
+	}
+	workingDir, err = filepath.Abs(workingDir)
+	if err != nil {
+		return nil, err
+	}
+	dir, err := os.MkdirTemp("", "compose-config")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir) // nolint: errcheck
+
+	var paths []string
+	for i, path := range options.ConfigPaths {
+		if files[i] == nil {
+			paths = append(paths, path)
+			continue
+		}
+		content, err := yaml.Marshal(files[i])
+		if err != nil {
+			return nil, err
+		}
+		stripped := filepath.Join(dir, fmt.Sprintf("%d-%s", i, filepath.Base(path)))
+		if err := os.WriteFile(stripped, content, 0o600); err != nil {
+			return nil, err
+		}
+		paths = append(paths, stripped)
+	}
+
+	// relative paths and the default project name still depend on the directory of the original Compose files
+	stripped := *opts.projectOptions
+	stripped.ConfigPaths = paths
+	stripped.ProjectDir = workingDir
+	project, err := stripped.toProject(ctx, services, po...)
+	if err != nil {
+		return nil, err
+	}
+	project.ComposeFiles = options.ConfigPaths
+	for i, service := range project.Services {
+		service.CustomLabels[api.ConfigFilesLabel] = strings.Join(project.ComposeFiles, ",")
+		project.Services[i] = service
+	}
+	return project, nil
+}
+
+// parseUnknownKeys parses the Compose files and removes the keys not defined by the Compose specification, which are
+// returned prefixed by the file they are found in. A Compose file read from stdin is left to the loader validation.
+func parseUnknownKeys(paths []string) ([]map[string]interface{}, []string, error) {
+	files := make([]map[string]interface{}, len(paths))
+	var unknown []string
+	for i, path := range paths {
+		if path == "-" {
+			continue
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		dict, err := loader.ParseYAML(content)
+		if err != nil {
+			return nil, nil, err
+		}
+		keys, err := unknownSchemaKeys(dict)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, key := range keys {
+			unknown = append(unknown, fmt.Sprintf("%s: %s", path, key))
+			key.remove(dict)
+		}
+		files[i] = dict
+	}
+	return files, unknown, nil
+}
+
+// schemaKey is the path to a key of a Compose file, as the keys and the list indexes leading to it
+type schemaKey []string
+
+func (k schemaKey) String() string {
+	return strings.Join(k, ".")
+}
+
+// remove deletes the key from a Compose file
+func (k schemaKey) remove(dict map[string]interface{}) {
+	var parent interface{} = dict
+	for _, segment := range k[:len(k)-1] {
+		switch p := parent.(type) {
+		case map[string]interface{}:
+			parent = p[segment]
+		case []interface{}:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(p) {
+				return
+			}
+			parent = p[index]
+		default:
+			return
+		}
+	}
+	if m, ok := parent.(map[string]interface{}); ok {
+		delete(m, k[len(k)-1])
+	}
+}
+
+// unknownSchemaKeys validates a Compose file against the Compose specification schema and returns the keys it doesn't
+// allow, like `services.web.foo`. Other schema violations are left to the loader, which validates values once
+// interpolated.
+func unknownSchemaKeys(dict map[string]interface{}) ([]schemaKey, error) {
+	result, err := gojsonschema.Validate(gojsonschema.NewStringLoader(schema.Schema), gojsonschema.NewGoLoader(dict))
+	if err != nil {
+		return nil, err
+	}
+	seen := map[string]bool{}
+	var keys []schemaKey
+	for _, e := range result.Errors() {
+		if e.Type() != "additional_property_not_allowed" {
+			continue
+		}
+		// the context is the path to the object holding the key, starting with "(root)"
+		key := append(schemaKey{}, strings.Split(e.Context().String("\x00"), "\x00")[1:]...)
+		key = append(key, fmt.Sprint(e.Details()["property"]))
+		if !seen[key.String()] {
+			seen[key.String()] = true
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].String() < keys[j].String()
+	})
+	return keys, nil
+}
@@ -0,0 +1,88 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"testing"
+
+	"github.com/compose-spec/compose-go/loader"
+	"gotest.tools/v3/assert"
+)
+
+func TestUnknownSchemaKeys(t *testing.T) {
+	dict, err := loader.ParseYAML([]byte(`
+services:
+  web:
+    image: nginx
+    restart_policy: always
+    x-custom: allowed
+    build:
+      context: .
+      dockerfil: Dockerfile
+tweaks: true
+x-extension: allowed
+`))
+	assert.NilError(t, err)
+
+	keys, err := unknownSchemaKeys(dict)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, keys, []schemaKey{
+		{"services", "web", "build", "dockerfil"},
+		{"services", "web", "restart_policy"},
+		{"tweaks"},
+	})
+}
+
+func TestUnknownSchemaKeysIgnoresValues(t *testing.T) {
+	dict, err := loader.ParseYAML([]byte(`
+services:
+  web:
+    image: nginx
+    scale: ${SCALE}
+`))
+	assert.NilError(t, err)
+
+	keys, err := unknownSchemaKeys(dict)
+	assert.NilError(t, err)
+	assert.Equal(t, len(keys), 0)
+}
+
+func TestSchemaKeyRemove(t *testing.T) {
+	dict, err := loader.ParseYAML([]byte(`
+services:
+  web:
+    image: nginx
+    restart_policy: always
+    ports:
+      - target: 80
+        unknown: true
+`))
+	assert.NilError(t, err)
+
+	keys, err := unknownSchemaKeys(dict)
+	assert.NilError(t, err)
+	assert.Equal(t, len(keys), 2)
+	for _, key := range keys {
+		key.remove(dict)
+	}
+
+	keys, err = unknownSchemaKeys(dict)
+	assert.NilError(t, err)
+	assert.Equal(t, len(keys), 0)
+	web := dict["services"].(map[string]interface{})["web"].(map[string]interface{})
+	assert.Equal(t, web["image"], "nginx")
+}
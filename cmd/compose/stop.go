@@ -18,6 +18,8 @@ package compose
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -29,6 +31,7 @@ type stopOptions struct {
 	*projectOptions
 	timeChanged bool
 	timeout     int
+	labels      []string
 }
 
 func stopCommand(p *projectOptions, backend api.Service) *cobra.Command {
@@ -38,8 +41,14 @@ func stopCommand(p *projectOptions, backend api.Service) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "stop [SERVICE...]",
 		Short: "Stop services",
-		PreRun: func(cmd *cobra.Command, args []string) {
+		PreRunE: func(cmd *cobra.Command, args []string) error {
 			opts.timeChanged = cmd.Flags().Changed("timeout")
+			for _, label := range opts.labels {
+				if strings.HasPrefix(label, "=") || label == "" {
+					return fmt.Errorf("invalid label selector %q: expected key=value or key", label)
+				}
+			}
+			return nil
 		},
 		RunE: Adapt(func(ctx context.Context, args []string) error {
 			return runStop(ctx, backend, opts, args)
@@ -48,12 +57,13 @@ func stopCommand(p *projectOptions, backend api.Service) *cobra.Command {
 	}
 	flags := cmd.Flags()
 	flags.IntVarP(&opts.timeout, "timeout", "t", 10, "Specify a shutdown timeout in seconds")
+	flags.StringArrayVar(&opts.labels, "label", []string{}, "Only stop containers having this label, as key=value or key. Can be repeated to match all of them")
 
 	return cmd
 }
 
 func runStop(ctx context.Context, backend api.Service, opts stopOptions, services []string) error {
-	projectName, err := opts.toProjectName()
+	projectName, err := opts.toProjectName(ctx)
 	if err != nil {
 		return err
 	}
@@ -66,5 +76,6 @@ func runStop(ctx context.Context, backend api.Service, opts stopOptions, service
 	return backend.Stop(ctx, projectName, api.StopOptions{
 		Timeout:  timeout,
 		Services: services,
+		Labels:   opts.labels,
 	})
 }
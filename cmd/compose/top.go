@@ -22,9 +22,12 @@ import (
 	"io"
 	"os"
 	"sort"
+	"strconv"
 	"strings"
 	"text/tabwriter"
+	"time"
 
+	"github.com/moby/term"
 	"github.com/spf13/cobra"
 
 	"github.com/docker/compose/v2/pkg/api"
@@ -32,6 +35,21 @@ import (
 
 type topOptions struct {
 	*projectOptions
+	aggregate bool
+	sort      string
+	follow    bool
+	interval  time.Duration
+}
+
+// sortColumns are the process columns, by order of preference, used to sort processes by a resource
+var sortColumns = map[string][]string{
+	"cpu": {"%CPU", "C"},
+	"mem": {"%MEM", "RSS", "VSZ"},
+}
+
+// sortPsArgs are the ps arguments listing the columns a resource is sorted by, when the default -ef doesn't
+var sortPsArgs = map[string][]string{
+	"mem": {"-e", "-o", "user,pid,ppid,%mem,rss,vsz,stime,tty,time,cmd"},
 }
 
 func topCommand(p *projectOptions, backend api.Service) *cobra.Command {
@@ -41,29 +59,88 @@ func topCommand(p *projectOptions, backend api.Service) *cobra.Command {
 	topCmd := &cobra.Command{
 		Use:   "top [SERVICES...]",
 		Short: "Display the running processes",
+		PreRunE: Adapt(func(ctx context.Context, args []string) error {
+			if opts.follow && opts.interval <= 0 {
+				return fmt.Errorf("invalid --interval %s, must be positive", opts.interval)
+			}
+			if opts.sort == "" {
+				return nil
+			}
+			if _, ok := sortColumns[opts.sort]; !ok {
+				return fmt.Errorf("unsupported --sort value %q, must be one of cpu, mem", opts.sort)
+			}
+			if opts.aggregate {
+				return fmt.Errorf("--sort and --aggregate are incompatible")
+			}
+			return nil
+		}),
 		RunE: Adapt(func(ctx context.Context, args []string) error {
 			return runTop(ctx, backend, opts, args)
 		}),
 		ValidArgsFunction: serviceCompletion(p),
 	}
+	topCmd.Flags().BoolVar(&opts.aggregate, "aggregate", false, "Group processes by service, with totals across replicas")
+	topCmd.Flags().StringVar(&opts.sort, "sort", "", "Sort processes of each container by resource usage, descending (cpu|mem)")
+	topCmd.Flags().BoolVarP(&opts.follow, "follow", "f", false, "Refresh the process listing at each interval until interrupted")
+	topCmd.Flags().DurationVar(&opts.interval, "interval", 2*time.Second, "Refresh interval for --follow")
 	return topCmd
 }
 
 func runTop(ctx context.Context, backend api.Service, opts topOptions, services []string) error {
-	projectName, err := opts.toProjectName()
+	projectName, err := opts.toProjectName(ctx)
 	if err != nil {
 		return err
 	}
-	containers, err := backend.Top(ctx, projectName, services)
+	if !opts.follow {
+		return printTop(ctx, backend, projectName, opts, services)
+	}
+
+	// redraw the listing in place when on a terminal, otherwise print successive blocks separated by an empty line
+	redraw := term.IsTerminal(os.Stdout.Fd())
+	ticker := time.NewTicker(opts.interval)
+	defer ticker.Stop()
+	for first := true; ; first = false {
+		if redraw {
+			fmt.Fprint(os.Stdout, "\033[H\033[2J") // nolint:errcheck
+		} else if !first {
+			fmt.Fprintln(os.Stdout) // nolint:errcheck
+		}
+		err = printTop(ctx, backend, projectName, opts, services)
+		if err != nil || ctx.Err() != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// printTop prints the processes of the project containers, as selected by options, to stdout
+func printTop(ctx context.Context, backend api.Service, projectName string, opts topOptions, services []string) error {
+	containers, err := backend.Top(ctx, projectName, api.TopOptions{
+		Services: services,
+		PsArgs:   sortPsArgs[opts.sort],
+	})
 	if err != nil {
 		return err
 	}
 
+	if opts.aggregate {
+		return printAggregatedTop(containers)
+	}
+
 	sort.Slice(containers, func(i, j int) bool {
 		return containers[i].Name < containers[j].Name
 	})
 
 	for _, container := range containers {
+		if opts.sort != "" {
+			if err := sortProcesses(container, sortColumns[opts.sort]); err != nil {
+				return err
+			}
+		}
 		fmt.Printf("%s\n", container.Name)
 		err := psPrinter(os.Stdout, func(w io.Writer) {
 			for _, proc := range container.Processes {
@@ -90,3 +167,141 @@ func psPrinter(out io.Writer, printer func(writer io.Writer), headers ...string)
 	printer(w)
 	return w.Flush()
 }
+
+// serviceProcSummary holds the processes summary of all replicas of a service
+type serviceProcSummary struct {
+	Service    string
+	Containers int
+	Processes  int
+	CPU        int
+	Time       time.Duration
+}
+
+func printAggregatedTop(containers []api.ContainerProcSummary) error {
+	summaries, err := aggregateTop(containers)
+	if err != nil {
+		return err
+	}
+	return psPrinter(os.Stdout, func(w io.Writer) {
+		for _, s := range summaries {
+			_, _ = fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%s\n", s.Service, s.Containers, s.Processes, s.CPU, formatProcessTime(s.Time))
+		}
+	}, "SERVICE", "CONTAINERS", "PROCESSES", "C", "TIME")
+}
+
+func aggregateTop(containers []api.ContainerProcSummary) ([]serviceProcSummary, error) {
+	byService := map[string]*serviceProcSummary{}
+	for _, container := range containers {
+		summary, ok := byService[container.Service]
+		if !ok {
+			summary = &serviceProcSummary{Service: container.Service}
+			byService[container.Service] = summary
+		}
+		summary.Containers++
+		summary.Processes += len(container.Processes)
+
+		cpu, cputime := titleIndex(container.Titles, "C"), titleIndex(container.Titles, "TIME")
+		for _, proc := range container.Processes {
+			if cpu >= 0 && cpu < len(proc) {
+				c, err := strconv.Atoi(proc[cpu])
+				if err != nil {
+					return nil, fmt.Errorf("invalid CPU utilization %q for container %s", proc[cpu], container.Name)
+				}
+				summary.CPU += c
+			}
+			if cputime >= 0 && cputime < len(proc) {
+				d, err := parseProcessTime(proc[cputime])
+				if err != nil {
+					return nil, err
+				}
+				summary.Time += d
+			}
+		}
+	}
+
+	summaries := make([]serviceProcSummary, 0, len(byService))
+	for _, summary := range byService {
+		summaries = append(summaries, *summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].Service < summaries[j].Service
+	})
+	return summaries, nil
+}
+
+// sortProcesses sorts the processes of a container by the first of columns available, descending
+func sortProcesses(container api.ContainerProcSummary, columns []string) error {
+	column := -1
+	for _, title := range columns {
+		if column = titleIndex(container.Titles, title); column >= 0 {
+			break
+		}
+	}
+	if column < 0 {
+		return fmt.Errorf("no %s column in processes of container %s", strings.Join(columns, " or "), container.Name)
+	}
+
+	values := make([]float64, len(container.Processes))
+	for i, proc := range container.Processes {
+		if column >= len(proc) {
+			continue
+		}
+		v, err := strconv.ParseFloat(proc[column], 64)
+		if err != nil {
+			return fmt.Errorf("invalid %s value %q for container %s", container.Titles[column], proc[column], container.Name)
+		}
+		values[i] = v
+	}
+	sort.Stable(byValue{processes: container.Processes, values: values})
+	return nil
+}
+
+// byValue sorts processes by decreasing values
+type byValue struct {
+	processes [][]string
+	values    []float64
+}
+
+func (b byValue) Len() int           { return len(b.processes) }
+func (b byValue) Less(i, j int) bool { return b.values[i] > b.values[j] }
+func (b byValue) Swap(i, j int) {
+	b.processes[i], b.processes[j] = b.processes[j], b.processes[i]
+	b.values[i], b.values[j] = b.values[j], b.values[i]
+}
+
+func titleIndex(titles []string, title string) int {
+	for i, t := range titles {
+		if t == title {
+			return i
+		}
+	}
+	return -1
+}
+
+// parseProcessTime parses cumulative CPU time as reported by ps, formatted as [[dd-]hh:]mm:ss
+func parseProcessTime(s string) (time.Duration, error) {
+	var days int
+	value := s
+	if i := strings.Index(value, "-"); i >= 0 {
+		d, err := strconv.Atoi(value[:i])
+		if err != nil {
+			return 0, fmt.Errorf("invalid process time %q", s)
+		}
+		days = d
+		value = value[i+1:]
+	}
+	var seconds int
+	for _, part := range strings.Split(value, ":") {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return 0, fmt.Errorf("invalid process time %q", s)
+		}
+		seconds = seconds*60 + n
+	}
+	return time.Duration(days*24*60*60+seconds) * time.Second, nil
+}
+
+func formatProcessTime(d time.Duration) string {
+	seconds := int(d.Seconds())
+	return fmt.Sprintf("%02d:%02d:%02d", seconds/3600, seconds/60%60, seconds%60)
+}
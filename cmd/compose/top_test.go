@@ -0,0 +1,148 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose/v2/pkg/api"
+	"github.com/docker/compose/v2/pkg/mocks"
+)
+
+func TestAggregateTop(t *testing.T) {
+	titles := []string{"UID", "PID", "PPID", "C", "STIME", "TTY", "TIME", "CMD"}
+	containers := []api.ContainerProcSummary{
+		{
+			Name:    "project-web-1",
+			Service: "web",
+			Titles:  titles,
+			Processes: [][]string{
+				{"root", "1", "0", "2", "10:00", "?", "00:01:10", "nginx"},
+				{"root", "8", "1", "0", "10:00", "?", "00:00:05", "nginx: worker"},
+			},
+		},
+		{
+			Name:    "project-db-1",
+			Service: "db",
+			Titles:  titles,
+			Processes: [][]string{
+				{"root", "1", "0", "1", "10:00", "?", "1-02:00:00", "postgres"},
+			},
+		},
+		{
+			Name:    "project-web-2",
+			Service: "web",
+			Titles:  titles,
+			Processes: [][]string{
+				{"root", "1", "0", "3", "10:00", "?", "01:00:00", "nginx"},
+			},
+		},
+	}
+
+	summaries, err := aggregateTop(containers)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, summaries, []serviceProcSummary{
+		{Service: "db", Containers: 1, Processes: 1, CPU: 1, Time: 26 * time.Hour},
+		{Service: "web", Containers: 2, Processes: 3, CPU: 5, Time: time.Hour + time.Minute + 15*time.Second},
+	})
+	assert.Equal(t, formatProcessTime(summaries[1].Time), "01:01:15")
+}
+
+func TestParseProcessTime(t *testing.T) {
+	d, err := parseProcessTime("02:03")
+	assert.NilError(t, err)
+	assert.Equal(t, d, 2*time.Minute+3*time.Second)
+
+	_, err = parseProcessTime("a:b")
+	assert.ErrorContains(t, err, `invalid process time "a:b"`)
+}
+
+func TestSortProcesses(t *testing.T) {
+	container := api.ContainerProcSummary{
+		Name:   "project-web-1",
+		Titles: []string{"UID", "PID", "PPID", "C", "STIME", "TTY", "TIME", "CMD"},
+		Processes: [][]string{
+			{"root", "1", "0", "0", "10:00", "?", "00:00:00", "sleep infinity"},
+			{"root", "7", "1", "98", "10:00", "?", "00:01:00", "busy"},
+			{"root", "8", "1", "3", "10:00", "?", "00:00:01", "sh"},
+		},
+	}
+
+	err := sortProcesses(container, sortColumns["cpu"])
+	assert.NilError(t, err)
+	assert.Equal(t, container.Processes[0][7], "busy")
+	assert.Equal(t, container.Processes[1][7], "sh")
+	assert.Equal(t, container.Processes[2][7], "sleep infinity")
+
+	err = sortProcesses(container, sortColumns["mem"])
+	assert.ErrorContains(t, err, "no %MEM or RSS or VSZ column in processes of container project-web-1")
+}
+
+func TestTopFollow(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	origStdout := os.Stdout
+	t.Cleanup(func() {
+		os.Stdout = origStdout
+	})
+	f, err := os.Create(filepath.Join(t.TempDir(), "output.txt"))
+	assert.NilError(t, err)
+	defer func() { _ = f.Close() }()
+	os.Stdout = f
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	commands := []string{"sleep 10", "sleep 20"}
+	calls := 0
+	backend := mocks.NewMockService(ctrl)
+	backend.EXPECT().
+		Top(gomock.Any(), "test", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, projectName string, options api.TopOptions) ([]api.ContainerProcSummary, error) {
+			command := commands[calls]
+			calls++
+			if calls == len(commands) {
+				cancel()
+			}
+			return []api.ContainerProcSummary{{
+				Name:      "test-web-1",
+				Service:   "web",
+				Titles:    []string{"PID", "CMD"},
+				Processes: [][]string{{"1", command}},
+			}}, nil
+		}).Times(2)
+
+	opts := topOptions{projectOptions: &projectOptions{ProjectName: "test"}, follow: true, interval: time.Millisecond}
+	err = runTop(ctx, backend, opts, nil)
+	assert.NilError(t, err)
+
+	output, err := os.ReadFile(f.Name())
+	assert.NilError(t, err)
+	// each refresh prints a block, separated from the previous one by an empty line
+	blocks := strings.Split(string(output), "\n\n\n")
+	assert.Equal(t, len(blocks), 2, string(output))
+	assert.Assert(t, strings.HasPrefix(blocks[0], "test-web-1\nPID   CMD\n1     sleep 10"), blocks[0])
+	assert.Assert(t, strings.HasPrefix(blocks[1], "test-web-1\nPID   CMD\n1     sleep 20"), blocks[1])
+}
@@ -22,13 +22,17 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/docker/compose/v2/cmd/formatter"
 
+	"github.com/compose-spec/compose-go/cli"
 	"github.com/compose-spec/compose-go/types"
 	"github.com/spf13/cobra"
 
 	"github.com/docker/compose/v2/pkg/api"
+	"github.com/docker/compose/v2/pkg/compose"
+	"github.com/docker/compose/v2/pkg/progress"
 	"github.com/docker/compose/v2/pkg/utils"
 )
 
@@ -50,6 +54,24 @@ type upOptions struct {
 	attachDependencies bool
 	attach             []string
 	wait               bool
+	waitLogLines       int
+	waitTimeout        int
+	rollback           bool
+	environment        []string
+	noHealthcheck      bool
+	printCommands      bool
+	prefixWidth        int
+	noInterpolate      bool
+	stopTimeout        int
+	stopTimeoutChanged bool
+}
+
+// toProject loads the project to be run, failing on all the missing required variables before anything is created
+func (opts upOptions) toProject(ctx context.Context, p *projectOptions, services []string) (*types.Project, error) {
+	if opts.noInterpolate {
+		return p.toProject(ctx, services, cli.WithResolvedPaths(true), cli.WithInterpolation(false))
+	}
+	return p.toProjectCheckingVariables(ctx, services, cli.WithResolvedPaths(true))
 }
 
 func (opts upOptions) apply(project *types.Project, services []string) error {
@@ -89,6 +111,59 @@ func (opts upOptions) apply(project *types.Project, services []string) error {
 		}
 	}
 
+	for _, env := range opts.environment {
+		err := applyEnvironment(project, env)
+		if err != nil {
+			return err
+		}
+	}
+
+	if opts.noHealthcheck {
+		disableHealthchecks(project)
+	}
+
+	return nil
+}
+
+// disableHealthchecks disables the healthcheck of all services, dependencies on a service being healthy then only
+// requiring it to be started
+func disableHealthchecks(project *types.Project) {
+	for i, s := range project.Services {
+		s.HealthCheck = &types.HealthCheckConfig{Disable: true}
+		for name, dep := range s.DependsOn {
+			if dep.Condition == types.ServiceConditionHealthy {
+				dep.Condition = types.ServiceConditionStarted
+				s.DependsOn[name] = dep
+			}
+		}
+		project.Services[i] = s
+	}
+}
+
+// applyEnvironment sets a [SERVICE:]KEY=VAL variable in the environment of the selected service, or all services
+func applyEnvironment(project *types.Project, env string) error {
+	split := strings.SplitN(env, "=", 2)
+	if len(split) != 2 || split[0] == "" {
+		return fmt.Errorf("invalid --environment option %q. Should be [SERVICE:]KEY=VAL", env)
+	}
+	key, value := split[0], split[1]
+	service := ""
+	if i := strings.Index(key, ":"); i >= 0 {
+		service, key = key[:i], key[i+1:]
+		if _, err := project.GetService(service); err != nil {
+			return err
+		}
+	}
+	for i, s := range project.Services {
+		if service != "" && s.Name != service {
+			continue
+		}
+		if s.Environment == nil {
+			s.Environment = types.MappingWithEquals{}
+		}
+		s.Environment[key] = &value
+		project.Services[i] = s
+	}
 	return nil
 }
 
@@ -100,9 +175,18 @@ func upCommand(p *projectOptions, backend api.Service) *cobra.Command {
 		Short: "Create and start containers",
 		PreRunE: AdaptCmd(func(ctx context.Context, cmd *cobra.Command, args []string) error {
 			create.timeChanged = cmd.Flags().Changed("timeout")
+			up.stopTimeoutChanged = cmd.Flags().Changed("stop-timeout")
+			if up.printCommands {
+				// commands are printed as they run, which the TTY progress display would overwrite
+				progress.Mode = progress.ModePlain
+			}
 			return validateFlags(&up, &create)
 		}),
-		RunE: p.WithServices(func(ctx context.Context, project *types.Project, services []string) error {
+		RunE: Adapt(func(ctx context.Context, services []string) error {
+			project, err := up.toProject(ctx, p, services)
+			if err != nil {
+				return err
+			}
 			create.ignoreOrphans = utils.StringToBool(project.Environment["COMPOSE_IGNORE_ORPHANS"])
 			if create.ignoreOrphans && create.removeOrphans {
 				return fmt.Errorf("COMPOSE_IGNORE_ORPHANS and --remove-orphans cannot be combined")
@@ -119,19 +203,32 @@ func upCommand(p *projectOptions, backend api.Service) *cobra.Command {
 	flags.StringArrayVar(&up.scale, "scale", []string{}, "Scale SERVICE to NUM instances. Overrides the `scale` setting in the Compose file if present.")
 	flags.BoolVar(&up.noColor, "no-color", false, "Produce monochrome output.")
 	flags.BoolVar(&up.noPrefix, "no-log-prefix", false, "Don't print prefix in logs.")
+	flags.IntVar(&up.prefixWidth, "prefix-width", 0, "Pad log prefixes to this width. 0 pads them to the longest container name.")
 	flags.BoolVar(&create.forceRecreate, "force-recreate", false, "Recreate containers even if their configuration and image haven't changed.")
 	flags.BoolVar(&create.noRecreate, "no-recreate", false, "If containers already exist, don't recreate them. Incompatible with --force-recreate.")
-	flags.BoolVar(&up.noStart, "no-start", false, "Don't start the services after creating them.")
+	flags.StringVar(&create.recreateOrder, "recreate-strategy", api.RecreateOrderAllAtOnce, "How to recreate the containers of a service: all-at-once, or rolling to recreate them one at a time, waiting for each to be running|healthy.")
+	flags.BoolVar(&create.recreateOnLabelChange, "recreate-on-label-change", false, "Only recreate containers which labels have changed, ignoring other configuration changes. Incompatible with --force-recreate and --no-recreate.")
+	flags.BoolVar(&up.noStart, "no-start", false, `Don't start the services after creating them. Equivalent to "compose create".`)
 	flags.BoolVar(&up.cascadeStop, "abort-on-container-exit", false, "Stops all containers if any container was stopped. Incompatible with -d")
 	flags.StringVar(&up.exitCodeFrom, "exit-code-from", "", "Return the exit code of the selected service container. Implies --abort-on-container-exit")
 	flags.IntVarP(&create.timeout, "timeout", "t", 10, "Use this timeout in seconds for container shutdown when attached or when containers are already running.")
+	flags.IntVar(&up.stopTimeout, "stop-timeout", 0, "Timeout in seconds to stop attached containers on interrupt before killing them (default: the stop_grace_period of each service).")
 	flags.BoolVar(&up.noDeps, "no-deps", false, "Don't start linked services.")
 	flags.BoolVar(&create.recreateDeps, "always-recreate-deps", false, "Recreate dependent containers. Incompatible with --no-recreate.")
 	flags.BoolVarP(&create.noInherit, "renew-anon-volumes", "V", false, "Recreate anonymous volumes instead of retrieving data from the previous containers.")
 	flags.BoolVar(&up.attachDependencies, "attach-dependencies", false, "Attach to dependent containers.")
 	flags.BoolVar(&create.quietPull, "quiet-pull", false, "Pull without printing progress information.")
+	flags.BoolVar(&create.quietBuild, "quiet-build", false, "Only print a line per service telling whether its image was built, instead of the build output.")
+	flags.BoolVar(&create.preferBuild, "pull-policy-per-service", false, "Don't pull missing images of services which can be built, build them instead.")
 	flags.StringArrayVar(&up.attach, "attach", []string{}, "Attach to service output.")
-	flags.BoolVar(&up.wait, "wait", false, "Wait for services to be running|healthy. Implies detached mode.")
+	flags.BoolVar(&up.noInterpolate, "no-interpolate", false, "Don't interpolate environment variables, leaving ${VAR} references as is.")
+	flags.BoolVar(&up.wait, "wait", false, "Wait for services to be running|healthy, only the selected ones if any. Implies detached mode.")
+	flags.IntVar(&up.waitTimeout, "wait-timeout", 0, "Maximum duration in seconds to wait for services to be running|healthy with --wait. 0 waits with no limit.")
+	flags.IntVar(&up.waitLogLines, "wait-log-lines", 20, "Number of log lines printed for each container of services failing to get running|healthy with --wait. 0 to disable.")
+	flags.StringArrayVar(&up.environment, "environment", []string{}, "Set environment variables in all services as KEY=VAL, or in a single one as SERVICE:KEY=VAL. Overrides the Compose file.")
+	flags.BoolVar(&up.noHealthcheck, "no-healthcheck", false, "Disable the healthchecks of services, so dependencies on a healthy service only wait for it to be started.")
+	flags.BoolVar(&up.printCommands, "print-command", false, "Print the docker commands equivalent to the operations being run.")
+	flags.BoolVar(&up.rollback, "rollback", false, "Remove containers of services created by this command if it fails. Incompatible with --no-start.")
 
 	return upCmd
 }
@@ -146,6 +243,33 @@ func validateFlags(up *upOptions, create *createOptions) error {
 		}
 		up.Detach = true
 	}
+	if up.noStart {
+		if up.wait || up.attachDependencies || up.cascadeStop || len(up.attach) > 0 {
+			return fmt.Errorf("--no-start cannot be combined with --wait, --abort-on-container-exit, --attach or --attach-dependencies")
+		}
+		if up.rollback {
+			return fmt.Errorf("--no-start and --rollback are incompatible")
+		}
+		up.Detach = true
+	}
+	if up.waitTimeout < 0 {
+		return fmt.Errorf("invalid --wait-timeout %d, must not be negative", up.waitTimeout)
+	}
+	if up.waitTimeout > 0 && !up.wait {
+		return fmt.Errorf("--wait-timeout requires --wait")
+	}
+	if up.waitLogLines < 0 {
+		return fmt.Errorf("invalid --wait-log-lines %d, must not be negative", up.waitLogLines)
+	}
+	if up.prefixWidth < 0 {
+		return fmt.Errorf("invalid --prefix-width %d, must not be negative", up.prefixWidth)
+	}
+	if up.stopTimeout < 0 {
+		return fmt.Errorf("invalid --stop-timeout %d, must not be negative", up.stopTimeout)
+	}
+	if up.stopTimeoutChanged && up.Detach {
+		return fmt.Errorf("--stop-timeout only applies to attached containers, it cannot be combined with --detach, --wait or --no-start")
+	}
 	if create.Build && create.noBuild {
 		return fmt.Errorf("--build and --no-build are incompatible")
 	}
@@ -155,12 +279,53 @@ func validateFlags(up *upOptions, create *createOptions) error {
 	if create.forceRecreate && create.noRecreate {
 		return fmt.Errorf("--force-recreate and --no-recreate are incompatible")
 	}
+	switch create.recreateOrder {
+	case "", api.RecreateOrderAllAtOnce:
+	case api.RecreateOrderRolling:
+		if create.noRecreate || up.noStart {
+			return fmt.Errorf("--recreate-strategy rolling cannot be combined with --no-recreate or --no-start")
+		}
+	default:
+		return fmt.Errorf("invalid --recreate-strategy %q, must be one of %s or %s", create.recreateOrder, api.RecreateOrderAllAtOnce, api.RecreateOrderRolling)
+	}
+	if create.recreateOnLabelChange && (create.forceRecreate || create.noRecreate) {
+		return fmt.Errorf("--recreate-on-label-change cannot be combined with --force-recreate or --no-recreate")
+	}
 	if create.recreateDeps && create.noRecreate {
 		return fmt.Errorf("--always-recreate-deps and --no-recreate are incompatible")
 	}
+	if compose.DryRun {
+		// there is no container to attach to
+		up.Detach = true
+	}
 	return nil
 }
 
+// getStopTimeout returns the timeout to stop attached containers, nil to use the stop timeout of each container
+func (opts upOptions) getStopTimeout() *time.Duration {
+	if !opts.stopTimeoutChanged {
+		return nil
+	}
+	t := time.Duration(opts.stopTimeout) * time.Second
+	return &t
+}
+
+// attachTo returns the services whose containers are attached to, the service selected by --exit-code-from included
+// as its exit code is only known from the events of attached containers
+func (opts upOptions) attachTo(project *types.Project, services []string) []string {
+	attachTo := services
+	if len(opts.attach) > 0 {
+		attachTo = opts.attach
+	}
+	if opts.attachDependencies || len(attachTo) == 0 {
+		return project.ServiceNames()
+	}
+	if opts.exitCodeFrom != "" && !utils.StringContains(attachTo, opts.exitCodeFrom) {
+		attachTo = append(append([]string{}, attachTo...), opts.exitCodeFrom)
+	}
+	return attachTo
+}
+
 func runUp(ctx context.Context, backend api.Service, createOptions createOptions, upOptions upOptions, project *types.Project, services []string) error {
 	if len(project.Services) == 0 {
 		return fmt.Errorf("no service selected")
@@ -175,19 +340,10 @@ func runUp(ctx context.Context, backend api.Service, createOptions createOptions
 
 	var consumer api.LogConsumer
 	if !upOptions.Detach {
-		consumer = formatter.NewLogConsumer(ctx, os.Stdout, !upOptions.noColor, !upOptions.noPrefix)
+		consumer = formatter.NewLogConsumer(ctx, os.Stdout, !upOptions.noColor, !upOptions.noPrefix, upOptions.prefixWidth)
 	}
 
-	attachTo := services
-	if len(upOptions.attach) > 0 {
-		attachTo = upOptions.attach
-	}
-	if upOptions.attachDependencies {
-		attachTo = project.ServiceNames()
-	}
-	if len(attachTo) == 0 {
-		attachTo = project.ServiceNames()
-	}
+	attachTo := upOptions.attachTo(project, services)
 
 	create := api.CreateOptions{
 		Services:             services,
@@ -198,6 +354,10 @@ func runUp(ctx context.Context, backend api.Service, createOptions createOptions
 		Inherit:              !createOptions.noInherit,
 		Timeout:              createOptions.GetTimeout(),
 		QuietPull:            createOptions.quietPull,
+		QuietBuild:           createOptions.quietBuild,
+		PreferBuild:          createOptions.preferBuild,
+		PrintCommands:        upOptions.printCommands,
+		RecreateOrder:        createOptions.recreateOrder,
 	}
 
 	if upOptions.noStart {
@@ -213,7 +373,12 @@ func runUp(ctx context.Context, backend api.Service, createOptions createOptions
 			ExitCodeFrom: upOptions.exitCodeFrom,
 			CascadeStop:  upOptions.cascadeStop,
 			Wait:         upOptions.wait,
+			WaitServices: services,
+			WaitLogLines: upOptions.waitLogLines,
+			WaitTimeout:  time.Duration(upOptions.waitTimeout) * time.Second,
 		},
+		Rollback:    upOptions.rollback,
+		StopTimeout: upOptions.getStopTimeout(),
 	})
 }
 
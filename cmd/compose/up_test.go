@@ -18,9 +18,12 @@ package compose
 
 import (
 	"testing"
+	"time"
 
 	"github.com/compose-spec/compose-go/types"
 	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose/v2/pkg/api"
 )
 
 func TestApplyScaleOpt(t *testing.T) {
@@ -41,3 +44,170 @@ func TestApplyScaleOpt(t *testing.T) {
 	assert.NilError(t, err)
 	assert.Equal(t, *foo.Deploy.Replicas, uint64(2))
 }
+
+func TestApplyEnvironmentOpt(t *testing.T) {
+	declared := "info"
+	p := types.Project{
+		Services: []types.ServiceConfig{
+			{
+				Name:        "foo",
+				Environment: types.MappingWithEquals{"LOG_LEVEL": &declared},
+			},
+			{
+				Name: "bar",
+			},
+		},
+	}
+	opt := upOptions{environment: []string{"DEBUG=1", "foo:LOG_LEVEL=debug"}}
+	err := opt.apply(&p, nil)
+	assert.NilError(t, err)
+	foo, err := p.GetService("foo")
+	assert.NilError(t, err)
+	assert.Equal(t, *foo.Environment["DEBUG"], "1")
+	assert.Equal(t, *foo.Environment["LOG_LEVEL"], "debug")
+	bar, err := p.GetService("bar")
+	assert.NilError(t, err)
+	assert.Equal(t, *bar.Environment["DEBUG"], "1")
+	_, ok := bar.Environment["LOG_LEVEL"]
+	assert.Assert(t, !ok)
+
+	opt = upOptions{environment: []string{"unknown:LOG_LEVEL=debug"}}
+	err = opt.apply(&p, nil)
+	assert.ErrorContains(t, err, "no such service: unknown")
+
+	opt = upOptions{environment: []string{"LOG_LEVEL"}}
+	err = opt.apply(&p, nil)
+	assert.ErrorContains(t, err, "Should be [SERVICE:]KEY=VAL")
+}
+
+func TestApplyNoHealthcheckOpt(t *testing.T) {
+	p := types.Project{
+		Services: []types.ServiceConfig{
+			{
+				Name: "db",
+				HealthCheck: &types.HealthCheckConfig{
+					Test: types.HealthCheckTest{"CMD", "pg_isready"},
+				},
+			},
+			{
+				Name: "web",
+				DependsOn: types.DependsOnConfig{
+					"db":    {Condition: types.ServiceConditionHealthy},
+					"cache": {Condition: types.ServiceConditionCompletedSuccessfully},
+				},
+			},
+		},
+	}
+	opt := upOptions{noHealthcheck: true}
+	err := opt.apply(&p, nil)
+	assert.NilError(t, err)
+	db, err := p.GetService("db")
+	assert.NilError(t, err)
+	assert.Assert(t, db.HealthCheck.Disable)
+	web, err := p.GetService("web")
+	assert.NilError(t, err)
+	assert.Assert(t, web.HealthCheck.Disable)
+	assert.Equal(t, web.DependsOn["db"].Condition, types.ServiceConditionStarted)
+	assert.Equal(t, web.DependsOn["cache"].Condition, types.ServiceConditionCompletedSuccessfully)
+}
+
+func TestNoStartValidation(t *testing.T) {
+	up := upOptions{noStart: true}
+	err := validateFlags(&up, &createOptions{})
+	assert.NilError(t, err)
+	assert.Assert(t, up.Detach)
+
+	up = upOptions{noStart: true, wait: true}
+	err = validateFlags(&up, &createOptions{})
+	assert.ErrorContains(t, err, "--no-start cannot be combined with --wait")
+}
+
+func TestRollbackValidation(t *testing.T) {
+	up := upOptions{rollback: true}
+	err := validateFlags(&up, &createOptions{})
+	assert.NilError(t, err)
+
+	up = upOptions{noStart: true, rollback: true}
+	err = validateFlags(&up, &createOptions{})
+	assert.ErrorContains(t, err, "--no-start and --rollback are incompatible")
+}
+
+func TestWaitLogLinesValidation(t *testing.T) {
+	up := upOptions{wait: true, waitLogLines: 50}
+	err := validateFlags(&up, &createOptions{})
+	assert.NilError(t, err)
+
+	up = upOptions{wait: true, waitLogLines: -1}
+	err = validateFlags(&up, &createOptions{})
+	assert.ErrorContains(t, err, "invalid --wait-log-lines -1, must not be negative")
+}
+
+func TestWaitTimeoutValidation(t *testing.T) {
+	up := upOptions{wait: true, waitTimeout: 30}
+	err := validateFlags(&up, &createOptions{})
+	assert.NilError(t, err)
+
+	up = upOptions{wait: true, waitTimeout: -1}
+	err = validateFlags(&up, &createOptions{})
+	assert.ErrorContains(t, err, "invalid --wait-timeout -1, must not be negative")
+
+	up = upOptions{waitTimeout: 30}
+	err = validateFlags(&up, &createOptions{})
+	assert.ErrorContains(t, err, "--wait-timeout requires --wait")
+}
+
+func TestStopTimeoutValidation(t *testing.T) {
+	up := upOptions{stopTimeout: 3, stopTimeoutChanged: true}
+	err := validateFlags(&up, &createOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, *up.getStopTimeout(), 3*time.Second)
+
+	up = upOptions{}
+	assert.Assert(t, up.getStopTimeout() == nil)
+
+	up = upOptions{stopTimeout: -1, stopTimeoutChanged: true}
+	err = validateFlags(&up, &createOptions{})
+	assert.ErrorContains(t, err, "invalid --stop-timeout -1, must not be negative")
+
+	up = upOptions{wait: true, stopTimeout: 3, stopTimeoutChanged: true}
+	err = validateFlags(&up, &createOptions{})
+	assert.ErrorContains(t, err, "--stop-timeout only applies to attached containers")
+}
+
+func TestRecreateStrategyValidation(t *testing.T) {
+	err := validateFlags(&upOptions{}, &createOptions{recreateOrder: api.RecreateOrderRolling})
+	assert.NilError(t, err)
+
+	err = validateFlags(&upOptions{}, &createOptions{recreateOrder: api.RecreateOrderAllAtOnce})
+	assert.NilError(t, err)
+
+	err = validateFlags(&upOptions{}, &createOptions{recreateOrder: "blue-green"})
+	assert.ErrorContains(t, err, `invalid --recreate-strategy "blue-green", must be one of all-at-once or rolling`)
+
+	err = validateFlags(&upOptions{}, &createOptions{recreateOrder: api.RecreateOrderRolling, noRecreate: true})
+	assert.ErrorContains(t, err, "--recreate-strategy rolling cannot be combined with --no-recreate or --no-start")
+
+	err = validateFlags(&upOptions{noStart: true}, &createOptions{recreateOrder: api.RecreateOrderRolling})
+	assert.ErrorContains(t, err, "--recreate-strategy rolling cannot be combined with --no-recreate or --no-start")
+}
+
+func TestUpAttachTo(t *testing.T) {
+	project := &types.Project{
+		Services: []types.ServiceConfig{
+			{Name: "db"},
+			{Name: "tests"},
+			{Name: "web"},
+		},
+	}
+	assert.DeepEqual(t, upOptions{}.attachTo(project, nil), []string{"db", "tests", "web"})
+	assert.DeepEqual(t, upOptions{}.attachTo(project, []string{"web"}), []string{"web"})
+	assert.DeepEqual(t, upOptions{attach: []string{"db"}}.attachTo(project, []string{"web"}), []string{"db"})
+	assert.DeepEqual(t, upOptions{attachDependencies: true}.attachTo(project, []string{"web"}), []string{"db", "tests", "web"})
+
+	// the service the exit code is returned from is attached to, to receive its exit
+	services := []string{"web"}
+	assert.DeepEqual(t, upOptions{exitCodeFrom: "tests"}.attachTo(project, services), []string{"web", "tests"})
+	assert.DeepEqual(t, services, []string{"web"})
+	assert.DeepEqual(t, upOptions{attach: []string{"db"}, exitCodeFrom: "tests"}.attachTo(project, nil), []string{"db", "tests"})
+	assert.DeepEqual(t, upOptions{exitCodeFrom: "web"}.attachTo(project, services), []string{"web"})
+}
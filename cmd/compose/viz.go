@@ -0,0 +1,226 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/compose-spec/compose-go/types"
+	"github.com/spf13/cobra"
+)
+
+type vizOptions struct {
+	*projectOptions
+	Format          string
+	includeNetworks bool
+	includeVolumes  bool
+}
+
+// vizEdge is a relation from a service to another service, a network or a volume of the project
+type vizEdge struct {
+	From  string
+	To    string
+	Label string
+}
+
+// vizGraph is the dependency graph of a project, its nodes being identified by kind and name
+type vizGraph struct {
+	Services []string
+	Networks []string
+	Volumes  []string
+	Edges    []vizEdge
+}
+
+func vizCommand(p *projectOptions) *cobra.Command {
+	opts := vizOptions{
+		projectOptions: p,
+	}
+	vizCmd := &cobra.Command{
+		Use:   "viz",
+		Short: "Render the dependency graph of the project services",
+		Args:  cobra.NoArgs,
+		PreRunE: Adapt(func(ctx context.Context, args []string) error {
+			if opts.Format != "dot" && opts.Format != "mermaid" {
+				return fmt.Errorf("unsupported format %q, must be one of dot or mermaid", opts.Format)
+			}
+			return nil
+		}),
+		RunE: p.WithProject(func(ctx context.Context, project *types.Project) error {
+			return runViz(ctx, opts, project)
+		}),
+	}
+	flags := vizCmd.Flags()
+	flags.StringVar(&opts.Format, "format", "dot", "Format the output. Values: [dot | mermaid].")
+	flags.BoolVar(&opts.includeNetworks, "include-networks", false, "Include the networks and the services connected to them.")
+	flags.BoolVar(&opts.includeVolumes, "include-volumes", false, "Include the named volumes and the services mounting them.")
+	return vizCmd
+}
+
+func runViz(_ context.Context, opts vizOptions, project *types.Project) error {
+	graph := newVizGraph(project, opts.includeNetworks, opts.includeVolumes)
+	if opts.Format == "mermaid" {
+		_, err := fmt.Fprint(os.Stdout, graph.mermaid())
+		return err
+	}
+	_, err := fmt.Fprint(os.Stdout, graph.dot(project.Name))
+	return err
+}
+
+// newVizGraph builds the dependency graph of a project from its model: services are related by `depends_on`,
+// `links`, `network_mode: service:NAME` and `volumes_from`, and optionally to the networks they are connected to and
+// the named volumes they mount
+func newVizGraph(project *types.Project, includeNetworks, includeVolumes bool) vizGraph {
+	services := append(types.Services{}, project.Services...)
+	sort.Slice(services, func(i, j int) bool {
+		return services[i].Name < services[j].Name
+	})
+
+	var graph vizGraph
+	networks := map[string]bool{}
+	volumes := map[string]bool{}
+	for _, service := range services {
+		graph.Services = append(graph.Services, service.Name)
+
+		for _, dependency := range sortedDependencies(service.DependsOn) {
+			label := "depends_on"
+			if condition := service.DependsOn[dependency].Condition; condition != "" && condition != types.ServiceConditionStarted {
+				label += ": " + condition
+			}
+			graph.Edges = append(graph.Edges, vizEdge{From: "service:" + service.Name, To: "service:" + dependency, Label: label})
+		}
+		for _, link := range service.Links {
+			target, _, _ := strings.Cut(link, ":")
+			graph.Edges = append(graph.Edges, vizEdge{From: "service:" + service.Name, To: "service:" + target, Label: "links"})
+		}
+		if strings.HasPrefix(service.NetworkMode, types.NetworkModeServicePrefix) {
+			target := strings.TrimPrefix(service.NetworkMode, types.NetworkModeServicePrefix)
+			graph.Edges = append(graph.Edges, vizEdge{From: "service:" + service.Name, To: "service:" + target, Label: "network_mode"})
+		}
+		for _, source := range service.VolumesFrom {
+			parts := strings.Split(source, ":")
+			target := parts[0]
+			if parts[0] == "container" {
+				continue
+			}
+			if parts[0] == "service" && len(parts) > 1 {
+				target = parts[1]
+			}
+			graph.Edges = append(graph.Edges, vizEdge{From: "service:" + service.Name, To: "service:" + target, Label: "volumes_from"})
+		}
+
+		if includeNetworks && service.NetworkMode == "" {
+			var serviceNetworks []string
+			for network := range service.Networks {
+				serviceNetworks = append(serviceNetworks, network)
+			}
+			sort.Strings(serviceNetworks)
+			for _, network := range serviceNetworks {
+				networks[network] = true
+				graph.Edges = append(graph.Edges, vizEdge{From: "service:" + service.Name, To: "network:" + network})
+			}
+		}
+		if includeVolumes {
+			for _, volume := range service.Volumes {
+				if volume.Type != types.VolumeTypeVolume || volume.Source == "" {
+					continue
+				}
+				if _, ok := project.Volumes[volume.Source]; !ok {
+					continue
+				}
+				volumes[volume.Source] = true
+				graph.Edges = append(graph.Edges, vizEdge{From: "service:" + service.Name, To: "volume:" + volume.Source, Label: volume.Target})
+			}
+		}
+	}
+	for network := range networks {
+		graph.Networks = append(graph.Networks, network)
+	}
+	sort.Strings(graph.Networks)
+	for volume := range volumes {
+		graph.Volumes = append(graph.Volumes, volume)
+	}
+	sort.Strings(graph.Volumes)
+	return graph
+}
+
+func sortedDependencies(dependsOn types.DependsOnConfig) []string {
+	var dependencies []string
+	for dependency := range dependsOn {
+		dependencies = append(dependencies, dependency)
+	}
+	sort.Strings(dependencies)
+	return dependencies
+}
+
+// dot renders the graph in the Graphviz DOT language
+func (g vizGraph) dot(name string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "digraph %q {\n", name)
+	for _, service := range g.Services {
+		fmt.Fprintf(&b, "  %q [label=%q, shape=box];\n", "service:"+service, service)
+	}
+	for _, network := range g.Networks {
+		fmt.Fprintf(&b, "  %q [label=%q, shape=ellipse];\n", "network:"+network, network)
+	}
+	for _, volume := range g.Volumes {
+		fmt.Fprintf(&b, "  %q [label=%q, shape=cylinder];\n", "volume:"+volume, volume)
+	}
+	for _, edge := range g.Edges {
+		if edge.Label == "" {
+			fmt.Fprintf(&b, "  %q -> %q;\n", edge.From, edge.To)
+			continue
+		}
+		fmt.Fprintf(&b, "  %q -> %q [label=%q];\n", edge.From, edge.To, edge.Label)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+var mermaidUnsafe = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// mermaidID turns a node identifier into one Mermaid accepts, as names may contain dots and dashes
+func mermaidID(id string) string {
+	return mermaidUnsafe.ReplaceAllString(id, "_")
+}
+
+// mermaid renders the graph as a Mermaid flowchart
+func (g vizGraph) mermaid() string {
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	for _, service := range g.Services {
+		fmt.Fprintf(&b, "  %s[%q]\n", mermaidID("service:"+service), service)
+	}
+	for _, network := range g.Networks {
+		fmt.Fprintf(&b, "  %s{{%q}}\n", mermaidID("network:"+network), network)
+	}
+	for _, volume := range g.Volumes {
+		fmt.Fprintf(&b, "  %s[(%q)]\n", mermaidID("volume:"+volume), volume)
+	}
+	for _, edge := range g.Edges {
+		if edge.Label == "" {
+			fmt.Fprintf(&b, "  %s --> %s\n", mermaidID(edge.From), mermaidID(edge.To))
+			continue
+		}
+		fmt.Fprintf(&b, "  %s -->|%q| %s\n", mermaidID(edge.From), edge.Label, mermaidID(edge.To))
+	}
+	return b.String()
+}
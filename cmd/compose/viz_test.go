@@ -0,0 +1,112 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"testing"
+
+	"github.com/compose-spec/compose-go/types"
+	"gotest.tools/v3/assert"
+)
+
+func vizProject() *types.Project {
+	return &types.Project{
+		Name: "test",
+		Services: types.Services{
+			{
+				Name: "web",
+				DependsOn: types.DependsOnConfig{
+					"db":    {Condition: types.ServiceConditionHealthy},
+					"cache": {Condition: types.ServiceConditionStarted},
+				},
+				Links:    []string{"db:database"},
+				Networks: map[string]*types.ServiceNetworkConfig{"front": nil, "back": nil},
+			},
+			{
+				Name:        "db",
+				Networks:    map[string]*types.ServiceNetworkConfig{"back": nil},
+				VolumesFrom: []string{"container:legacy"},
+				Volumes: []types.ServiceVolumeConfig{
+					{Type: types.VolumeTypeVolume, Source: "db-data", Target: "/var/lib/db"},
+					{Type: types.VolumeTypeBind, Source: "/tmp", Target: "/tmp"},
+				},
+			},
+			{
+				Name:        "cache",
+				NetworkMode: "service:db",
+				VolumesFrom: []string{"db:ro"},
+			},
+		},
+		Networks: types.Networks{"front": {}, "back": {}},
+		Volumes:  types.Volumes{"db-data": {}},
+	}
+}
+
+func TestVizGraph(t *testing.T) {
+	graph := newVizGraph(vizProject(), false, false)
+	assert.DeepEqual(t, graph, vizGraph{
+		Services: []string{"cache", "db", "web"},
+		Edges: []vizEdge{
+			{From: "service:cache", To: "service:db", Label: "network_mode"},
+			{From: "service:cache", To: "service:db", Label: "volumes_from"},
+			{From: "service:web", To: "service:cache", Label: "depends_on"},
+			{From: "service:web", To: "service:db", Label: "depends_on: service_healthy"},
+			{From: "service:web", To: "service:db", Label: "links"},
+		},
+	})
+}
+
+func TestVizGraphNetworksAndVolumes(t *testing.T) {
+	graph := newVizGraph(vizProject(), true, true)
+	assert.DeepEqual(t, graph.Networks, []string{"back", "front"})
+	assert.DeepEqual(t, graph.Volumes, []string{"db-data"})
+	assert.DeepEqual(t, graph.Edges[2:], []vizEdge{
+		{From: "service:db", To: "network:back"},
+		{From: "service:db", To: "volume:db-data", Label: "/var/lib/db"},
+		{From: "service:web", To: "service:cache", Label: "depends_on"},
+		{From: "service:web", To: "service:db", Label: "depends_on: service_healthy"},
+		{From: "service:web", To: "service:db", Label: "links"},
+		{From: "service:web", To: "network:back"},
+		{From: "service:web", To: "network:front"},
+	})
+}
+
+func TestVizRender(t *testing.T) {
+	graph := vizGraph{
+		Services: []string{"db", "web-app"},
+		Volumes:  []string{"data"},
+		Edges: []vizEdge{
+			{From: "service:web-app", To: "service:db", Label: "depends_on"},
+			{From: "service:db", To: "volume:data", Label: "/data"},
+		},
+	}
+	assert.Equal(t, graph.dot("test"), `digraph "test" {
+  "service:db" [label="db", shape=box];
+  "service:web-app" [label="web-app", shape=box];
+  "volume:data" [label="data", shape=cylinder];
+  "service:web-app" -> "service:db" [label="depends_on"];
+  "service:db" -> "volume:data" [label="/data"];
+}
+`)
+	assert.Equal(t, graph.mermaid(), `flowchart LR
+  service_db["db"]
+  service_web_app["web-app"]
+  volume_data[("data")]
+  service_web_app -->|"depends_on"| service_db
+  service_db -->|"/data"| volume_data
+`)
+}
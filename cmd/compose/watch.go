@@ -0,0 +1,57 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"time"
+
+	"github.com/compose-spec/compose-go/types"
+	"github.com/spf13/cobra"
+
+	"github.com/docker/compose/v2/pkg/api"
+)
+
+type watchOptions struct {
+	*projectOptions
+	interval time.Duration
+	noUp     bool
+}
+
+func watchCommand(p *projectOptions, backend api.Service) *cobra.Command {
+	opts := watchOptions{
+		projectOptions: p,
+	}
+	watchCmd := &cobra.Command{
+		Use:   "watch [SERVICE...]",
+		Short: "Watch build contexts and configured paths, then sync, rebuild or restart services on changes",
+		RunE: p.WithServices(func(ctx context.Context, project *types.Project, services []string) error {
+			return runWatch(ctx, backend, opts, project, services)
+		}),
+		ValidArgsFunction: serviceCompletion(p),
+	}
+	watchCmd.Flags().DurationVar(&opts.interval, "interval", 500*time.Millisecond, "Delay between two scans of the watched paths")
+	watchCmd.Flags().BoolVar(&opts.noUp, "no-up", false, "Watch the running containers of the project, without running up first")
+	return watchCmd
+}
+
+func runWatch(ctx context.Context, backend api.Service, opts watchOptions, project *types.Project, services []string) error {
+	return backend.Watch(ctx, project, services, api.WatchOptions{
+		Interval: opts.interval,
+		NoUp:     opts.noUp,
+	})
+}
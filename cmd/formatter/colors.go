@@ -60,11 +60,20 @@ func useAnsi(ansi string) bool {
 	case Always:
 		return true
 	case Auto:
-		return isatty.IsTerminal(os.Stdout.Fd())
+		return isTerminal(os.Stdout)
 	}
 	return false
 }
 
+// isTerminal checks the file is attached to a terminal which can render ANSI codes
+func isTerminal(f *os.File) bool {
+	if os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	fd := f.Fd()
+	return isatty.IsTerminal(fd) || isatty.IsCygwinTerminal(fd)
+}
+
 // colorFunc use ANSI codes to render colored text on console
 type colorFunc func(s string) string
 
@@ -0,0 +1,52 @@
+//go:build !windows
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package formatter
+
+import (
+	"os"
+	"testing"
+
+	"github.com/containerd/console"
+	"gotest.tools/v3/assert"
+)
+
+func TestIsTerminalPipe(t *testing.T) {
+	r, w, err := os.Pipe()
+	assert.NilError(t, err)
+	defer r.Close() // nolint: errcheck
+	defer w.Close() // nolint: errcheck
+
+	assert.Assert(t, !isTerminal(w))
+}
+
+func TestIsTerminalPty(t *testing.T) {
+	t.Setenv("TERM", "xterm")
+	pty, slave, err := console.NewPty()
+	assert.NilError(t, err)
+	defer pty.Close() // nolint: errcheck
+
+	f, err := os.OpenFile(slave, os.O_RDWR, 0)
+	assert.NilError(t, err)
+	defer f.Close() // nolint: errcheck
+
+	assert.Assert(t, isTerminal(f))
+
+	t.Setenv("TERM", "dumb")
+	assert.Assert(t, !isTerminal(f))
+}
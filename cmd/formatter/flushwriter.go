@@ -0,0 +1,79 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package formatter
+
+import (
+	"bufio"
+	"io"
+	"sync"
+	"time"
+)
+
+// FlushingWriter buffers writes to an underlying writer and flushes them at a regular interval, so that high volume
+// output is written in batches rather than line by line. Writes are serialized, preserving their order.
+type FlushingWriter struct {
+	mutex   sync.Mutex
+	buffer  *bufio.Writer
+	done    chan struct{}
+	stopped chan struct{}
+}
+
+// NewFlushingWriter creates a FlushingWriter flushing to w every interval, until closed
+func NewFlushingWriter(w io.Writer, interval time.Duration) *FlushingWriter {
+	f := &FlushingWriter{
+		buffer:  bufio.NewWriterSize(w, 64*1024),
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	go f.flushEvery(interval)
+	return f
+}
+
+func (f *FlushingWriter) flushEvery(interval time.Duration) {
+	defer close(f.stopped)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-f.done:
+			return
+		case <-ticker.C:
+			f.Flush() // nolint: errcheck
+		}
+	}
+}
+
+// Write buffers p, which is written to the underlying writer on the next flush, or as soon as the buffer is full
+func (f *FlushingWriter) Write(p []byte) (int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.buffer.Write(p)
+}
+
+// Flush writes the buffered data to the underlying writer
+func (f *FlushingWriter) Flush() error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.buffer.Flush()
+}
+
+// Close stops the periodic flush and writes the remaining buffered data
+func (f *FlushingWriter) Close() error {
+	close(f.done)
+	<-f.stopped
+	return f.Flush()
+}
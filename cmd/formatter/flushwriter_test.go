@@ -0,0 +1,104 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package formatter
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+// syncBuffer is a bytes.Buffer safe to read while being written by the periodic flush
+type syncBuffer struct {
+	mutex  sync.Mutex
+	buffer bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.buffer.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.buffer.String()
+}
+
+func TestFlushingWriter(t *testing.T) {
+	var out syncBuffer
+	w := NewFlushingWriter(&out, time.Hour)
+	consumer := NewLogConsumer(context.Background(), w, false, true, 0)
+	consumer.Register("web-1")
+	for i := 0; i < 100; i++ {
+		consumer.Log("web-1", "web", fmt.Sprintf("line %d", i))
+	}
+	assert.Equal(t, out.String(), "", "output should be buffered until the next flush")
+
+	assert.NilError(t, w.Close())
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	assert.Equal(t, len(lines), 100)
+	for i, line := range lines {
+		assert.Equal(t, line, fmt.Sprintf("web-1  | line %d", i))
+	}
+}
+
+func TestFlushingWriterInterval(t *testing.T) {
+	var out syncBuffer
+	w := NewFlushingWriter(&out, 10*time.Millisecond)
+	defer w.Close() // nolint: errcheck
+
+	_, err := w.Write([]byte("ready\n"))
+	assert.NilError(t, err)
+	for start := time.Now(); out.String() == "" && time.Since(start) < 5*time.Second; {
+		time.Sleep(5 * time.Millisecond)
+	}
+	assert.Equal(t, out.String(), "ready\n")
+}
+
+func BenchmarkLogConsumer(b *testing.B) {
+	run := func(b *testing.B, flushInterval time.Duration) {
+		f, err := os.Create(filepath.Join(b.TempDir(), "logs.txt"))
+		assert.NilError(b, err)
+		defer f.Close() // nolint: errcheck
+
+		var out io.Writer = f
+		if flushInterval > 0 {
+			w := NewFlushingWriter(f, flushInterval)
+			defer w.Close() // nolint: errcheck
+			out = w
+		}
+		consumer := NewLogConsumer(context.Background(), out, false, true, 0)
+		consumer.Register("web-1")
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			consumer.Log("web-1", "web", "GET /health HTTP/1.1 200 OK")
+		}
+	}
+	b.Run("per-line", func(b *testing.B) { run(b, 0) })
+	b.Run("flush-interval=100ms", func(b *testing.B) { run(b, 100*time.Millisecond) })
+}
@@ -0,0 +1,108 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package formatter
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+// FileLogConsumer is a LogConsumer writing logs of each container to its own file
+type FileLogConsumer struct {
+	ctx      context.Context
+	dir      string
+	fileName func(container, service string) string
+	mutex    sync.Mutex
+	files    map[string]*os.File
+	errs     error
+}
+
+// NewFileLogConsumer creates a FileLogConsumer writing to dir, fileName selecting the file for a container
+func NewFileLogConsumer(ctx context.Context, dir string, fileName func(container, service string) string) *FileLogConsumer {
+	return &FileLogConsumer{
+		ctx:      ctx,
+		dir:      dir,
+		fileName: fileName,
+		files:    map[string]*os.File{},
+	}
+}
+
+// Open creates, or truncates, the log file for a container
+func (l *FileLogConsumer) Open(container, service string) error {
+	_, err := l.file(container, service)
+	return err
+}
+
+func (l *FileLogConsumer) file(container, service string) (*os.File, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	name := filepath.Join(l.dir, l.fileName(container, service))
+	if f, ok := l.files[name]; ok {
+		return f, nil
+	}
+	f, err := os.Create(name)
+	if err != nil {
+		return nil, err
+	}
+	l.files[name] = f
+	return f, nil
+}
+
+// Log writes a log message from container to its log file
+func (l *FileLogConsumer) Log(container, service, message string) {
+	if l.ctx.Err() != nil {
+		return
+	}
+	f, err := l.file(container, service)
+	if err == nil {
+		for _, line := range strings.Split(message, "\n") {
+			if _, err = fmt.Fprintln(f, line); err != nil {
+				break
+			}
+		}
+	}
+	if err != nil {
+		l.mutex.Lock()
+		l.errs = multierror.Append(l.errs, err)
+		l.mutex.Unlock()
+	}
+}
+
+// Status is a no-op, log files only hold the containers output
+func (l *FileLogConsumer) Status(container, msg string) {}
+
+// Register is a no-op, log files are created on first use
+func (l *FileLogConsumer) Register(container string) {}
+
+// Close closes all log files and reports errors met while writing them
+func (l *FileLogConsumer) Close() error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	errs := l.errs
+	for _, f := range l.files {
+		if err := f.Close(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	return errs
+}
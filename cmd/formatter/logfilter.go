@@ -0,0 +1,54 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package formatter
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/docker/compose/v2/pkg/api"
+)
+
+// NewFilteredLogConsumer creates a LogConsumer only forwarding log lines matching pattern, or not matching it when
+// invert is set, to consumer
+func NewFilteredLogConsumer(consumer api.LogConsumer, pattern *regexp.Regexp, invert bool) api.LogConsumer {
+	return &filteredLogConsumer{
+		LogConsumer: consumer,
+		pattern:     pattern,
+		invert:      invert,
+	}
+}
+
+type filteredLogConsumer struct {
+	api.LogConsumer
+	pattern *regexp.Regexp
+	invert  bool
+}
+
+func (l *filteredLogConsumer) Log(container, service, message string) {
+	var lines []string
+	for _, line := range strings.Split(message, "\n") {
+		// match the message only, not the timestamp the engine prefixes it with when requested
+		_, msg := splitTimestamp(line)
+		if l.pattern.MatchString(msg) != l.invert {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) > 0 {
+		l.LogConsumer.Log(container, service, strings.Join(lines, "\n"))
+	}
+}
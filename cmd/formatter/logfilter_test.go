@@ -0,0 +1,48 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package formatter
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestFilteredLogConsumer(t *testing.T) {
+	var out bytes.Buffer
+	consumer := NewFilteredLogConsumer(NewLogConsumer(context.Background(), &out, false, true, 0), regexp.MustCompile("err"), false)
+	consumer.Register("web-1")
+	consumer.Log("web-1", "web", "starting\nerror: boom\ndone")
+	consumer.Log("web-1", "web", "all good")
+	assert.Equal(t, out.String(), "web-1  | error: boom\n")
+
+	out.Reset()
+	consumer = NewFilteredLogConsumer(NewLogConsumer(context.Background(), &out, false, true, 0), regexp.MustCompile("err"), true)
+	consumer.Register("web-1")
+	consumer.Log("web-1", "web", "starting\nerror: boom")
+	assert.Equal(t, out.String(), "web-1  | starting\n")
+}
+
+func TestFilteredLogConsumerIgnoresTimestamps(t *testing.T) {
+	var out bytes.Buffer
+	consumer := NewFilteredLogConsumer(NewLogConsumer(context.Background(), &out, false, false, 0), regexp.MustCompile("^error"), false)
+	consumer.Log("web-1", "web", "2022-05-04T10:12:01Z starting\n2022-05-04T10:12:02Z error: boom")
+	assert.Equal(t, out.String(), "2022-05-04T10:12:02Z error: boom\n")
+}
@@ -0,0 +1,86 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package formatter
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/compose/v2/pkg/api"
+)
+
+// NewJSONLogConsumer creates a LogConsumer writing each log line as a JSON object, one per line. Log messages are
+// expected to be prefixed by their timestamp, as returned by the engine when timestamps are requested.
+func NewJSONLogConsumer(ctx context.Context, w io.Writer) api.LogConsumer {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	return &jsonLogConsumer{
+		ctx:     ctx,
+		encoder: encoder,
+	}
+}
+
+type jsonLogConsumer struct {
+	ctx     context.Context
+	mutex   sync.Mutex
+	encoder *json.Encoder
+}
+
+type jsonLogLine struct {
+	Service   string `json:"service"`
+	Container string `json:"container"`
+	Message   string `json:"message"`
+	Timestamp string `json:"timestamp,omitempty"`
+}
+
+func (l *jsonLogConsumer) Log(container, service, message string) {
+	if l.ctx.Err() != nil {
+		return
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	for _, line := range strings.Split(message, "\n") {
+		timestamp, msg := splitTimestamp(line)
+		l.encoder.Encode(jsonLogLine{ // nolint:errcheck
+			Service:   service,
+			Container: container,
+			Message:   msg,
+			Timestamp: timestamp,
+		})
+	}
+}
+
+// Status is ignored, as not being a container log line
+func (l *jsonLogConsumer) Status(container, msg string) {}
+
+func (l *jsonLogConsumer) Register(container string) {}
+
+// splitTimestamp splits the RFC3339 timestamp the engine prefixes log lines with, if any, from the message
+func splitTimestamp(line string) (string, string) {
+	i := strings.IndexByte(line, ' ')
+	if i <= 0 {
+		return "", line
+	}
+	if _, err := time.Parse(time.RFC3339Nano, line[:i]); err != nil {
+		return "", line
+	}
+	return line[:i], line[i+1:]
+}
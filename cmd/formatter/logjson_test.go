@@ -0,0 +1,39 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package formatter
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestJSONLogConsumer(t *testing.T) {
+	var out bytes.Buffer
+	consumer := NewJSONLogConsumer(context.Background(), &out)
+	consumer.Register("demo-web-1")
+	consumer.Log("demo-web-1", "web", "2022-05-04T10:12:01.123456789Z listening on :80\n2022-05-04T10:12:02Z ready")
+	consumer.Log("demo-db-1", "db", "no \"timestamp\"")
+	consumer.Status("demo-web-1", "exited with code 0")
+	assert.Equal(t, out.String(),
+		`{"service":"web","container":"demo-web-1","message":"listening on :80","timestamp":"2022-05-04T10:12:01.123456789Z"}
+{"service":"web","container":"demo-web-1","message":"ready","timestamp":"2022-05-04T10:12:02Z"}
+{"service":"db","container":"demo-db-1","message":"no \"timestamp\""}
+`)
+}
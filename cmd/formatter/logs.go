@@ -27,15 +27,17 @@ import (
 	"github.com/docker/compose/v2/pkg/api"
 )
 
-// NewLogConsumer creates a new LogConsumer
-func NewLogConsumer(ctx context.Context, w io.Writer, color bool, prefix bool) api.LogConsumer {
+// NewLogConsumer creates a new LogConsumer, prefixes being padded to prefixWidth, or to the longest container name
+// when 0
+func NewLogConsumer(ctx context.Context, w io.Writer, color bool, prefix bool, prefixWidth int) api.LogConsumer {
 	return &logConsumer{
-		ctx:        ctx,
-		presenters: sync.Map{},
-		width:      0,
-		writer:     w,
-		color:      color,
-		prefix:     prefix,
+		ctx:         ctx,
+		presenters:  sync.Map{},
+		width:       0,
+		prefixWidth: prefixWidth,
+		writer:      w,
+		color:       color,
+		prefix:      prefix,
 	}
 }
 
@@ -90,6 +92,10 @@ func (l *logConsumer) Status(container, msg string) {
 }
 
 func (l *logConsumer) computeWidth() {
+	if l.prefixWidth > 0 {
+		l.width = l.prefixWidth
+		return
+	}
 	width := 0
 	l.presenters.Range(func(key, value interface{}) bool {
 		p := value.(*presenter)
@@ -103,12 +109,13 @@ func (l *logConsumer) computeWidth() {
 
 // LogConsumer consume logs from services and format them
 type logConsumer struct {
-	ctx        context.Context
-	presenters sync.Map // map[string]*presenter
-	width      int
-	writer     io.Writer
-	color      bool
-	prefix     bool
+	ctx         context.Context
+	presenters  sync.Map // map[string]*presenter
+	width       int
+	prefixWidth int
+	writer      io.Writer
+	color       bool
+	prefix      bool
 }
 
 type presenter struct {
@@ -0,0 +1,43 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package formatter
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestLogConsumerPrefixWidth(t *testing.T) {
+	var out bytes.Buffer
+	consumer := NewLogConsumer(context.Background(), &out, false, true, 0)
+	consumer.Register("db-1")
+	consumer.Register("frontend-1")
+	consumer.Log("db-1", "db", "ready")
+	consumer.Log("frontend-1", "frontend", "listening")
+	assert.Equal(t, out.String(), "db-1        | ready\nfrontend-1  | listening\n")
+
+	out.Reset()
+	consumer = NewLogConsumer(context.Background(), &out, false, true, 14)
+	consumer.Register("db-1")
+	consumer.Log("db-1", "db", "ready")
+	consumer.Register("frontend-1")
+	consumer.Log("frontend-1", "frontend", "listening")
+	assert.Equal(t, out.String(), "db-1           | ready\nfrontend-1     | listening\n")
+}
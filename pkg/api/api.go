@@ -68,20 +68,28 @@ type Service interface {
 	// UnPause executes the equivalent to a `compose unpause`
 	UnPause(ctx context.Context, projectName string, options PauseOptions) error
 	// Top executes the equivalent to a `compose top`
-	Top(ctx context.Context, projectName string, services []string) ([]ContainerProcSummary, error)
+	Top(ctx context.Context, projectName string, options TopOptions) ([]ContainerProcSummary, error)
 	// Events executes the equivalent to a `compose events`
 	Events(ctx context.Context, projectName string, options EventsOptions) error
 	// Port executes the equivalent to a `compose port`
 	Port(ctx context.Context, projectName string, service string, port int, options PortOptions) (string, int, error)
 	// Images executes the equivalent of a `compose images`
 	Images(ctx context.Context, projectName string, options ImagesOptions) ([]ImageSummary, error)
+	// DiskUsage executes the equivalent of a `compose alpha df`
+	DiskUsage(ctx context.Context, projectName string, options DiskUsageOptions) ([]DiskUsageSummary, error)
+	// Diff executes the equivalent of a `compose alpha diff`
+	Diff(ctx context.Context, project *types.Project, options DiffOptions) ([]DiffSummary, error)
+	// Watch executes the equivalent of a `compose watch`
+	Watch(ctx context.Context, project *types.Project, services []string, options WatchOptions) error
+	// Publish executes the equivalent of a `compose publish`
+	Publish(ctx context.Context, project *types.Project, repository string, options PublishOptions) error
 }
 
 // BuildOptions group options of the Build API
 type BuildOptions struct {
 	// Pull always attempt to pull a newer version of the image
 	Pull bool
-	// Progress set type of progress output ("auto", "plain", "tty")
+	// Progress set type of progress output ("auto", "plain", "tty", "rawjson")
 	Progress string
 	// Args set build-time args
 	Args types.MappingWithEquals
@@ -93,6 +101,18 @@ type BuildOptions struct {
 	Services []string
 	// Ssh authentications passed in the command line
 	SSHs []types.SSHKey
+	// CacheFrom set external cache sources, in addition to the ones declared by services
+	CacheFrom []string
+	// CacheTo set cache export destinations, in addition to the ones declared by services
+	CacheTo []string
+	// ExtraHosts adds host-to-IP mappings available during the build, in addition to the ones declared by services
+	ExtraHosts []string
+	// Check reports issues found in the services Dockerfile, without building them
+	Check bool
+	// ErrorOnWarnings makes Check fail when issues are found
+	ErrorOnWarnings bool
+	// Print outputs the resolved build configuration of services in this format (yaml|json), without building them
+	Print string
 }
 
 // CreateOptions group options of the Create API
@@ -113,6 +133,14 @@ type CreateOptions struct {
 	Timeout *time.Duration
 	// QuietPull makes the pulling process quiet
 	QuietPull bool
+	// QuietBuild only prints whether the build of each service succeeded, instead of the build output
+	QuietBuild bool
+	// PreferBuild skips pulling missing images of services which can be built
+	PreferBuild bool
+	// PrintCommands prints the docker CLI commands equivalent to the engine API calls being made
+	PrintCommands bool
+	// RecreateOrder defines how the containers of a service are recreated, all at once if empty
+	RecreateOrder string
 }
 
 // StartOptions group options of the Start API
@@ -129,6 +157,13 @@ type StartOptions struct {
 	ExitCodeFrom string
 	// Wait won't return until containers reached the running|healthy state
 	Wait bool
+	// WaitServices restricts Wait to the containers of these services, all services being waited for if empty
+	WaitServices []string
+	// WaitLogLines is the number of log lines printed for each container of the services which failed to get
+	// running|healthy when Wait fails, none if 0
+	WaitLogLines int
+	// WaitTimeout bounds Wait, which fails once it is exceeded, no limit being applied if zero
+	WaitTimeout time.Duration
 }
 
 // RestartOptions group options of the Restart API
@@ -145,12 +180,19 @@ type StopOptions struct {
 	Timeout *time.Duration
 	// Services passed in the command line to be stopped
 	Services []string
+	// Labels restricts the stopped containers to the ones matching all those key=value or key selectors
+	Labels []string
 }
 
 // UpOptions group options of the Up API
 type UpOptions struct {
 	Create CreateOptions
 	Start  StartOptions
+	// Rollback removes containers of services created by this invocation if up fails
+	Rollback bool
+	// StopTimeout bounds the graceful stop of attached containers, which are then killed, the stop timeout of each
+	// container being used if nil
+	StopTimeout *time.Duration
 }
 
 // DownOptions group options of the Down API
@@ -165,6 +207,12 @@ type DownOptions struct {
 	Images string
 	// Volumes remove volumes, both declared in the `volumes` section and anonymous ones
 	Volumes bool
+	// PreserveVolumes lists named volumes to keep when Volumes is set
+	PreserveVolumes []string
+	// KeepNetworks preserves the networks created for the project, only containers are removed
+	KeepNetworks bool
+	// Signal overrides the stop signal of containers, sent before they get killed once their grace period elapses
+	Signal string
 }
 
 // ConvertOptions group options of the Convert API
@@ -173,13 +221,25 @@ type ConvertOptions struct {
 	Format string
 	// Output defines the path to save the application model
 	Output string
+	// EscapeDollarSign escapes `$` as `$$` in the output, so that literal values of an interpolated model are not
+	// interpolated again when the output is loaded
+	EscapeDollarSign bool
 }
 
 // PushOptions group options of the Push API
 type PushOptions struct {
+	// Services passed in the command line to be pushed
+	Services []string
+	// IgnoreFailures push what it can and ignores images with push failures
 	IgnoreFailures bool
 }
 
+// PublishOptions group options of the Publish API
+type PublishOptions struct {
+	// EnvFile is the env file defining the default values of the project variables, published along with the project
+	EnvFile string
+}
+
 // PullOptions group options of the Pull API
 type PullOptions struct {
 	Quiet          bool
@@ -191,12 +251,44 @@ type ImagesOptions struct {
 	Services []string
 }
 
+// DiskUsageOptions group options of the DiskUsage API
+type DiskUsageOptions struct {
+	Services []string
+}
+
+// TopOptions group options of the Top API
+type TopOptions struct {
+	Services []string
+	// PsArgs are the arguments ps is run with to list the processes, instead of the engine default -ef
+	PsArgs []string
+}
+
+// DiffOptions group options of the Diff API
+type DiffOptions struct {
+	Services []string
+}
+
+// WatchOptions group options of the Watch API
+type WatchOptions struct {
+	// Interval is the delay between two scans of the watched paths
+	Interval time.Duration
+	// NoUp watches the running containers of the project instead of running up on the services first
+	NoUp bool
+}
+
 // KillOptions group options of the Kill API
 type KillOptions struct {
+	// Project is the compose project used to define the current project's services, if not set all containers labelled
+	// with the project name are killed
+	Project *types.Project
 	// Services passed in the command line to be killed
 	Services []string
 	// Signal to send to containers
 	Signal string
+	// RemoveOrphans also kills containers of the project for services not defined in Project
+	RemoveOrphans bool
+	// Force don't ask to confirm killing orphan containers
+	Force bool
 }
 
 // RemoveOptions group options of the Remove API
@@ -228,15 +320,24 @@ type RunOptions struct {
 	Privileged        bool
 	UseNetworkAliases bool
 	NoDeps            bool
+	// Network overrides the networks the container joins, either a project network,
+	// an existing network or a network mode (host, none, bridge)
+	Network string
 	// QuietPull makes the pulling process quiet
 	QuietPull bool
+	// DetachKeys overrides the key sequence for detaching from the container
+	DetachKeys string
 	// used by exec
 	Index int
+	// ReuseSession makes exec resolve the target container once, and reuse it for the next execs run with it
+	ReuseSession bool
 }
 
 // EventsOptions group options of the Events API
 type EventsOptions struct {
 	Services []string
+	// Events restricts the stream to these event types (e.g. start, die, health_status)
+	Events   []string
 	Consumer func(event Event) error
 }
 
@@ -298,13 +399,18 @@ type PortPublisher struct {
 type ContainerSummary struct {
 	ID         string
 	Name       string
+	Image      string
 	Command    string
 	Project    string
 	Service    string
+	Created    int64
 	State      string
 	Health     string
 	ExitCode   int
 	Publishers PortPublishers
+	Networks   []string
+	Mounts     []string
+	Labels     map[string]string
 }
 
 // PortPublishers is a slice of PortPublisher
@@ -340,6 +446,7 @@ func (p PortPublishers) Swap(i, j int) {
 type ContainerProcSummary struct {
 	ID        string
 	Name      string
+	Service   string
 	Processes [][]string
 	Titles    []string
 }
@@ -350,9 +457,53 @@ type ImageSummary struct {
 	ContainerName string
 	Repository    string
 	Tag           string
+	Digest        string
 	Size          int64
 }
 
+const (
+	// DiskUsageImage is the type of the disk usage of an image
+	DiskUsageImage = "image"
+	// DiskUsageContainer is the type of the disk usage of a container writable layer
+	DiskUsageContainer = "container"
+	// DiskUsageVolume is the type of the disk usage of a named volume
+	DiskUsageVolume = "volume"
+)
+
+// DiskUsageSummary holds the disk space used by a project resource
+type DiskUsageSummary struct {
+	Type string
+	Name string
+	Size int64
+}
+
+const (
+	// DiffService is the type of a service resource in a diff
+	DiffService = "service"
+	// DiffNetwork is the type of a network resource in a diff
+	DiffNetwork = "network"
+	// DiffVolume is the type of a volume resource in a diff
+	DiffVolume = "volume"
+
+	// DiffCreate means the resource does not exist yet and would be created
+	DiffCreate = "create"
+	// DiffRecreate means the resource exists but doesn't match the configuration
+	DiffRecreate = "recreate"
+	// DiffRemove means the resource exists but is not declared by the configuration
+	DiffRemove = "remove"
+	// DiffOrphan means the containers of a service not declared by the configuration anymore, up only removes them
+	// with --remove-orphans
+	DiffOrphan = "orphan"
+)
+
+// DiffSummary holds the change required to bring a project resource to the desired state
+type DiffSummary struct {
+	Type   string
+	Name   string
+	Action string
+	Fields []string `json:",omitempty"`
+}
+
 // ServiceStatus hold status about a service
 type ServiceStatus struct {
 	ID         string
@@ -371,6 +522,10 @@ type LogOptions struct {
 	Until      string
 	Follow     bool
 	Timestamps bool
+	// Merge prints the existing logs of all containers sorted by timestamp, rather than grouped by container
+	Merge bool
+	// Index restricts logs to the container with this replica number in each service, all replicas if zero
+	Index int
 }
 
 // PauseOptions group options of the Pause API
@@ -401,6 +556,16 @@ const (
 	RecreateForce = "force"
 	// RecreateNever to never recreate existing service containers
 	RecreateNever = "never"
+	// RecreateLabelsChanged to only recreate service containers which labels diverge from compose model
+	RecreateLabelsChanged = "labels"
+)
+
+const (
+	// RecreateOrderAllAtOnce to recreate all the containers of a service concurrently
+	RecreateOrderAllAtOnce = "all-at-once"
+	// RecreateOrderRolling to recreate the containers of a service one at a time, each one being started and running or
+	// healthy before the next one is recreated
+	RecreateOrderRolling = "rolling"
 )
 
 // Stack holds the name and state of a compose application/stack
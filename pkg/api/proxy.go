@@ -44,10 +44,14 @@ type ServiceProxy struct {
 	CopyFn               func(ctx context.Context, project string, options CopyOptions) error
 	PauseFn              func(ctx context.Context, project string, options PauseOptions) error
 	UnPauseFn            func(ctx context.Context, project string, options PauseOptions) error
-	TopFn                func(ctx context.Context, projectName string, services []string) ([]ContainerProcSummary, error)
+	TopFn                func(ctx context.Context, projectName string, options TopOptions) ([]ContainerProcSummary, error)
 	EventsFn             func(ctx context.Context, project string, options EventsOptions) error
 	PortFn               func(ctx context.Context, project string, service string, port int, options PortOptions) (string, int, error)
 	ImagesFn             func(ctx context.Context, projectName string, options ImagesOptions) ([]ImageSummary, error)
+	DiskUsageFn          func(ctx context.Context, projectName string, options DiskUsageOptions) ([]DiskUsageSummary, error)
+	DiffFn               func(ctx context.Context, project *types.Project, options DiffOptions) ([]DiffSummary, error)
+	WatchFn              func(ctx context.Context, project *types.Project, services []string, options WatchOptions) error
+	PublishFn            func(ctx context.Context, project *types.Project, repository string, options PublishOptions) error
 	interceptors         []Interceptor
 }
 
@@ -87,6 +91,10 @@ func (s *ServiceProxy) WithService(service Service) *ServiceProxy {
 	s.EventsFn = service.Events
 	s.PortFn = service.Port
 	s.ImagesFn = service.Images
+	s.DiskUsageFn = service.DiskUsage
+	s.DiffFn = service.Diff
+	s.WatchFn = service.Watch
+	s.PublishFn = service.Publish
 	return s
 }
 
@@ -278,11 +286,11 @@ func (s *ServiceProxy) UnPause(ctx context.Context, projectName string, options
 }
 
 // Top implements Service interface
-func (s *ServiceProxy) Top(ctx context.Context, project string, services []string) ([]ContainerProcSummary, error) {
+func (s *ServiceProxy) Top(ctx context.Context, project string, options TopOptions) ([]ContainerProcSummary, error) {
 	if s.TopFn == nil {
 		return nil, ErrNotImplemented
 	}
-	return s.TopFn(ctx, project, services)
+	return s.TopFn(ctx, project, options)
 }
 
 // Events implements Service interface
@@ -308,3 +316,44 @@ func (s *ServiceProxy) Images(ctx context.Context, project string, options Image
 	}
 	return s.ImagesFn(ctx, project, options)
 }
+
+// DiskUsage implements Service interface
+func (s *ServiceProxy) DiskUsage(ctx context.Context, project string, options DiskUsageOptions) ([]DiskUsageSummary, error) {
+	if s.DiskUsageFn == nil {
+		return nil, ErrNotImplemented
+	}
+	return s.DiskUsageFn(ctx, project, options)
+}
+
+// Diff implements Service interface
+func (s *ServiceProxy) Diff(ctx context.Context, project *types.Project, options DiffOptions) ([]DiffSummary, error) {
+	if s.DiffFn == nil {
+		return nil, ErrNotImplemented
+	}
+	for _, i := range s.interceptors {
+		i(ctx, project)
+	}
+	return s.DiffFn(ctx, project, options)
+}
+
+// Watch implements Service interface
+func (s *ServiceProxy) Watch(ctx context.Context, project *types.Project, services []string, options WatchOptions) error {
+	if s.WatchFn == nil {
+		return ErrNotImplemented
+	}
+	for _, i := range s.interceptors {
+		i(ctx, project)
+	}
+	return s.WatchFn(ctx, project, services, options)
+}
+
+// Publish implements Service interface
+func (s *ServiceProxy) Publish(ctx context.Context, project *types.Project, repository string, options PublishOptions) error {
+	if s.PublishFn == nil {
+		return ErrNotImplemented
+	}
+	for _, i := range s.interceptors {
+		i(ctx, project)
+	}
+	return s.PublishFn(ctx, project, repository, options)
+}
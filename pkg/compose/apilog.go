@@ -0,0 +1,120 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/docker/cli/cli/command"
+	moby "github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+	"github.com/sirupsen/logrus"
+)
+
+// apiClientLoggingCalls returns a client for the engine endpoint of the docker CLI, which logs the engine API calls
+// at debug level. The docker CLI client is used as is if such a client can't be created.
+func (s *composeService) apiClientLoggingCalls() client.APIClient {
+	s.loggingClientOnce.Do(func() {
+		s.loggingClient = s.dockerCli.Client()
+		endpoint := s.dockerCli.DockerEndpoint()
+		opts, err := endpoint.ClientOpts()
+		if err != nil {
+			logrus.Debugf("engine API calls won't be logged: %v", err)
+			return
+		}
+		headers := map[string]string{}
+		for k, v := range s.configFile().HTTPHeaders {
+			headers[k] = v
+		}
+		headers["User-Agent"] = command.UserAgent()
+		opts = append(opts,
+			client.WithHTTPHeaders(headers),
+			client.WithVersion(s.dockerCli.Client().ClientVersion()),
+			withAPICallsLogging)
+		apiClient, err := client.NewClientWithOpts(opts...)
+		if err != nil {
+			logrus.Debugf("engine API calls won't be logged: %v", err)
+			return
+		}
+		s.loggingClient = &loggingClient{APIClient: apiClient, hijack: s.dockerCli.Client()}
+	})
+	return s.loggingClient
+}
+
+// withAPICallsLogging makes a client log the engine API calls it sends
+func withAPICallsLogging(c *client.Client) error {
+	httpClient := c.HTTPClient()
+	next := httpClient.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	if t, ok := next.(*http.Transport); ok && t.TLSClientConfig != nil {
+		// the client can't detect TLS is used once its transport is wrapped
+		if err := client.WithScheme("https")(c); err != nil {
+			return err
+		}
+	}
+	httpClient.Transport = apiCallsLogger{next: next}
+	return client.WithHTTPClient(httpClient)(c)
+}
+
+// apiCallsLogger is a http.RoundTripper logging the method, path, status and duration of engine API calls
+type apiCallsLogger struct {
+	next http.RoundTripper
+}
+
+func (l apiCallsLogger) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := l.next.RoundTrip(req)
+	log := logrus.WithFields(logrus.Fields{
+		"method":   req.Method,
+		"path":     req.URL.Path,
+		"duration": time.Since(start).String(),
+	})
+	if err != nil {
+		log.WithError(err).Debug("engine API call failed")
+		return resp, err
+	}
+	log.WithField("status", resp.StatusCode).Debug("engine API call")
+	return resp, nil
+}
+
+// loggingClient logs the engine API calls, but for the hijacked connections which need the transport of the docker
+// CLI client to dial the engine
+type loggingClient struct {
+	client.APIClient
+	hijack client.APIClient
+}
+
+func (l *loggingClient) ContainerAttach(ctx context.Context, container string, options moby.ContainerAttachOptions) (moby.HijackedResponse, error) {
+	return l.hijack.ContainerAttach(ctx, container, options)
+}
+
+func (l *loggingClient) ContainerExecAttach(ctx context.Context, execID string, config moby.ExecStartCheck) (moby.HijackedResponse, error) {
+	return l.hijack.ContainerExecAttach(ctx, execID, config)
+}
+
+func (l *loggingClient) DialHijack(ctx context.Context, url, proto string, meta map[string][]string) (net.Conn, error) {
+	return l.hijack.DialHijack(ctx, url, proto, meta)
+}
+
+func (l *loggingClient) Dialer() func(context.Context) (net.Conn, error) {
+	return l.hijack.Dialer()
+}
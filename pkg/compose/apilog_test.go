@@ -0,0 +1,54 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"gotest.tools/v3/assert"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestAPICallsLogger(t *testing.T) {
+	hook := logtest.NewGlobal()
+	defer hook.Reset()
+	level := logrus.GetLevel()
+	defer logrus.SetLevel(level)
+	logrus.SetLevel(logrus.DebugLevel)
+
+	logger := apiCallsLogger{next: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusNoContent}, nil
+	})}
+	_, err := logger.RoundTrip(httptest.NewRequest(http.MethodPost, "/v1.41/containers/abc/start", nil))
+	assert.NilError(t, err)
+
+	entry := hook.LastEntry()
+	assert.Assert(t, entry != nil)
+	assert.Equal(t, entry.Message, "engine API call")
+	assert.Equal(t, entry.Data["method"], http.MethodPost)
+	assert.Equal(t, entry.Data["path"], "/v1.41/containers/abc/start")
+	assert.Equal(t, entry.Data["status"], http.StatusNoContent)
+}
@@ -41,6 +41,12 @@ import (
 )
 
 func (s *composeService) Build(ctx context.Context, project *types.Project, options api.BuildOptions) error {
+	if options.Check {
+		return s.check(ctx, project, options)
+	}
+	if options.Print != "" {
+		return s.printBuild(project, options)
+	}
 	return progress.Run(ctx, func(ctx context.Context) error {
 		return s.build(ctx, project, options)
 	})
@@ -50,11 +56,6 @@ func (s *composeService) build(ctx context.Context, project *types.Project, opti
 	opts := map[string]build.Options{}
 	imagesToBuild := []string{}
 
-	args := flatten(options.Args.Resolve(func(s string) (string, bool) {
-		s, ok := project.Environment[s]
-		return s, ok
-	}))
-
 	services, err := project.GetServices(options.Services...)
 	if err != nil {
 		return err
@@ -64,24 +65,10 @@ func (s *composeService) build(ctx context.Context, project *types.Project, opti
 		if service.Build != nil {
 			imageName := getImageName(service, project.Name)
 			imagesToBuild = append(imagesToBuild, imageName)
-			buildOptions, err := s.toBuildOptions(project, service, imageName, options.SSHs)
-			if err != nil {
-				return err
-			}
-			buildOptions.Pull = options.Pull
-			buildOptions.BuildArgs = mergeArgs(buildOptions.BuildArgs, args)
-			buildOptions.NoCache = options.NoCache
-			buildOptions.CacheFrom, err = buildflags.ParseCacheEntry(service.Build.CacheFrom)
+			buildOptions, err := s.toServiceBuildOptions(project, service, imageName, options)
 			if err != nil {
 				return err
 			}
-
-			for _, image := range service.Build.CacheFrom {
-				buildOptions.CacheFrom = append(buildOptions.CacheFrom, bclient.CacheOptionsEntry{
-					Type:  "registry",
-					Attrs: map[string]string{"ref": image},
-				})
-			}
 			opts[imageName] = buildOptions
 		}
 	}
@@ -96,7 +83,47 @@ func (s *composeService) build(ctx context.Context, project *types.Project, opti
 	return err
 }
 
-func (s *composeService) ensureImagesExists(ctx context.Context, project *types.Project, quietPull bool) error {
+// toServiceBuildOptions computes the build options of a service, with the overrides set on the build command
+func (s *composeService) toServiceBuildOptions(project *types.Project, service types.ServiceConfig, imageName string, options api.BuildOptions) (build.Options, error) {
+	args := flatten(options.Args.Resolve(func(s string) (string, bool) {
+		s, ok := project.Environment[s]
+		return s, ok
+	}))
+
+	cacheFrom, err := buildflags.ParseCacheEntry(options.CacheFrom)
+	if err != nil {
+		return build.Options{}, err
+	}
+	cacheTo, err := buildflags.ParseCacheEntry(options.CacheTo)
+	if err != nil {
+		return build.Options{}, err
+	}
+
+	buildOptions, err := s.toBuildOptions(project, service, imageName, options.SSHs)
+	if err != nil {
+		return build.Options{}, err
+	}
+	buildOptions.Pull = options.Pull
+	buildOptions.BuildArgs = mergeArgs(buildOptions.BuildArgs, args)
+	buildOptions.NoCache = options.NoCache
+	buildOptions.CacheFrom, err = buildflags.ParseCacheEntry(service.Build.CacheFrom)
+	if err != nil {
+		return build.Options{}, err
+	}
+
+	for _, image := range service.Build.CacheFrom {
+		buildOptions.CacheFrom = append(buildOptions.CacheFrom, bclient.CacheOptionsEntry{
+			Type:  "registry",
+			Attrs: map[string]string{"ref": image},
+		})
+	}
+	buildOptions.CacheFrom = append(buildOptions.CacheFrom, cacheFrom...)
+	buildOptions.CacheTo = append(buildOptions.CacheTo, cacheTo...)
+	buildOptions.ExtraHosts = append(buildOptions.ExtraHosts, options.ExtraHosts...)
+	return buildOptions, nil
+}
+
+func (s *composeService) ensureImagesExists(ctx context.Context, project *types.Project, quietPull bool, quietBuild bool, preferBuild bool) error {
 	for _, service := range project.Services {
 		if service.Image == "" && service.Build == nil {
 			return fmt.Errorf("invalid service %q. Must specify either image or build", service.Name)
@@ -108,14 +135,19 @@ func (s *composeService) ensureImagesExists(ctx context.Context, project *types.
 		return err
 	}
 
-	err = s.pullRequiredImages(ctx, project, images, quietPull)
+	err = s.pullRequiredImages(ctx, project, images, quietPull, preferBuild)
 	if err != nil {
 		return err
 	}
 
 	mode := xprogress.PrinterModeAuto
-	if quietPull {
+	switch {
+	case progress.Mode == progress.ModeQuiet, quietPull:
 		mode = xprogress.PrinterModeQuiet
+	case quietBuild:
+		mode = printerModeSummary
+	case progress.Mode == progress.ModePlain:
+		mode = xprogress.PrinterModePlain
 	}
 	opts, err := s.getBuildOptions(project, images)
 	if err != nil {
@@ -204,6 +236,9 @@ func (s *composeService) doBuild(ctx context.Context, project *types.Project, op
 	if len(opts) == 0 {
 		return nil, nil
 	}
+	if DryRun {
+		return s.doBuildDryRun(ctx, opts), nil
+	}
 	if buildkitEnabled, err := s.dockerCli.BuildKitEnabled(); err != nil || !buildkitEnabled {
 		return s.doBuildClassic(ctx, project, opts)
 	}
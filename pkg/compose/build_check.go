@@ -0,0 +1,163 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/compose-spec/compose-go/types"
+	"github.com/docker/docker/pkg/urlutil"
+	"github.com/moby/buildkit/frontend/dockerfile/parser"
+
+	"github.com/docker/compose/v2/pkg/api"
+)
+
+// buildCheckWarning is an issue found in a Dockerfile by one of the Compose build check rules
+type buildCheckWarning struct {
+	Rule    string
+	Line    int
+	Message string
+}
+
+// check reports issues found in the Dockerfile of the services to be built, without building them
+func (s *composeService) check(ctx context.Context, project *types.Project, options api.BuildOptions) error {
+	services, err := project.GetServices(options.Services...)
+	if err != nil {
+		return err
+	}
+	count := 0
+	for _, service := range services {
+		if service.Build == nil {
+			continue
+		}
+		if urlutil.IsGitURL(service.Build.Context) || urlutil.IsURL(service.Build.Context) {
+			fmt.Fprintf(s.stdout(), "%s: remote build context, skipped\n", service.Name) // nolint:errcheck
+			continue
+		}
+		dockerfile := dockerFilePath(service.Build.Context, service.Build.Dockerfile)
+		f, err := os.Open(dockerfile)
+		if err != nil {
+			return err
+		}
+		warnings, err := checkDockerfile(f)
+		f.Close() // nolint:errcheck
+		if err != nil {
+			return fmt.Errorf("failed to check %s for service %q: %s", dockerfile, service.Name, err)
+		}
+		for _, w := range warnings {
+			fmt.Fprintf(s.stdout(), "%s: %s:%d %s: %s\n", service.Name, service.Build.Dockerfile, w.Line, w.Rule, w.Message) // nolint:errcheck
+		}
+		count += len(warnings)
+	}
+	fmt.Fprintf(s.stdout(), "Check complete, %d warning(s) found\n", count) // nolint:errcheck
+	if count > 0 && options.ErrorOnWarnings {
+		return fmt.Errorf("build check failed with %d warning(s)", count)
+	}
+	return nil
+}
+
+// checkDockerfile parses a Dockerfile and applies the Compose build check rules. The BuildKit version Compose uses has
+// no check mode, so these are heuristics on the parsed Dockerfile, modeled on some of the later BuildKit checks.
+func checkDockerfile(r io.Reader) ([]buildCheckWarning, error) {
+	result, err := parser.Parse(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		warnings []buildCheckWarning
+		upper    int
+		lower    int
+	)
+	for _, node := range result.AST.Children {
+		keyword := instructionKeyword(node)
+		switch keyword {
+		case strings.ToUpper(keyword):
+			upper++
+		case strings.ToLower(keyword):
+			lower++
+		}
+	}
+	majority := strings.ToUpper
+	if lower > upper {
+		majority = strings.ToLower
+	}
+
+	for _, node := range result.AST.Children {
+		keyword := instructionKeyword(node)
+		if keyword != majority(keyword) {
+			casing := "uppercase"
+			if lower > upper {
+				casing = "lowercase"
+			}
+			warnings = append(warnings, buildCheckWarning{
+				Rule:    "instruction-casing",
+				Line:    node.StartLine,
+				Message: fmt.Sprintf("Command '%s' should match the case of the command majority (%s)", keyword, casing),
+			})
+		}
+		switch node.Value {
+		case "maintainer":
+			warnings = append(warnings, buildCheckWarning{
+				Rule:    "maintainer-deprecated",
+				Line:    node.StartLine,
+				Message: "Maintainer instruction is deprecated in favor of using label",
+			})
+		case "from":
+			fields := strings.Fields(node.Original)
+			if n := len(fields); n >= 4 && strings.EqualFold(fields[n-2], "as") {
+				as, stage := fields[n-2], fields[n-1]
+				if (as == strings.ToUpper(as)) != (keyword == strings.ToUpper(keyword)) {
+					warnings = append(warnings, buildCheckWarning{
+						Rule:    "from-as-casing",
+						Line:    node.StartLine,
+						Message: fmt.Sprintf("'%s' and '%s' keywords' casing do not match", as, keyword),
+					})
+				}
+				if stage != strings.ToLower(stage) {
+					warnings = append(warnings, buildCheckWarning{
+						Rule:    "stage-name-casing",
+						Line:    node.StartLine,
+						Message: fmt.Sprintf("Stage name '%s' should be lowercase", stage),
+					})
+				}
+			}
+		case "cmd", "entrypoint":
+			if !node.Attributes["json"] {
+				warnings = append(warnings, buildCheckWarning{
+					Rule:    "json-args",
+					Line:    node.StartLine,
+					Message: fmt.Sprintf("JSON arguments recommended for %s to prevent unintended behavior related to OS signals", strings.ToUpper(node.Value)),
+				})
+			}
+		}
+	}
+	return warnings, nil
+}
+
+// instructionKeyword returns the instruction keyword as written in the Dockerfile
+func instructionKeyword(node *parser.Node) string {
+	fields := strings.Fields(node.Original)
+	if len(fields) == 0 {
+		return node.Value
+	}
+	return fields[0]
+}
@@ -0,0 +1,50 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestCheckDockerfile(t *testing.T) {
+	warnings, err := checkDockerfile(strings.NewReader(`FROM golang:1.18 as Builder
+MAINTAINER someone@example.com
+RUN go build -o /app .
+from alpine
+COPY --from=builder /app /app
+ENTRYPOINT ["/app"]
+CMD serve
+`))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, warnings, []buildCheckWarning{
+		{Rule: "from-as-casing", Line: 1, Message: "'as' and 'FROM' keywords' casing do not match"},
+		{Rule: "stage-name-casing", Line: 1, Message: "Stage name 'Builder' should be lowercase"},
+		{Rule: "maintainer-deprecated", Line: 2, Message: "Maintainer instruction is deprecated in favor of using label"},
+		{Rule: "instruction-casing", Line: 4, Message: "Command 'from' should match the case of the command majority (uppercase)"},
+		{Rule: "json-args", Line: 7, Message: "JSON arguments recommended for CMD to prevent unintended behavior related to OS signals"},
+	})
+
+	warnings, err = checkDockerfile(strings.NewReader(`FROM --platform=linux/amd64 alpine AS base
+RUN echo ok
+CMD ["echo", "ok"]
+`))
+	assert.NilError(t, err)
+	assert.Equal(t, len(warnings), 0)
+}
@@ -0,0 +1,147 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/compose-spec/compose-go/types"
+	"github.com/containerd/containerd/platforms"
+	bclient "github.com/moby/buildkit/client"
+	"github.com/sanathkr/go-yaml"
+
+	"github.com/docker/compose/v2/pkg/api"
+	"github.com/docker/compose/v2/pkg/utils"
+)
+
+// buildConfig is the resolved build configuration of a service, as passed to the builder
+type buildConfig struct {
+	Image      string            `yaml:"image" json:"image"`
+	Context    string            `yaml:"context" json:"context"`
+	Dockerfile string            `yaml:"dockerfile" json:"dockerfile"`
+	Args       map[string]string `yaml:"args,omitempty" json:"args,omitempty"`
+	Target     string            `yaml:"target,omitempty" json:"target,omitempty"`
+	Tags       []string          `yaml:"tags,omitempty" json:"tags,omitempty"`
+	Labels     map[string]string `yaml:"labels,omitempty" json:"labels,omitempty"`
+	CacheFrom  []string          `yaml:"cache_from,omitempty" json:"cache_from,omitempty"`
+	CacheTo    []string          `yaml:"cache_to,omitempty" json:"cache_to,omitempty"`
+	Platforms  []string          `yaml:"platforms,omitempty" json:"platforms,omitempty"`
+	Network    string            `yaml:"network,omitempty" json:"network,omitempty"`
+	ExtraHosts []string          `yaml:"extra_hosts,omitempty" json:"extra_hosts,omitempty"`
+	SSH        []string          `yaml:"ssh,omitempty" json:"ssh,omitempty"`
+	Secrets    []string          `yaml:"secrets,omitempty" json:"secrets,omitempty"`
+	Pull       bool              `yaml:"pull,omitempty" json:"pull,omitempty"`
+	NoCache    bool              `yaml:"no_cache,omitempty" json:"no_cache,omitempty"`
+}
+
+func (s *composeService) printBuild(project *types.Project, options api.BuildOptions) error {
+	configs, err := s.resolveBuildConfigs(project, options)
+	if err != nil {
+		return err
+	}
+	var marshal []byte
+	switch options.Print {
+	case "json":
+		marshal, err = json.MarshalIndent(configs, "", "  ")
+		marshal = append(marshal, '\n')
+	case "yaml":
+		marshal, err = yaml.Marshal(configs)
+	default:
+		return fmt.Errorf("unsupported format %q", options.Print)
+	}
+	if err != nil {
+		return err
+	}
+	_, err = s.stdout().Write(marshal)
+	return err
+}
+
+// resolveBuildConfigs computes the build configuration of services from the options build passes to the builder,
+// indexed by service name
+func (s *composeService) resolveBuildConfigs(project *types.Project, options api.BuildOptions) (map[string]buildConfig, error) {
+	services, err := project.GetServices(options.Services...)
+	if err != nil {
+		return nil, err
+	}
+
+	configs := map[string]buildConfig{}
+	for _, service := range services {
+		if service.Build == nil {
+			continue
+		}
+		buildOptions, err := s.toServiceBuildOptions(project, service, getImageName(service, project.Name), options)
+		if err != nil {
+			return nil, err
+		}
+		config := buildConfig{
+			Image:      buildOptions.Tags[0],
+			Context:    buildOptions.Inputs.ContextPath,
+			Dockerfile: buildOptions.Inputs.DockerfilePath,
+			Args:       buildOptions.BuildArgs,
+			Target:     buildOptions.Target,
+			Tags:       buildOptions.Tags[1:],
+			Labels:     buildOptions.Labels,
+			CacheFrom:  cacheEntries(buildOptions.CacheFrom),
+			CacheTo:    cacheEntries(buildOptions.CacheTo),
+			Network:    buildOptions.NetworkMode,
+			ExtraHosts: buildOptions.ExtraHosts,
+			Pull:       buildOptions.Pull,
+			NoCache:    buildOptions.NoCache,
+		}
+		if len(config.Args) == 0 {
+			config.Args = nil
+		}
+		if len(config.Tags) == 0 {
+			config.Tags = nil
+		}
+		for _, platform := range buildOptions.Platforms {
+			config.Platforms = append(config.Platforms, platforms.Format(platform))
+		}
+		// session attachables can't be inspected, SSH keys and secrets are reported as declared
+		for _, key := range append(service.Build.SSH, options.SSHs...) {
+			config.SSH = append(config.SSH, key.ID)
+		}
+		for _, secret := range service.Build.Secrets {
+			config.Secrets = append(config.Secrets, secret.Source)
+		}
+		configs[service.Name] = config
+	}
+	return configs, nil
+}
+
+// cacheEntries formats cache entries as accepted by --cache-from and --cache-to, skipping duplicates
+func cacheEntries(entries []bclient.CacheOptionsEntry) []string {
+	var formatted []string
+	for _, entry := range entries {
+		attrs := []string{"type=" + entry.Type}
+		keys := make([]string, 0, len(entry.Attrs))
+		for key := range entry.Attrs {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			attrs = append(attrs, key+"="+entry.Attrs[key])
+		}
+		if e := strings.Join(attrs, ","); !utils.StringContains(formatted, e) {
+			formatted = append(formatted, e)
+		}
+	}
+	return formatted
+}
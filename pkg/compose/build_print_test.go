@@ -0,0 +1,98 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"io"
+	"testing"
+
+	"github.com/compose-spec/compose-go/types"
+	"github.com/golang/mock/gomock"
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose/v2/pkg/api"
+	"github.com/docker/compose/v2/pkg/mocks"
+)
+
+func TestResolveBuildConfigs(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	cli := mocks.NewMockCli(mockCtrl)
+	cli.EXPECT().Err().Return(io.Discard).AnyTimes()
+	tested := composeService{dockerCli: cli}
+
+	version := "1.0"
+	project := &types.Project{
+		Name:        "test",
+		Environment: map[string]string{"VERSION": "2.0"},
+		Services: types.Services{
+			{
+				Name:     "web",
+				Platform: "linux/arm64",
+				Build: &types.BuildConfig{
+					Context:    "/src/web",
+					Dockerfile: "Dockerfile",
+					Args: types.MappingWithEquals{
+						"VERSION": nil,
+						"DEBUG":   &version,
+					},
+					CacheFrom: types.StringList{"web:cache"},
+					Target:    "prod",
+				},
+			},
+			{
+				Name:  "api",
+				Image: "registry.example.com/api:1.0",
+				Build: &types.BuildConfig{
+					Context:    "/src/api",
+					Dockerfile: "Dockerfile",
+				},
+			},
+			{Name: "db", Image: "postgres"},
+		},
+	}
+
+	configs, err := tested.resolveBuildConfigs(project, api.BuildOptions{
+		Args:       types.NewMappingWithEquals([]string{"EXTRA=yes"}),
+		CacheFrom:  []string{"type=registry,ref=web:ci"},
+		ExtraHosts: []string{"registry.internal:10.0.0.5"},
+		NoCache:    true,
+	})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, configs, map[string]buildConfig{
+		"web": {
+			Image:      "test_web",
+			Context:    "/src/web",
+			Dockerfile: "/src/web/Dockerfile",
+			Args:       map[string]string{"VERSION": "2.0", "DEBUG": "1.0", "EXTRA": "yes"},
+			Target:     "prod",
+			CacheFrom:  []string{"type=registry,ref=web:cache", "type=registry,ref=web:ci"},
+			ExtraHosts: []string{"registry.internal:10.0.0.5"},
+			Platforms:  []string{"linux/arm64"},
+			NoCache:    true,
+		},
+		"api": {
+			Image:      "registry.example.com/api:1.0",
+			Context:    "/src/api",
+			Dockerfile: "/src/api/Dockerfile",
+			Args:       map[string]string{"EXTRA": "yes"},
+			CacheFrom:  []string{"type=registry,ref=web:ci"},
+			ExtraHosts: []string{"registry.internal:10.0.0.5"},
+			NoCache:    true,
+		},
+	})
+}
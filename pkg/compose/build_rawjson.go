@@ -0,0 +1,162 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/compose-spec/compose-go/types"
+	"github.com/docker/buildx/build"
+	xprogress "github.com/docker/buildx/util/progress"
+	"github.com/moby/buildkit/client"
+	"github.com/opencontainers/go-digest"
+)
+
+// PrinterModeRawJSON prints the BuildKit status stream as JSON lines, each one being tagged with the service it relates to
+const PrinterModeRawJSON = "rawjson"
+
+type buildProgressWriter interface {
+	xprogress.Writer
+	Wait() error
+}
+
+type rawJSONStatus struct {
+	Service string              `json:"service"`
+	Status  *client.SolveStatus `json:"status"`
+}
+
+// serviceResolver resolves the service BuildKit vertexes belong to, targets mapping build targets, as set by buildx,
+// to service names
+type serviceResolver struct {
+	targets  map[string]string
+	vertexes map[digest.Digest]string
+}
+
+func newServiceResolver(targets map[string]string) serviceResolver {
+	return serviceResolver{
+		targets:  targets,
+		vertexes: map[digest.Digest]string{},
+	}
+}
+
+// rawJSONWriter is a buildx progress writer encoding BuildKit statuses as JSON, one line per service per status update
+type rawJSONWriter struct {
+	serviceResolver
+	mutex   sync.Mutex
+	encoder *json.Encoder
+	err     error
+}
+
+// newRawJSONWriter creates a rawJSONWriter, targets mapping build targets, as set by buildx, to service names
+func newRawJSONWriter(out io.Writer, targets map[string]string) *rawJSONWriter {
+	return &rawJSONWriter{
+		serviceResolver: newServiceResolver(targets),
+		encoder:         json.NewEncoder(out),
+	}
+}
+
+// buildTargets maps images to be built to the services they are built for
+func buildTargets(project *types.Project, opts map[string]build.Options) map[string]string {
+	targets := map[string]string{}
+	for _, service := range project.Services {
+		imageName := getImageName(service, project.Name)
+		if _, ok := opts[imageName]; ok {
+			targets[imageName] = service.Name
+		}
+	}
+	return targets
+}
+
+func (w *rawJSONWriter) Write(s *client.SolveStatus) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	var services []string
+	statuses := map[string]*client.SolveStatus{}
+	statusFor := func(service string) *client.SolveStatus {
+		status, ok := statuses[service]
+		if !ok {
+			status = &client.SolveStatus{}
+			statuses[service] = status
+			services = append(services, service)
+		}
+		return status
+	}
+
+	for _, v := range s.Vertexes {
+		service := w.serviceOf(v)
+		w.vertexes[v.Digest] = service
+		status := statusFor(service)
+		status.Vertexes = append(status.Vertexes, v)
+	}
+	for _, v := range s.Statuses {
+		status := statusFor(w.vertexes[v.Vertex])
+		status.Statuses = append(status.Statuses, v)
+	}
+	for _, v := range s.Logs {
+		status := statusFor(w.vertexes[v.Vertex])
+		status.Logs = append(status.Logs, v)
+	}
+	for _, v := range s.Warnings {
+		status := statusFor(w.vertexes[v.Vertex])
+		status.Warnings = append(status.Warnings, v)
+	}
+
+	for _, service := range services {
+		err := w.encoder.Encode(rawJSONStatus{
+			Service: service,
+			Status:  statuses[service],
+		})
+		if err != nil && w.err == nil {
+			w.err = err
+		}
+	}
+}
+
+// serviceOf resolves the service a vertex belongs to, relying on the `[target] ` prefix buildx sets on vertex names
+// when building multiple targets
+func (r *serviceResolver) serviceOf(v *client.Vertex) string {
+	if len(r.targets) == 1 {
+		for _, service := range r.targets {
+			return service
+		}
+	}
+	if strings.HasPrefix(v.Name, "[") {
+		if end := strings.IndexAny(v.Name, " ]"); end > 0 {
+			if service, ok := r.targets[v.Name[1:end]]; ok {
+				return service
+			}
+		}
+	}
+	return r.vertexes[v.Digest]
+}
+
+func (w *rawJSONWriter) ValidateLogSource(digest.Digest, interface{}) bool {
+	return true
+}
+
+func (w *rawJSONWriter) ClearLogSource(interface{}) {}
+
+// Wait returns the first error met while writing statuses
+func (w *rawJSONWriter) Wait() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.err
+}
@@ -0,0 +1,66 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/moby/buildkit/client"
+	"github.com/opencontainers/go-digest"
+	"gotest.tools/v3/assert"
+)
+
+func TestRawJSONWriterDemultiplexesServices(t *testing.T) {
+	var out bytes.Buffer
+	w := newRawJSONWriter(&out, map[string]string{
+		"project_front": "front",
+		"custom-back":   "back",
+	})
+
+	front := digest.FromString("front")
+	back := digest.FromString("back")
+	w.Write(&client.SolveStatus{
+		Vertexes: []*client.Vertex{
+			{Digest: front, Name: "[project_front 1/2] FROM alpine"},
+			{Digest: back, Name: "[custom-back internal] load build definition"},
+		},
+	})
+	w.Write(&client.SolveStatus{
+		Logs: []*client.VertexLog{
+			{Vertex: back, Data: []byte("hello")},
+		},
+	})
+	assert.NilError(t, w.Wait())
+
+	var lines []rawJSONStatus
+	scanner := bufio.NewScanner(&out)
+	for scanner.Scan() {
+		var line rawJSONStatus
+		assert.NilError(t, json.Unmarshal(scanner.Bytes(), &line), scanner.Text())
+		lines = append(lines, line)
+	}
+	assert.Equal(t, len(lines), 3)
+	assert.Equal(t, lines[0].Service, "front")
+	assert.Equal(t, lines[0].Status.Vertexes[0].Digest, front)
+	assert.Equal(t, lines[1].Service, "back")
+	assert.Equal(t, lines[1].Status.Vertexes[0].Digest, back)
+	assert.Equal(t, lines[2].Service, "back")
+	assert.Equal(t, string(lines[2].Status.Logs[0].Data), "hello")
+}
@@ -0,0 +1,107 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/moby/buildkit/client"
+	"github.com/opencontainers/go-digest"
+)
+
+// printerModeSummary discards the BuildKit status stream to only print the outcome of the build of each service
+const printerModeSummary = "summary"
+
+// summaryWriter is a buildx progress writer printing a single line per service once builds complete, telling whether
+// its image was built or the error its build failed with
+type summaryWriter struct {
+	serviceResolver
+	mutex     sync.Mutex
+	out       io.Writer
+	completed map[digest.Digest]bool
+	errors    map[string]string
+}
+
+// newSummaryWriter creates a summaryWriter, targets mapping build targets, as set by buildx, to service names
+func newSummaryWriter(out io.Writer, targets map[string]string) *summaryWriter {
+	return &summaryWriter{
+		serviceResolver: newServiceResolver(targets),
+		out:             out,
+		completed:       map[digest.Digest]bool{},
+		errors:          map[string]string{},
+	}
+}
+
+func (w *summaryWriter) Write(s *client.SolveStatus) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	for _, v := range s.Vertexes {
+		service := w.serviceOf(v)
+		w.vertexes[v.Digest] = service
+		w.completed[v.Digest] = v.Completed != nil
+		if v.Error != "" && w.errors[service] == "" {
+			w.errors[service] = v.Error
+		}
+	}
+}
+
+func (w *summaryWriter) ValidateLogSource(digest.Digest, interface{}) bool {
+	return true
+}
+
+func (w *summaryWriter) ClearLogSource(interface{}) {}
+
+// Wait prints the outcome of the build of each service. A service is reported as built once all its build steps
+// completed, as its build can also be interrupted by another one failing
+func (w *summaryWriter) Wait() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	started := map[string]bool{}
+	pending := map[string]bool{}
+	for d, service := range w.vertexes {
+		started[service] = true
+		if !w.completed[d] {
+			pending[service] = true
+		}
+	}
+
+	var services []string
+	for _, service := range w.targets {
+		services = append(services, service)
+	}
+	sort.Strings(services)
+	for _, service := range services {
+		var err error
+		switch {
+		case w.errors[service] != "":
+			_, err = fmt.Fprintf(w.out, "Service %s: build failed: %s\n", service, w.errors[service])
+		case started[service] && !pending[service]:
+			_, err = fmt.Fprintf(w.out, "Service %s: built\n", service)
+		default:
+			_, err = fmt.Fprintf(w.out, "Service %s: build canceled\n", service)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/moby/buildkit/client"
+	"github.com/opencontainers/go-digest"
+	"gotest.tools/v3/assert"
+)
+
+func TestSummaryWriter(t *testing.T) {
+	var out bytes.Buffer
+	w := newSummaryWriter(&out, map[string]string{
+		"project_front": "front",
+		"project_back":  "back",
+		"project_admin": "admin",
+	})
+
+	now := time.Now()
+	front := digest.FromString("front")
+	back := digest.FromString("back")
+	admin := digest.FromString("admin")
+	w.Write(&client.SolveStatus{
+		Vertexes: []*client.Vertex{
+			{Digest: front, Name: "[project_front 1/2] FROM alpine"},
+			{Digest: back, Name: "[project_back 1/2] FROM alpine"},
+			{Digest: admin, Name: "[project_admin 1/2] FROM alpine"},
+		},
+		Logs: []*client.VertexLog{
+			{Vertex: front, Data: []byte("not printed")},
+		},
+	})
+	w.Write(&client.SolveStatus{
+		Vertexes: []*client.Vertex{
+			{Digest: front, Name: "[project_front 1/2] FROM alpine", Completed: &now},
+			{Digest: back, Name: "[project_back 1/2] FROM alpine", Completed: &now, Error: "exit code: 1"},
+		},
+	})
+	assert.NilError(t, w.Wait())
+
+	assert.Equal(t, out.String(), `Service admin: build canceled
+Service back: build failed: exit code: 1
+Service front: built
+`)
+}
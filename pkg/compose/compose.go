@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"sync"
 
 	"github.com/compose-spec/compose-go/types"
 	"github.com/docker/cli/cli/command"
@@ -34,6 +35,7 @@ import (
 	"github.com/docker/docker/client"
 	"github.com/pkg/errors"
 	"github.com/sanathkr/go-yaml"
+	"github.com/sirupsen/logrus"
 )
 
 // Separator is used for naming components
@@ -42,16 +44,30 @@ var Separator = "-"
 // NewComposeService create a local implementation of the compose.Service API
 func NewComposeService(dockerCli command.Cli) api.Service {
 	return &composeService{
-		dockerCli: dockerCli,
+		dockerCli:   dockerCli,
+		dryRun:      newDryRunState(),
+		execTargets: newExecTargetCache(),
 	}
 }
 
 type composeService struct {
-	dockerCli command.Cli
+	dockerCli   command.Cli
+	dryRun      *dryRunState
+	execTargets *execTargetCache
+
+	loggingClientOnce sync.Once
+	loggingClient     client.APIClient
 }
 
 func (s *composeService) apiClient() client.APIClient {
-	return s.dockerCli.Client()
+	apiClient := s.dockerCli.Client()
+	if logrus.IsLevelEnabled(logrus.DebugLevel) {
+		apiClient = s.apiClientLoggingCalls()
+	}
+	if DryRun {
+		return &dryRunClient{APIClient: apiClient, state: s.dryRun}
+	}
+	return apiClient
 }
 
 func (s *composeService) configFile() *configfile.ConfigFile {
@@ -95,22 +111,25 @@ func getContainerNameWithoutProject(c moby.Container) string {
 }
 
 func (s *composeService) Convert(ctx context.Context, project *types.Project, options api.ConvertOptions) ([]byte, error) {
+	var (
+		marshal []byte
+		err     error
+	)
 	switch options.Format {
 	case "json":
-		marshal, err := json.MarshalIndent(project, "", "  ")
-		if err != nil {
-			return nil, err
-		}
-		return escapeDollarSign(marshal), nil
+		marshal, err = json.MarshalIndent(project, "", "  ")
 	case "yaml":
-		marshal, err := yaml.Marshal(project)
-		if err != nil {
-			return nil, err
-		}
-		return escapeDollarSign(marshal), nil
+		marshal, err = yaml.Marshal(project)
 	default:
-		return nil, fmt.Errorf("unsupported format %q", options)
+		return nil, fmt.Errorf("unsupported format %q", options.Format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if options.EscapeDollarSign {
+		marshal = escapeDollarSign(marshal)
 	}
+	return marshal, nil
 }
 
 func escapeDollarSign(marshal []byte) []byte {
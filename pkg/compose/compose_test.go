@@ -0,0 +1,47 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/compose-spec/compose-go/types"
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose/v2/pkg/api"
+)
+
+func TestConvertEscapeDollarSign(t *testing.T) {
+	project := &types.Project{
+		Name: "test",
+		Services: []types.ServiceConfig{
+			{Name: "web", Image: "alpine", Command: types.ShellCommand{"echo", "$HOME"}},
+		},
+	}
+
+	out, err := tested.Convert(context.Background(), project, api.ConvertOptions{Format: "yaml", EscapeDollarSign: true})
+	assert.NilError(t, err)
+	assert.Assert(t, strings.Contains(string(out), "- $$HOME"), string(out))
+
+	// a model loaded without interpolation still holds the escaped values
+	project.Services[0].Command = types.ShellCommand{"echo", "$$HOME"}
+	out, err = tested.Convert(context.Background(), project, api.ConvertOptions{Format: "yaml"})
+	assert.NilError(t, err)
+	assert.Assert(t, strings.Contains(string(out), "- $$HOME"), string(out))
+}
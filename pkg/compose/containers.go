@@ -20,7 +20,9 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strconv"
 
+	"github.com/compose-spec/compose-go/types"
 	"github.com/docker/compose/v2/pkg/api"
 	"github.com/docker/compose/v2/pkg/utils"
 	moby "github.com/docker/docker/api/types"
@@ -98,6 +100,14 @@ func isService(services ...string) containerPredicate {
 	}
 }
 
+// isReplica is satisfied by the containers with the given replica number in their service
+func isReplica(index int) containerPredicate {
+	number := strconv.Itoa(index)
+	return func(c moby.Container) bool {
+		return c.Labels[api.ContainerNumberLabel] == number
+	}
+}
+
 func isNotService(services ...string) containerPredicate {
 	return func(c moby.Container) bool {
 		service := c.Labels[api.ServiceLabel]
@@ -105,6 +115,20 @@ func isNotService(services ...string) containerPredicate {
 	}
 }
 
+// isOrphaned is satisfied by containers of services the project doesn't define. Services disabled because their
+// profile is not active are still defined, so their containers are not orphans.
+func isOrphaned(project *types.Project) containerPredicate {
+	return isNotService(allServiceNames(project)...)
+}
+
+// hasLabels is satisfied by containers matching all the selectors, set as key=value to match a label value or as key
+// to match containers having the label whatever its value
+func hasLabels(selectors ...string) containerPredicate {
+	return func(c moby.Container) bool {
+		return matchLabels(c.Labels, selectors)
+	}
+}
+
 func isNotOneOff(c moby.Container) bool {
 	v, ok := c.Labels[api.OneoffLabel]
 	return !ok || v == "False"
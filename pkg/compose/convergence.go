@@ -96,7 +96,8 @@ func (c *convergence) apply(ctx context.Context, project *types.Project, options
 		if utils.StringContains(options.Services, name) {
 			strategy = options.Recreate
 		}
-		err = c.ensureService(ctx, project, service, strategy, options.Inherit, options.Timeout)
+		rolling := options.RecreateOrder == api.RecreateOrderRolling
+		err = c.ensureService(ctx, project, service, strategy, rolling, options.Inherit, options.Timeout)
 		if err != nil {
 			return err
 		}
@@ -164,7 +165,7 @@ func updateServices(service *types.ServiceConfig, cnts Containers) {
 	}
 }
 
-func (c *convergence) ensureService(ctx context.Context, project *types.Project, service types.ServiceConfig, recreate string, inherit bool, timeout *time.Duration) error {
+func (c *convergence) ensureService(ctx context.Context, project *types.Project, service types.ServiceConfig, recreate string, rolling bool, inherit bool, timeout *time.Duration) error {
 	expected, err := getScale(service)
 	if err != nil {
 		return err
@@ -174,6 +175,8 @@ func (c *convergence) ensureService(ctx context.Context, project *types.Project,
 	updated := make(Containers, expected)
 
 	eg, _ := errgroup.WithContext(ctx)
+	// indexes of the containers to be recreated one at a time, once other changes are applied
+	var rolled []int
 
 	for i, container := range containers {
 		if i >= expected {
@@ -193,6 +196,10 @@ func (c *convergence) ensureService(ctx context.Context, project *types.Project,
 		if err != nil {
 			return err
 		}
+		if mustRecreate && rolling {
+			rolled = append(rolled, i)
+			continue
+		}
 		if mustRecreate {
 			i, container := i, container
 			eg.Go(func() error {
@@ -240,23 +247,60 @@ func (c *convergence) ensureService(ctx context.Context, project *types.Project,
 	}
 
 	err = eg.Wait()
+	for _, i := range rolled {
+		if err != nil {
+			// containers not recreated yet are kept as they are
+			updated[i] = containers[i]
+			continue
+		}
+		updated[i], err = c.service.rollContainer(ctx, project, service, containers[i], inherit, timeout)
+	}
 	c.setObservedState(service.Name, updated)
 	return err
 }
 
 func mustRecreate(expected types.ServiceConfig, actual moby.Container, policy string) (bool, error) {
+	log := logrus.WithFields(logrus.Fields{
+		"service":   expected.Name,
+		"container": actual.ID,
+	})
 	if policy == api.RecreateNever {
+		log.Debug("container kept: recreate policy is never")
 		return false, nil
 	}
 	if policy == api.RecreateForce || expected.Extensions[extLifecycle] == forceRecreate {
+		log.Debug("container recreated: recreate is forced")
 		return true, nil
 	}
+	if policy == api.RecreateLabelsChanged {
+		if labelsHash, ok := actual.Labels[api.LabelsHashLabel]; ok {
+			expectedHash, err := ServiceLabelsHash(expected)
+			if err != nil {
+				return false, err
+			}
+			changed := labelsHash != expectedHash
+			log.WithField("labels-changed", changed).Debug("recreate decided on labels only")
+			return changed, nil
+		}
+		// container created before labels were tracked separately, fall back to the whole configuration
+	}
 	configHash, err := ServiceHash(expected)
 	if err != nil {
 		return false, err
 	}
 	configChanged := actual.Labels[api.ConfigHashLabel] != configHash
 	imageUpdated := actual.Labels[api.ImageDigestLabel] != expected.CustomLabels[api.ImageDigestLabel]
+	switch {
+	case configChanged:
+		log.WithFields(logrus.Fields{
+			"config-hash":  configHash,
+			"current-hash": actual.Labels[api.ConfigHashLabel],
+		}).Debug("container recreated: configuration changed")
+	case imageUpdated:
+		log.WithField("image", expected.Image).Debug("container recreated: image updated")
+	default:
+		log.Debug("container kept: up to date")
+	}
 	return configChanged || imageUpdated, nil
 }
 
@@ -304,7 +348,11 @@ func (s *composeService) waitDependencies(ctx context.Context, project *types.Pr
 			ticker := time.NewTicker(500 * time.Millisecond)
 			defer ticker.Stop()
 			for {
-				<-ticker.C
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-ticker.C:
+				}
 				switch config.Condition {
 				case ServiceConditionRunningOrHealthy:
 					healthy, err := s.isServiceHealthy(ctx, project, dep, true)
@@ -318,7 +366,8 @@ func (s *composeService) waitDependencies(ctx context.Context, project *types.Pr
 				case types.ServiceConditionHealthy:
 					healthy, err := s.isServiceHealthy(ctx, project, dep, false)
 					if err != nil {
-						return err
+						w.Events(containerEvents(containers, progress.ErrorEvent))
+						return fmt.Errorf("dependency failed to start: %w", err)
 					}
 					if healthy {
 						w.Events(containerEvents(containers, progress.Healthy))
@@ -439,6 +488,68 @@ func (s *composeService) recreateContainer(ctx context.Context, project *types.P
 	return created, err
 }
 
+// rollContainer recreates a container then starts the new one, and waits for it to be running, or healthy if it
+// declares a healthcheck, so that other replicas of the service keep running while it is replaced
+func (s *composeService) rollContainer(ctx context.Context, project *types.Project, service types.ServiceConfig,
+	replaced moby.Container, inherit bool, timeout *time.Duration) (moby.Container, error) {
+	created, err := s.recreateContainer(ctx, project, service, replaced, inherit, timeout)
+	if err != nil {
+		return created, err
+	}
+	w := progress.ContextWriter(ctx)
+	eventName := getContainerProgressName(created)
+	w.Event(progress.StartingEvent(eventName))
+	printCommand(ctx, "container", "start", getCanonicalContainerName(created))
+	err = s.apiClient().ContainerStart(ctx, created.ID, moby.ContainerStartOptions{})
+	if err != nil {
+		return created, err
+	}
+	w.Event(progress.StartedEvent(eventName))
+	w.Event(progress.Waiting(eventName))
+	err = s.waitContainerHealthy(ctx, created.ID)
+	if err != nil {
+		w.Event(progress.ErrorMessageEvent(eventName, err.Error()))
+		return created, fmt.Errorf("rolling recreate of service %q stopped: %w", service.Name, err)
+	}
+	w.Event(progress.Healthy(eventName))
+	return created, nil
+}
+
+// waitContainerHealthy waits for a container to be healthy, or running if it doesn't declare a healthcheck
+func (s *composeService) waitContainerHealthy(ctx context.Context, id string) error {
+	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+		container, err := s.apiClient().ContainerInspect(ctx, id)
+		if err != nil {
+			return err
+		}
+		if container.State == nil {
+			continue
+		}
+		if container.State.Status == "exited" || container.State.Status == "dead" {
+			return fmt.Errorf("container %s exited (%d)", strings.TrimPrefix(container.Name, "/"), container.State.ExitCode)
+		}
+		if healthcheckDisabled(container.Config.Healthcheck) || container.State.Health == nil {
+			if container.State.Running {
+				return nil
+			}
+			continue
+		}
+		switch container.State.Health.Status {
+		case moby.Healthy:
+			return nil
+		case moby.Unhealthy:
+			return fmt.Errorf("container %s is unhealthy", strings.TrimPrefix(container.Name, "/"))
+		}
+	}
+}
+
 // setDependentLifecycle define the Lifecycle strategy for all services to depend on specified service
 func setDependentLifecycle(project *types.Project, service string, strategy string) {
 	for i, s := range project.Services {
@@ -455,6 +566,7 @@ func setDependentLifecycle(project *types.Project, service string, strategy stri
 func (s *composeService) startContainer(ctx context.Context, container moby.Container) error {
 	w := progress.ContextWriter(ctx)
 	w.Event(progress.NewEvent(getContainerProgressName(container), progress.Working, "Restart"))
+	printCommand(ctx, "container", "start", getCanonicalContainerName(container))
 	err := s.apiClient().ContainerStart(ctx, container.ID, moby.ContainerStartOptions{})
 	if err != nil {
 		return err
@@ -479,6 +591,7 @@ func (s *composeService) createMobyContainer(ctx context.Context, project *types
 		}
 		plat = &p
 	}
+	printCommand(ctx, containerCreateArgs(name, containerConfig, hostConfig, networkingConfig)...)
 	response, err := s.apiClient().ContainerCreate(ctx, containerConfig, hostConfig, networkingConfig, plat, name)
 	if err != nil {
 		return created, err
@@ -513,6 +626,7 @@ func (s *composeService) createMobyContainer(ctx context.Context, project *types
 			if shortIDAliasExists(created.ID, val.Aliases...) {
 				continue
 			}
+			printCommand(ctx, "network", "disconnect", netwrk.Name, created.ID)
 			err = s.apiClient().NetworkDisconnect(ctx, netwrk.Name, created.ID, false)
 			if err != nil {
 				return created, err
@@ -529,7 +643,7 @@ func (s *composeService) createMobyContainer(ctx context.Context, project *types
 }
 
 // getLinks mimics V1 compose/service.py::Service::_get_links()
-func (s composeService) getLinks(ctx context.Context, projectName string, service types.ServiceConfig, number int) ([]string, error) {
+func (s *composeService) getLinks(ctx context.Context, projectName string, service types.ServiceConfig, number int) ([]string, error) {
 	var links []string
 	format := func(k, v string) string {
 		return fmt.Sprintf("%s:%s", k, v)
@@ -609,13 +723,15 @@ func (s *composeService) connectContainerToNetwork(ctx context.Context, id strin
 			IPv6Address: ipv6Address,
 		}
 	}
-	err := s.apiClient().NetworkConnect(ctx, netwrk, id, &network.EndpointSettings{
+	endpoint := &network.EndpointSettings{
 		Aliases:           aliases,
 		IPAddress:         ipv4Address,
 		GlobalIPv6Address: ipv6Address,
 		Links:             links,
 		IPAMConfig:        ipam,
-	})
+	}
+	printCommand(ctx, networkConnectArgs(netwrk, id, endpoint)...)
+	err := s.apiClient().NetworkConnect(ctx, netwrk, id, endpoint)
 	if err != nil {
 		return err
 	}
@@ -623,7 +739,8 @@ func (s *composeService) connectContainerToNetwork(ctx context.Context, id strin
 }
 
 func (s *composeService) isServiceHealthy(ctx context.Context, project *types.Project, service string, fallbackRunning bool) (bool, error) {
-	containers, err := s.getContainers(ctx, project.Name, oneOffExclude, false, service)
+	// stopped containers are listed too, as a container exiting before it gets healthy never will
+	containers, err := s.getContainers(ctx, project.Name, oneOffExclude, true, service)
 	if err != nil {
 		return false, err
 	}
@@ -636,7 +753,7 @@ func (s *composeService) isServiceHealthy(ctx context.Context, project *types.Pr
 		if err != nil {
 			return false, err
 		}
-		if container.Config.Healthcheck == nil && fallbackRunning {
+		if healthcheckDisabled(container.Config.Healthcheck) && fallbackRunning {
 			// Container does not define a health check, but we can fall back to "running" state
 			return container.State != nil && container.State.Status == "running", nil
 		}
@@ -644,6 +761,9 @@ func (s *composeService) isServiceHealthy(ctx context.Context, project *types.Pr
 		if container.State == nil || container.State.Health == nil {
 			return false, fmt.Errorf("container for service %q has no healthcheck configured", service)
 		}
+		if container.State.Status == "exited" || container.State.Status == "dead" {
+			return false, fmt.Errorf("container for service %q exited (%d) before becoming healthy", service, container.State.ExitCode)
+		}
 		switch container.State.Health.Status {
 		case moby.Healthy:
 			// Continue by checking the next container.
@@ -713,6 +833,7 @@ func (s *composeService) startService(ctx context.Context, project *types.Projec
 		eg.Go(func() error {
 			eventName := getContainerProgressName(container)
 			w.Event(progress.StartingEvent(eventName))
+			printCommand(ctx, "container", "start", getCanonicalContainerName(container))
 			err := s.apiClient().ContainerStart(ctx, container.ID, moby.ContainerStartOptions{})
 			if err == nil {
 				w.Event(progress.StartedEvent(eventName))
@@ -26,6 +26,7 @@ import (
 	"github.com/docker/compose/v2/pkg/api"
 	"github.com/docker/compose/v2/pkg/mocks"
 	moby "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/filters"
 	"github.com/golang/mock/gomock"
 	"gotest.tools/assert"
@@ -226,4 +227,116 @@ func TestWaitDependencies(t *testing.T) {
 		}
 		assert.NilError(t, tested.waitDependencies(context.Background(), &project, dependencies))
 	})
+
+	healthyDependency := func(state *moby.ContainerState) error {
+		webService := types.ServiceConfig{Name: "web", Scale: 1}
+		project := types.Project{Name: strings.ToLower(testProject), Services: []types.ServiceConfig{webService}}
+		dependencies := types.DependsOnConfig{
+			"web": {Condition: types.ServiceConditionHealthy},
+		}
+		apiClient.EXPECT().ContainerList(gomock.Any(), gomock.Any()).
+			Return([]moby.Container{testContainer("web", "123", false)}, nil).AnyTimes()
+		apiClient.EXPECT().ContainerInspect(gomock.Any(), "123").Return(moby.ContainerJSON{
+			ContainerJSONBase: &moby.ContainerJSONBase{State: state},
+			Config: &container.Config{
+				Healthcheck: &container.HealthConfig{Test: []string{"CMD", "true"}},
+			},
+		}, nil).Times(1)
+		return tested.waitDependencies(context.Background(), &project, dependencies)
+	}
+	t.Run("should wait for dependencies with condition service_healthy to be healthy", func(t *testing.T) {
+		err := healthyDependency(&moby.ContainerState{Status: "running", Health: &moby.Health{Status: moby.Healthy}})
+		assert.NilError(t, err)
+	})
+	t.Run("should fail when a dependency with condition service_healthy is unhealthy", func(t *testing.T) {
+		err := healthyDependency(&moby.ContainerState{Status: "running", Health: &moby.Health{Status: moby.Unhealthy}})
+		assert.Error(t, err, `dependency failed to start: container for service "web" is unhealthy`)
+	})
+	t.Run("should fail when a dependency with condition service_healthy exited", func(t *testing.T) {
+		err := healthyDependency(&moby.ContainerState{Status: "exited", ExitCode: 1, Health: &moby.Health{Status: moby.Starting}})
+		assert.Error(t, err, `dependency failed to start: container for service "web" exited (1) before becoming healthy`)
+	})
+}
+
+func TestWaitContainerHealthy(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	apiClient := mocks.NewMockAPIClient(mockCtrl)
+	cli := mocks.NewMockCli(mockCtrl)
+	tested.dockerCli = cli
+	cli.EXPECT().Client().Return(apiClient).AnyTimes()
+
+	inspect := func(state *moby.ContainerState, healthcheck *container.HealthConfig) moby.ContainerJSON {
+		return moby.ContainerJSON{
+			ContainerJSONBase: &moby.ContainerJSONBase{Name: "/web-1", State: state},
+			Config:            &container.Config{Healthcheck: healthcheck},
+		}
+	}
+	healthcheck := &container.HealthConfig{Test: []string{"CMD", "true"}}
+
+	t.Run("waits for the container to be healthy", func(t *testing.T) {
+		gomock.InOrder(
+			apiClient.EXPECT().ContainerInspect(gomock.Any(), "123").
+				Return(inspect(&moby.ContainerState{Status: "running", Running: true, Health: &moby.Health{Status: moby.Starting}}, healthcheck), nil),
+			apiClient.EXPECT().ContainerInspect(gomock.Any(), "123").
+				Return(inspect(&moby.ContainerState{Status: "running", Running: true, Health: &moby.Health{Status: moby.Healthy}}, healthcheck), nil),
+		)
+		assert.NilError(t, tested.waitContainerHealthy(context.Background(), "123"))
+	})
+	t.Run("running is enough without healthcheck", func(t *testing.T) {
+		apiClient.EXPECT().ContainerInspect(gomock.Any(), "123").
+			Return(inspect(&moby.ContainerState{Status: "running", Running: true}, nil), nil)
+		assert.NilError(t, tested.waitContainerHealthy(context.Background(), "123"))
+	})
+	t.Run("fails when the container is unhealthy", func(t *testing.T) {
+		apiClient.EXPECT().ContainerInspect(gomock.Any(), "123").
+			Return(inspect(&moby.ContainerState{Status: "running", Running: true, Health: &moby.Health{Status: moby.Unhealthy}}, healthcheck), nil)
+		assert.Error(t, tested.waitContainerHealthy(context.Background(), "123"), "container web-1 is unhealthy")
+	})
+	t.Run("fails when the container exited", func(t *testing.T) {
+		apiClient.EXPECT().ContainerInspect(gomock.Any(), "123").
+			Return(inspect(&moby.ContainerState{Status: "exited", ExitCode: 2}, nil), nil)
+		assert.Error(t, tested.waitContainerHealthy(context.Background(), "123"), "container web-1 exited (2)")
+	})
+}
+
+func TestMustRecreateOnLabelChange(t *testing.T) {
+	service := types.ServiceConfig{
+		Name:        "app",
+		Image:       "alpine",
+		Labels:      types.Labels{"com.example.release": "1"},
+		Environment: types.NewMappingWithEquals([]string{"LEVEL=info"}),
+	}
+	configHash, err := ServiceHash(service)
+	assert.NilError(t, err)
+	labelsHash, err := ServiceLabelsHash(service)
+	assert.NilError(t, err)
+	container := moby.Container{
+		Labels: map[string]string{
+			api.ConfigHashLabel: configHash,
+			api.LabelsHashLabel: labelsHash,
+		},
+	}
+
+	changedEnv := service
+	changedEnv.Environment = types.NewMappingWithEquals([]string{"LEVEL=debug"})
+	recreate, err := mustRecreate(changedEnv, container, api.RecreateDiverged)
+	assert.NilError(t, err)
+	assert.Check(t, recreate)
+	recreate, err = mustRecreate(changedEnv, container, api.RecreateLabelsChanged)
+	assert.NilError(t, err)
+	assert.Check(t, !recreate)
+
+	changedLabels := service
+	changedLabels.Labels = types.Labels{"com.example.release": "2"}
+	recreate, err = mustRecreate(changedLabels, container, api.RecreateLabelsChanged)
+	assert.NilError(t, err)
+	assert.Check(t, recreate)
+
+	// containers without the labels hash fall back to the configuration hash
+	delete(container.Labels, api.LabelsHashLabel)
+	recreate, err = mustRecreate(changedEnv, container, api.RecreateLabelsChanged)
+	assert.NilError(t, err)
+	assert.Check(t, recreate)
 }
@@ -37,6 +37,11 @@ func ToMobyEnv(environment compose.MappingWithEquals) []string {
 	return env
 }
 
+// healthcheckDisabled tells if a container has no healthcheck, or has it explicitly disabled
+func healthcheckDisabled(healthcheck *container.HealthConfig) bool {
+	return healthcheck == nil || len(healthcheck.Test) > 0 && healthcheck.Test[0] == "NONE"
+}
+
 // ToMobyHealthCheck convert into container.HealthConfig
 func ToMobyHealthCheck(check *compose.HealthCheckConfig) *container.HealthConfig {
 	if check == nil {
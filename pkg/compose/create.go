@@ -48,6 +48,9 @@ import (
 )
 
 func (s *composeService) Create(ctx context.Context, project *types.Project, options api.CreateOptions) error {
+	if options.PrintCommands {
+		ctx = withCommandPrinter(ctx, s.stdout())
+	}
 	return progress.Run(ctx, func(ctx context.Context) error {
 		return s.create(ctx, project, options)
 	})
@@ -64,7 +67,7 @@ func (s *composeService) create(ctx context.Context, project *types.Project, opt
 		return err
 	}
 
-	err = s.ensureImagesExists(ctx, project, options.QuietPull)
+	err = s.ensureImagesExists(ctx, project, options.QuietPull, options.QuietBuild, options.PreferBuild)
 	if err != nil {
 		return err
 	}
@@ -84,16 +87,11 @@ func (s *composeService) create(ctx context.Context, project *types.Project, opt
 		return err
 	}
 
-	allServices := project.AllServices()
-	allServiceNames := []string{}
-	for _, service := range allServices {
-		allServiceNames = append(allServiceNames, service.Name)
-	}
-	orphans := observedState.filter(isNotService(allServiceNames...))
+	orphans := observedState.filter(isOrphaned(project))
 	if len(orphans) > 0 && !options.IgnoreOrphans {
 		if options.RemoveOrphans {
 			w := progress.ContextWriter(ctx)
-			err := s.removeContainers(ctx, w, orphans, nil, false)
+			err := s.removeContainers(ctx, w, orphans, nil, "", false)
 			if err != nil {
 				return err
 			}
@@ -444,6 +442,12 @@ func (s *composeService) prepareLabels(service types.ServiceConfig, number int)
 	}
 	labels[api.ConfigHashLabel] = hash
 
+	labelsHash, err := ServiceLabelsHash(service)
+	if err != nil {
+		return nil, err
+	}
+	labels[api.LabelsHashLabel] = labelsHash
+
 	labels[api.ContainerNumberLabel] = strconv.Itoa(number)
 
 	var dependencies []string
@@ -1101,6 +1105,7 @@ func (s *composeService) ensureNetwork(ctx context.Context, n types.NetworkConfi
 		networkEventName := fmt.Sprintf("Network %s", n.Name)
 		w := progress.ContextWriter(ctx)
 		w.Event(progress.CreatingEvent(networkEventName))
+		printCommand(ctx, networkCreateArgs(n.Name, createOpts)...)
 		if _, err := s.apiClient().NetworkCreate(ctx, n.Name, createOpts); err != nil {
 			w.Event(progress.ErrorEvent(networkEventName))
 			return errors.Wrapf(err, "failed to create network %s", n.Name)
@@ -1143,12 +1148,14 @@ func (s *composeService) createVolume(ctx context.Context, volume types.VolumeCo
 	eventName := fmt.Sprintf("Volume %q", volume.Name)
 	w := progress.ContextWriter(ctx)
 	w.Event(progress.CreatingEvent(eventName))
-	_, err := s.apiClient().VolumeCreate(ctx, volume_api.VolumeCreateBody{
+	body := volume_api.VolumeCreateBody{
 		Labels:     volume.Labels,
 		Name:       volume.Name,
 		Driver:     volume.Driver,
 		DriverOpts: volume.DriverOpts,
-	})
+	}
+	printCommand(ctx, volumeCreateArgs(body)...)
+	_, err := s.apiClient().VolumeCreate(ctx, body)
 	if err != nil {
 		w.Event(progress.ErrorEvent(eventName))
 		return err
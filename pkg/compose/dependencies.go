@@ -23,6 +23,7 @@ import (
 	"sync"
 
 	"github.com/compose-spec/compose-go/types"
+	"github.com/sirupsen/logrus"
 	"golang.org/x/sync/errgroup"
 
 	"github.com/docker/compose/v2/pkg/utils"
@@ -93,9 +94,15 @@ func run(ctx context.Context, graph *Graph, eg *errgroup.Group, nodes []*Vertex,
 	for _, node := range nodes {
 		// Don't start this service yet if all of its children have
 		// not been started yet.
-		if len(traversalConfig.filterAdjacentByStatusFn(graph, node.Key, traversalConfig.adjacentServiceStatusToSkip)) != 0 {
+		if pending := traversalConfig.filterAdjacentByStatusFn(graph, node.Key, traversalConfig.adjacentServiceStatusToSkip); len(pending) != 0 {
+			var names []string
+			for _, v := range pending {
+				names = append(names, v.Service)
+			}
+			logrus.WithField("service", node.Service).Debugf("waiting for %s", strings.Join(names, ", "))
 			continue
 		}
+		logrus.WithField("service", node.Service).Debug("dependency order satisfied")
 
 		node := node
 		eg.Go(func() error {
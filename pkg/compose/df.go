@@ -0,0 +1,108 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	moby "github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/stringid"
+
+	"github.com/docker/compose/v2/pkg/api"
+	"github.com/docker/compose/v2/pkg/utils"
+)
+
+var diskUsageOrder = map[string]int{
+	api.DiskUsageImage:     0,
+	api.DiskUsageContainer: 1,
+	api.DiskUsageVolume:    2,
+}
+
+func (s *composeService) DiskUsage(ctx context.Context, projectName string, options api.DiskUsageOptions) ([]api.DiskUsageSummary, error) {
+	projectName = strings.ToLower(projectName)
+	du, err := s.apiClient().DiskUsage(ctx, moby.DiskUsageOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var containers []api.DiskUsageSummary
+	images := map[string]bool{}
+	volumes := map[string]bool{}
+	for _, c := range du.Containers {
+		if c.Labels[api.ProjectLabel] != projectName {
+			continue
+		}
+		if len(options.Services) > 0 && !utils.StringContains(options.Services, c.Labels[api.ServiceLabel]) {
+			continue
+		}
+		containers = append(containers, api.DiskUsageSummary{
+			Type: api.DiskUsageContainer,
+			Name: getCanonicalContainerName(*c),
+			Size: c.SizeRw,
+		})
+		images[c.ImageID] = true
+		for _, m := range c.Mounts {
+			if m.Type == "volume" {
+				volumes[m.Name] = true
+			}
+		}
+	}
+
+	var summary []api.DiskUsageSummary
+	for _, img := range du.Images {
+		if !images[img.ID] {
+			continue
+		}
+		name := stringid.TruncateID(img.ID)
+		if len(img.RepoTags) > 0 {
+			name = img.RepoTags[0]
+		}
+		summary = append(summary, api.DiskUsageSummary{
+			Type: api.DiskUsageImage,
+			Name: name,
+			Size: img.Size,
+		})
+	}
+	summary = append(summary, containers...)
+	for _, v := range du.Volumes {
+		if v.Labels[api.ProjectLabel] != projectName {
+			continue
+		}
+		if len(options.Services) > 0 && !volumes[v.Name] {
+			continue
+		}
+		var size int64
+		if v.UsageData != nil && v.UsageData.Size > 0 {
+			size = v.UsageData.Size
+		}
+		summary = append(summary, api.DiskUsageSummary{
+			Type: api.DiskUsageVolume,
+			Name: v.Name,
+			Size: size,
+		})
+	}
+
+	sort.Slice(summary, func(i, j int) bool {
+		if summary[i].Type != summary[j].Type {
+			return diskUsageOrder[summary[i].Type] < diskUsageOrder[summary[j].Type]
+		}
+		return summary[i].Name < summary[j].Name
+	})
+	return summary, nil
+}
@@ -0,0 +1,82 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"testing"
+
+	moby "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/golang/mock/gomock"
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose/v2/pkg/api"
+	"github.com/docker/compose/v2/pkg/mocks"
+)
+
+func TestDiskUsage(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	apiClient := mocks.NewMockAPIClient(mockCtrl)
+	cli := mocks.NewMockCli(mockCtrl)
+	tested.dockerCli = cli
+	cli.EXPECT().Client().Return(apiClient).AnyTimes()
+
+	ctx := context.Background()
+	labels := func(project, service string) map[string]string {
+		return map[string]string{api.ProjectLabel: project, api.ServiceLabel: service}
+	}
+	apiClient.EXPECT().DiskUsage(ctx, moby.DiskUsageOptions{}).Return(moby.DiskUsage{
+		Images: []*moby.ImageSummary{
+			{ID: "sha256:nginx", RepoTags: []string{"nginx:alpine"}, Size: 100},
+			{ID: "sha256:alpine", RepoTags: []string{"alpine:latest"}, Size: 50},
+			{ID: "sha256:other", RepoTags: []string{"other:latest"}, Size: 10},
+		},
+		Containers: []*moby.Container{
+			{ID: "123", Names: []string{"/test-web-1"}, ImageID: "sha256:nginx", Labels: labels("test", "web"), SizeRw: 2},
+			{ID: "456", Names: []string{"/test-db-1"}, ImageID: "sha256:alpine", Labels: labels("test", "db"), SizeRw: 3,
+				Mounts: []moby.MountPoint{{Type: mount.TypeVolume, Name: "test_data"}}},
+			{ID: "789", Names: []string{"/other-1"}, ImageID: "sha256:other", Labels: labels("other", "web"), SizeRw: 4},
+		},
+		Volumes: []*moby.Volume{
+			{Name: "test_data", Labels: map[string]string{api.ProjectLabel: "test"}, UsageData: &moby.VolumeUsageData{Size: 20}},
+			{Name: "test_cache", Labels: map[string]string{api.ProjectLabel: "test"}, UsageData: &moby.VolumeUsageData{Size: -1}},
+			{Name: "other_data", Labels: map[string]string{api.ProjectLabel: "other"}},
+		},
+	}, nil).Times(2)
+
+	summary, err := tested.DiskUsage(ctx, "test", api.DiskUsageOptions{})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, summary, []api.DiskUsageSummary{
+		{Type: api.DiskUsageImage, Name: "alpine:latest", Size: 50},
+		{Type: api.DiskUsageImage, Name: "nginx:alpine", Size: 100},
+		{Type: api.DiskUsageContainer, Name: "test-db-1", Size: 3},
+		{Type: api.DiskUsageContainer, Name: "test-web-1", Size: 2},
+		{Type: api.DiskUsageVolume, Name: "test_cache", Size: 0},
+		{Type: api.DiskUsageVolume, Name: "test_data", Size: 20},
+	})
+
+	summary, err = tested.DiskUsage(ctx, "test", api.DiskUsageOptions{Services: []string{"db"}})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, summary, []api.DiskUsageSummary{
+		{Type: api.DiskUsageImage, Name: "alpine:latest", Size: 50},
+		{Type: api.DiskUsageContainer, Name: "test-db-1", Size: 3},
+		{Type: api.DiskUsageVolume, Name: "test_data", Size: 20},
+	})
+}
@@ -0,0 +1,314 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/compose-spec/compose-go/types"
+	moby "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/errdefs"
+
+	"github.com/docker/compose/v2/pkg/api"
+	"github.com/docker/compose/v2/pkg/utils"
+)
+
+func (s *composeService) Diff(ctx context.Context, project *types.Project, options api.DiffOptions) ([]api.DiffSummary, error) {
+	observedState, err := s.getContainers(ctx, project.Name, oneOffExclude, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// resolve local images so that image updates are reported the same way `up` would detect them
+	if _, err := s.getLocalImagesDigests(ctx, project); err != nil {
+		return nil, err
+	}
+
+	var summary []api.DiffSummary
+	for _, service := range project.Services {
+		if len(options.Services) > 0 && !utils.StringContains(options.Services, service.Name) {
+			continue
+		}
+		diff, err := s.diffService(ctx, project, service, observedState.filter(isService(service.Name)))
+		if err != nil {
+			return nil, err
+		}
+		summary = append(summary, diff...)
+	}
+
+	if len(options.Services) == 0 {
+		orphans := map[string]bool{}
+		for _, c := range observedState.filter(isOrphaned(project)) {
+			orphans[c.Labels[api.ServiceLabel]] = true
+		}
+		var names []string
+		for name := range orphans {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			summary = append(summary, api.DiffSummary{
+				Type:   api.DiffService,
+				Name:   name,
+				Action: api.DiffOrphan,
+			})
+		}
+	}
+
+	networks, err := s.diffNetworks(ctx, project)
+	if err != nil {
+		return nil, err
+	}
+	summary = append(summary, networks...)
+
+	volumes, err := s.diffVolumes(ctx, project)
+	if err != nil {
+		return nil, err
+	}
+	summary = append(summary, volumes...)
+	return summary, nil
+}
+
+func (s *composeService) diffService(ctx context.Context, project *types.Project, service types.ServiceConfig, containers Containers) ([]api.DiffSummary, error) {
+	if len(containers) == 0 {
+		return []api.DiffSummary{{
+			Type:   api.DiffService,
+			Name:   service.Name,
+			Action: api.DiffCreate,
+		}}, nil
+	}
+
+	expected, err := getScale(service)
+	if err != nil {
+		return nil, err
+	}
+
+	var summary []api.DiffSummary
+	recreate := false
+	for i, c := range containers {
+		if i >= expected {
+			summary = append(summary, api.DiffSummary{
+				Type:   api.DiffService,
+				Name:   service.Name,
+				Action: api.DiffRemove,
+			})
+			continue
+		}
+		mustRecreate, err := mustRecreate(service, c, api.RecreateDiverged)
+		if err != nil {
+			return nil, err
+		}
+		if !mustRecreate || recreate {
+			continue
+		}
+		// all replicas share the same configuration, so only report the service once
+		recreate = true
+		fields, err := s.diffContainer(ctx, project, service, c)
+		if err != nil {
+			return nil, err
+		}
+		summary = append(summary, api.DiffSummary{
+			Type:   api.DiffService,
+			Name:   service.Name,
+			Action: api.DiffRecreate,
+			Fields: fields,
+		})
+	}
+	if len(containers) < expected {
+		summary = append(summary, api.DiffSummary{
+			Type:   api.DiffService,
+			Name:   service.Name,
+			Action: api.DiffCreate,
+		})
+	}
+	return summary, nil
+}
+
+// diffContainer lists the service attributes which differ from the running container configuration
+func (s *composeService) diffContainer(ctx context.Context, project *types.Project, service types.ServiceConfig, c moby.Container) ([]string, error) {
+	inspected, err := s.apiClient().ContainerInspect(ctx, c.ID)
+	if err != nil {
+		return nil, err
+	}
+	config := inspected.Config
+
+	var imageEnv []string
+	imageLabels := map[string]string{}
+	image, _, err := s.apiClient().ImageInspectWithRaw(ctx, inspected.Image)
+	if err != nil && !errdefs.IsNotFound(err) {
+		return nil, err
+	}
+	if err == nil && image.Config != nil {
+		imageEnv = image.Config.Env
+		imageLabels = image.Config.Labels
+	}
+
+	var fields []string
+	if config.Image != getImageName(service, project.Name) {
+		fields = append(fields, "image")
+	}
+	if c.Labels[api.ImageDigestLabel] != service.CustomLabels[api.ImageDigestLabel] {
+		fields = append(fields, "image digest")
+	}
+	if service.Command != nil && !equalStrings(config.Cmd, service.Command) {
+		fields = append(fields, "command")
+	}
+	if service.Entrypoint != nil && !equalStrings(config.Entrypoint, service.Entrypoint) {
+		fields = append(fields, "entrypoint")
+	}
+	if config.WorkingDir != service.WorkingDir && service.WorkingDir != "" {
+		fields = append(fields, "working_dir")
+	}
+	if config.User != service.User && service.User != "" {
+		fields = append(fields, "user")
+	}
+
+	proxyConfig := types.MappingWithEquals(s.configFile().ParseProxyConfig(s.apiClient().DaemonHost(), nil))
+	env := proxyConfig.OverrideBy(service.Environment)
+	fields = append(fields, diffMapping("environment", envToMap(config.Env), mappingToMap(env), envToMap(imageEnv))...)
+
+	actualLabels := map[string]string{}
+	for k, v := range config.Labels {
+		if !strings.HasPrefix(k, "com.docker.compose.") {
+			actualLabels[k] = v
+		}
+	}
+	fields = append(fields, diffMapping("labels", actualLabels, service.Labels, imageLabels)...)
+
+	if len(fields) == 0 {
+		// config hash changed on an attribute we don't inspect
+		fields = append(fields, "configuration")
+	}
+	return fields, nil
+}
+
+// diffMapping reports keys whose values differ between the running and expected mappings.
+// Keys only set by the image are ignored as long as they keep the image value.
+func diffMapping(prefix string, actual, expected, inherited map[string]string) []string {
+	var keys []string
+	for k, v := range expected {
+		if a, ok := actual[k]; !ok || a != v {
+			keys = append(keys, k)
+		}
+	}
+	for k, v := range actual {
+		if _, ok := expected[k]; ok {
+			continue
+		}
+		if i, ok := inherited[k]; ok && i == v {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	fields := make([]string, len(keys))
+	for i, k := range keys {
+		fields[i] = prefix + "." + k
+	}
+	return fields
+}
+
+func equalStrings(actual, expected []string) bool {
+	if len(actual) != len(expected) {
+		return false
+	}
+	for i := range actual {
+		if actual[i] != expected[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func envToMap(env []string) map[string]string {
+	m := map[string]string{}
+	for _, e := range env {
+		k, v, _ := strings.Cut(e, "=")
+		m[k] = v
+	}
+	return m
+}
+
+func mappingToMap(mapping types.MappingWithEquals) map[string]string {
+	m := map[string]string{}
+	for k, v := range mapping {
+		if v != nil {
+			m[k] = *v
+		}
+	}
+	return m
+}
+
+func (s *composeService) diffNetworks(ctx context.Context, project *types.Project) ([]api.DiffSummary, error) {
+	var summary []api.DiffSummary
+	for _, n := range project.Networks {
+		if n.External.External {
+			continue
+		}
+		networks, err := s.apiClient().NetworkList(ctx, moby.NetworkListOptions{
+			Filters: filters.NewArgs(filters.Arg("name", n.Name)),
+		})
+		if err != nil {
+			return nil, err
+		}
+		found := false
+		for _, nw := range networks {
+			if nw.Name == n.Name {
+				found = true
+			}
+		}
+		if !found {
+			summary = append(summary, api.DiffSummary{
+				Type:   api.DiffNetwork,
+				Name:   n.Name,
+				Action: api.DiffCreate,
+			})
+		}
+	}
+	sort.Slice(summary, func(i, j int) bool {
+		return summary[i].Name < summary[j].Name
+	})
+	return summary, nil
+}
+
+func (s *composeService) diffVolumes(ctx context.Context, project *types.Project) ([]api.DiffSummary, error) {
+	var summary []api.DiffSummary
+	for _, v := range project.Volumes {
+		if v.External.External {
+			continue
+		}
+		_, err := s.apiClient().VolumeInspect(ctx, v.Name)
+		if err == nil {
+			continue
+		}
+		if !errdefs.IsNotFound(err) {
+			return nil, err
+		}
+		summary = append(summary, api.DiffSummary{
+			Type:   api.DiffVolume,
+			Name:   v.Name,
+			Action: api.DiffCreate,
+		})
+	}
+	sort.Slice(summary, func(i, j int) bool {
+		return summary[i].Name < summary[j].Name
+	})
+	return summary, nil
+}
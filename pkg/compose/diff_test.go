@@ -0,0 +1,45 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestDiffMapping(t *testing.T) {
+	actual := map[string]string{
+		"PATH":    "/usr/bin",
+		"FOO":     "bar",
+		"REMOVED": "yes",
+		"SAME":    "value",
+	}
+	expected := map[string]string{
+		"FOO":   "baz",
+		"SAME":  "value",
+		"ADDED": "new",
+	}
+	inherited := map[string]string{
+		"PATH": "/usr/bin",
+	}
+	fields := diffMapping("environment", actual, expected, inherited)
+	assert.DeepEqual(t, fields, []string{"environment.ADDED", "environment.FOO", "environment.REMOVED"})
+
+	fields = diffMapping("labels", expected, expected, nil)
+	assert.Equal(t, len(fields), 0)
+}
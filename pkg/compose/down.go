@@ -24,6 +24,7 @@ import (
 
 	"github.com/compose-spec/compose-go/types"
 	moby "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/errdefs"
 	"github.com/pkg/errors"
@@ -31,10 +32,14 @@ import (
 
 	"github.com/docker/compose/v2/pkg/api"
 	"github.com/docker/compose/v2/pkg/progress"
+	"github.com/docker/compose/v2/pkg/utils"
 )
 
 type downOp func() error
 
+// defaultStopTimeout is the grace period the engine gives containers to stop, unless set by stop_grace_period
+const defaultStopTimeout = 10 * time.Second
+
 func (s *composeService) Down(ctx context.Context, projectName string, options api.DownOptions) error {
 	return progress.Run(ctx, func(ctx context.Context) error {
 		return s.down(ctx, strings.ToLower(projectName), options)
@@ -65,7 +70,7 @@ func (s *composeService) down(ctx context.Context, projectName string, options a
 
 	err = InReverseDependencyOrder(ctx, project, func(c context.Context, service string) error {
 		serviceContainers := containers.filter(isService(service))
-		err := s.removeContainers(ctx, w, serviceContainers, options.Timeout, options.Volumes)
+		err := s.removeContainers(ctx, w, serviceContainers, options.Timeout, options.Signal, options.Volumes)
 		return err
 	})
 	if err != nil {
@@ -74,20 +79,23 @@ func (s *composeService) down(ctx context.Context, projectName string, options a
 
 	orphans := containers.filter(isNotService(project.ServiceNames()...))
 	if options.RemoveOrphans && len(orphans) > 0 {
-		err := s.removeContainers(ctx, w, orphans, options.Timeout, false)
+		err := s.removeContainers(ctx, w, orphans, options.Timeout, options.Signal, false)
 		if err != nil {
 			return err
 		}
 	}
 
-	ops := s.ensureNetworksDown(ctx, project, w)
+	var ops []downOp
+	if !options.KeepNetworks {
+		ops = append(ops, s.ensureNetworksDown(ctx, project, w)...)
+	}
 
 	if options.Images != "" {
 		ops = append(ops, s.ensureImagesDown(ctx, project, options, w)...)
 	}
 
 	if options.Volumes {
-		ops = append(ops, s.ensureVolumesDown(ctx, project, w)...)
+		ops = append(ops, s.ensureVolumesDown(ctx, project, options.PreserveVolumes, w)...)
 	}
 
 	if !resourceToRemove && len(ops) == 0 {
@@ -101,12 +109,15 @@ func (s *composeService) down(ctx context.Context, projectName string, options a
 	return eg.Wait()
 }
 
-func (s *composeService) ensureVolumesDown(ctx context.Context, project *types.Project, w progress.Writer) []downOp {
+func (s *composeService) ensureVolumesDown(ctx context.Context, project *types.Project, preserve []string, w progress.Writer) []downOp {
 	var ops []downOp
-	for _, vol := range project.Volumes {
+	for name, vol := range project.Volumes {
 		if vol.External.External {
 			continue
 		}
+		if utils.StringContains(preserve, name) || utils.StringContains(preserve, vol.Name) {
+			continue
+		}
 		volumeName := vol.Name
 		ops = append(ops, func() error {
 			return s.removeVolume(ctx, volumeName, w)
@@ -189,6 +200,11 @@ func (s *composeService) getServiceImages(options api.DownOptions, project *type
 	images := map[string]struct{}{}
 	for _, service := range project.Services {
 		image := service.Image
+		if options.Project == nil && image == getImageName(types.ServiceConfig{Name: service.Name}, project.Name) {
+			// project was discovered from containers, which all have an image set: default image name is the one
+			// given to images built by compose for services without an explicit image
+			image = ""
+		}
 		if options.Images == "local" && image != "" {
 			continue
 		}
@@ -230,14 +246,14 @@ func (s *composeService) removeVolume(ctx context.Context, id string, w progress
 	return err
 }
 
-func (s *composeService) stopContainers(ctx context.Context, w progress.Writer, containers []moby.Container, timeout *time.Duration) error {
+func (s *composeService) stopContainers(ctx context.Context, w progress.Writer, containers []moby.Container, timeout *time.Duration, signal string) error {
 	eg, ctx := errgroup.WithContext(ctx)
 	for _, container := range containers {
 		container := container
 		eg.Go(func() error {
 			eventName := getContainerProgressName(container)
 			w.Event(progress.StoppingEvent(eventName))
-			err := s.apiClient().ContainerStop(ctx, container.ID, timeout)
+			err := s.stopContainer(ctx, container, timeout, signal)
 			if err != nil {
 				w.Event(progress.ErrorMessageEvent(eventName, "Error while Stopping"))
 				return err
@@ -249,14 +265,49 @@ func (s *composeService) stopContainers(ctx context.Context, w progress.Writer,
 	return eg.Wait()
 }
 
-func (s *composeService) removeContainers(ctx context.Context, w progress.Writer, containers []moby.Container, timeout *time.Duration, volumes bool) error {
+// stopContainer stops a container with the stop signal it was created with, or with signal when set. As the engine API
+// doesn't let the stop signal be overridden, the signal is then sent by compose, and the container killed once timeout,
+// or the stop timeout it was created with, elapses
+func (s *composeService) stopContainer(ctx context.Context, c moby.Container, timeout *time.Duration, signal string) error {
+	if signal == "" || c.State != "running" {
+		return s.apiClient().ContainerStop(ctx, c.ID, timeout)
+	}
+	if timeout == nil {
+		inspect, err := s.apiClient().ContainerInspect(ctx, c.ID)
+		if err != nil {
+			return err
+		}
+		grace := defaultStopTimeout
+		if inspect.Config != nil && inspect.Config.StopTimeout != nil {
+			grace = time.Duration(*inspect.Config.StopTimeout) * time.Second
+		}
+		timeout = &grace
+	}
+
+	exited, failed := s.apiClient().ContainerWait(ctx, c.ID, container.WaitConditionNotRunning)
+	if err := s.apiClient().ContainerKill(ctx, c.ID, signal); err != nil {
+		return err
+	}
+	select {
+	case <-exited:
+		return nil
+	case err := <-failed:
+		return err
+	case <-time.After(*timeout):
+		// the signal didn't stop the container within its grace period, let the engine kill it
+		kill := time.Duration(0)
+		return s.apiClient().ContainerStop(ctx, c.ID, &kill)
+	}
+}
+
+func (s *composeService) removeContainers(ctx context.Context, w progress.Writer, containers []moby.Container, timeout *time.Duration, signal string, volumes bool) error {
 	eg, _ := errgroup.WithContext(ctx)
 	for _, container := range containers {
 		container := container
 		eg.Go(func() error {
 			eventName := getContainerProgressName(container)
 			w.Event(progress.StoppingEvent(eventName))
-			err := s.stopContainers(ctx, w, []moby.Container{container}, timeout)
+			err := s.stopContainers(ctx, w, []moby.Container{container}, timeout, signal)
 			if err != nil {
 				w.Event(progress.ErrorMessageEvent(eventName, "Error while Stopping"))
 				return err
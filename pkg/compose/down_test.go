@@ -20,8 +20,10 @@ import (
 	"context"
 	"strings"
 	"testing"
+	"time"
 
 	moby "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/volume"
 	"github.com/golang/mock/gomock"
@@ -116,6 +118,82 @@ func TestDownRemoveOrphans(t *testing.T) {
 	assert.NilError(t, err)
 }
 
+func TestDownSignal(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	api := mocks.NewMockAPIClient(mockCtrl)
+	cli := mocks.NewMockCli(mockCtrl)
+	tested.dockerCli = cli
+	cli.EXPECT().Client().Return(api).AnyTimes()
+
+	running := testContainer("service1", "123", false)
+	running.State = "running"
+	stuck := testContainer("service1", "456", false)
+	stuck.State = "running"
+	exited := testContainer("service2", "789", false)
+	exited.State = "exited"
+	api.EXPECT().ContainerList(gomock.Any(), projectFilterListOpt()).Return([]moby.Container{running, stuck, exited}, nil)
+	api.EXPECT().VolumeList(gomock.Any(), filters.NewArgs(projectFilter(strings.ToLower(testProject)))).
+		Return(volume.VolumeListOKBody{}, nil)
+	api.EXPECT().NetworkList(gomock.Any(), moby.NetworkListOptions{Filters: filters.NewArgs(projectFilter(strings.ToLower(testProject)))}).
+		Return([]moby.NetworkResource{{Name: "myProject_default"}}, nil)
+
+	stopped := make(chan container.ContainerWaitOKBody, 1)
+	stopped <- container.ContainerWaitOKBody{}
+	var stoppedC <-chan container.ContainerWaitOKBody = stopped
+	var noError <-chan error = make(chan error)
+	var neverC <-chan container.ContainerWaitOKBody = make(chan container.ContainerWaitOKBody)
+
+	// stop_grace_period of the service is used as no timeout is set
+	stopTimeout := 0
+	api.EXPECT().ContainerInspect(gomock.Any(), "123").Return(moby.ContainerJSON{Config: &container.Config{}}, nil)
+	api.EXPECT().ContainerInspect(gomock.Any(), "456").Return(moby.ContainerJSON{Config: &container.Config{StopTimeout: &stopTimeout}}, nil)
+	api.EXPECT().ContainerWait(gomock.Any(), "123", container.WaitConditionNotRunning).Return(stoppedC, noError)
+	api.EXPECT().ContainerWait(gomock.Any(), "456", container.WaitConditionNotRunning).Return(neverC, noError)
+	api.EXPECT().ContainerKill(gomock.Any(), "123", "SIGQUIT").Return(nil)
+	api.EXPECT().ContainerKill(gomock.Any(), "456", "SIGQUIT").Return(nil)
+	// container ignoring the signal is killed once its grace period elapsed
+	kill := time.Duration(0)
+	api.EXPECT().ContainerStop(gomock.Any(), "456", &kill).Return(nil)
+	// container which is not running is not sent the signal
+	api.EXPECT().ContainerStop(gomock.Any(), "789", nil).Return(nil)
+
+	api.EXPECT().ContainerRemove(gomock.Any(), "123", moby.ContainerRemoveOptions{Force: true}).Return(nil)
+	api.EXPECT().ContainerRemove(gomock.Any(), "456", moby.ContainerRemoveOptions{Force: true}).Return(nil)
+	api.EXPECT().ContainerRemove(gomock.Any(), "789", moby.ContainerRemoveOptions{Force: true}).Return(nil)
+
+	err := tested.Down(context.Background(), strings.ToLower(testProject), compose.DownOptions{
+		Signal:       "SIGQUIT",
+		KeepNetworks: true,
+	})
+	assert.NilError(t, err)
+}
+
+func TestDownKeepNetworks(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	api := mocks.NewMockAPIClient(mockCtrl)
+	cli := mocks.NewMockCli(mockCtrl)
+	tested.dockerCli = cli
+	cli.EXPECT().Client().Return(api).AnyTimes()
+
+	api.EXPECT().ContainerList(gomock.Any(), projectFilterListOpt()).Return(
+		[]moby.Container{testContainer("service1", "123", false)}, nil)
+	api.EXPECT().VolumeList(gomock.Any(), filters.NewArgs(projectFilter(strings.ToLower(testProject)))).
+		Return(volume.VolumeListOKBody{}, nil)
+	api.EXPECT().NetworkList(gomock.Any(), moby.NetworkListOptions{Filters: filters.NewArgs(projectFilter(strings.ToLower(testProject)))}).
+		Return([]moby.NetworkResource{{Name: "myProject_default"}}, nil)
+
+	api.EXPECT().ContainerStop(gomock.Any(), "123", nil).Return(nil)
+	api.EXPECT().ContainerRemove(gomock.Any(), "123", moby.ContainerRemoveOptions{Force: true}).Return(nil)
+	// no NetworkRemove expected
+
+	err := tested.Down(context.Background(), strings.ToLower(testProject), compose.DownOptions{KeepNetworks: true})
+	assert.NilError(t, err)
+}
+
 func TestDownRemoveVolumes(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -142,3 +220,33 @@ func TestDownRemoveVolumes(t *testing.T) {
 	err := tested.Down(context.Background(), strings.ToLower(testProject), compose.DownOptions{Volumes: true})
 	assert.NilError(t, err)
 }
+
+func TestDownRemoveLocalImagesWithoutProject(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	api := mocks.NewMockAPIClient(mockCtrl)
+	cli := mocks.NewMockCli(mockCtrl)
+	tested.dockerCli = cli
+	cli.EXPECT().Client().Return(api).AnyTimes()
+
+	built := testContainer("service1", "123", false)
+	built.Image = strings.ToLower(testProject) + "_service1"
+	pulled := testContainer("service2", "456", false)
+	pulled.Image = "nginx"
+	api.EXPECT().ContainerList(gomock.Any(), projectFilterListOpt()).Return([]moby.Container{built, pulled}, nil)
+	api.EXPECT().VolumeList(gomock.Any(), filters.NewArgs(projectFilter(strings.ToLower(testProject)))).
+		Return(volume.VolumeListOKBody{}, nil)
+	api.EXPECT().NetworkList(gomock.Any(), moby.NetworkListOptions{Filters: filters.NewArgs(projectFilter(strings.ToLower(testProject)))}).
+		Return(nil, nil)
+
+	api.EXPECT().ContainerStop(gomock.Any(), "123", nil).Return(nil)
+	api.EXPECT().ContainerStop(gomock.Any(), "456", nil).Return(nil)
+	api.EXPECT().ContainerRemove(gomock.Any(), "123", moby.ContainerRemoveOptions{Force: true}).Return(nil)
+	api.EXPECT().ContainerRemove(gomock.Any(), "456", moby.ContainerRemoveOptions{Force: true}).Return(nil)
+
+	api.EXPECT().ImageRemove(gomock.Any(), strings.ToLower(testProject)+"_service1", moby.ImageRemoveOptions{}).Return(nil, nil)
+
+	err := tested.Down(context.Background(), strings.ToLower(testProject), compose.DownOptions{Images: "local"})
+	assert.NilError(t, err)
+}
@@ -0,0 +1,296 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/buildx/build"
+	"github.com/docker/compose/v2/pkg/progress"
+	moby "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	volume_api "github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/client"
+	specs "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// DryRun makes compose commands report the operations they would run, without changing anything on the engine
+var DryRun = false
+
+// dryRunIDPrefix prefixes the ID of resources only created in dry run mode
+const dryRunIDPrefix = "dryrun-"
+
+// dryRunState tracks the resources created and removed in dry run mode, so that later calls observe a consistent state
+type dryRunState struct {
+	mtx        sync.Mutex
+	containers map[string]*moby.ContainerJSON
+	removed    map[string]bool
+	images     map[string]bool
+}
+
+func newDryRunState() *dryRunState {
+	return &dryRunState{
+		containers: map[string]*moby.ContainerJSON{},
+		removed:    map[string]bool{},
+		images:     map[string]bool{},
+	}
+}
+
+// dryRunClient is an engine API client which runs read-only calls, and fakes the ones which would change the engine
+// state
+type dryRunClient struct {
+	client.APIClient
+	state *dryRunState
+}
+
+func (d *dryRunClient) ContainerCreate(ctx context.Context, config *container.Config, hostConfig *container.HostConfig,
+	networkingConfig *network.NetworkingConfig, platform *specs.Platform, name string) (container.ContainerCreateCreatedBody, error) {
+	id := dryRunIDPrefix + name
+	networks := map[string]*network.EndpointSettings{}
+	if networkingConfig != nil {
+		for name, endpoint := range networkingConfig.EndpointsConfig {
+			networks[name] = endpoint
+		}
+	}
+	d.state.mtx.Lock()
+	defer d.state.mtx.Unlock()
+	d.state.containers[id] = &moby.ContainerJSON{
+		ContainerJSONBase: &moby.ContainerJSONBase{
+			ID:         id,
+			Name:       "/" + name,
+			Image:      config.Image,
+			Created:    time.Now().Format(time.RFC3339Nano),
+			State:      &moby.ContainerState{Status: "created"},
+			HostConfig: hostConfig,
+		},
+		Config: config,
+		NetworkSettings: &moby.NetworkSettings{
+			Networks: networks,
+		},
+	}
+	return container.ContainerCreateCreatedBody{ID: id}, nil
+}
+
+func (d *dryRunClient) ContainerInspect(ctx context.Context, id string) (moby.ContainerJSON, error) {
+	d.state.mtx.Lock()
+	c, ok := d.state.containers[id]
+	d.state.mtx.Unlock()
+	if ok {
+		return *c, nil
+	}
+	return d.APIClient.ContainerInspect(ctx, id)
+}
+
+func (d *dryRunClient) ContainerList(ctx context.Context, options moby.ContainerListOptions) ([]moby.Container, error) {
+	containers, err := d.APIClient.ContainerList(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+	d.state.mtx.Lock()
+	defer d.state.mtx.Unlock()
+	var list []moby.Container
+	for _, c := range containers {
+		if !d.state.removed[c.ID] {
+			list = append(list, c)
+		}
+	}
+	for _, c := range d.state.containers {
+		if !options.All && !c.State.Running {
+			continue
+		}
+		if !matchLabels(c.Config.Labels, options.Filters.Get("label")) {
+			continue
+		}
+		list = append(list, moby.Container{
+			ID:     c.ID,
+			Names:  []string{c.Name},
+			Image:  c.Image,
+			Labels: c.Config.Labels,
+			State:  c.State.Status,
+			NetworkSettings: &moby.SummaryNetworkSettings{
+				Networks: c.NetworkSettings.Networks,
+			},
+		})
+	}
+	return list, nil
+}
+
+// matchLabels tells if labels match all the `key` or `key=value` filters
+func matchLabels(labels map[string]string, filters []string) bool {
+	for _, filter := range filters {
+		key, value, hasValue := strings.Cut(filter, "=")
+		v, ok := labels[key]
+		if !ok || hasValue && v != value {
+			return false
+		}
+	}
+	return true
+}
+
+func (d *dryRunClient) ContainerStart(ctx context.Context, id string, options moby.ContainerStartOptions) error {
+	d.state.mtx.Lock()
+	defer d.state.mtx.Unlock()
+	if c, ok := d.state.containers[id]; ok {
+		c.State = &moby.ContainerState{Status: "running", Running: true}
+		if c.Config.Healthcheck != nil && !healthcheckDisabled(c.Config.Healthcheck) {
+			// assume the plan succeeds
+			c.State.Health = &moby.Health{Status: moby.Healthy}
+		}
+	}
+	return nil
+}
+
+func (d *dryRunClient) ContainerStop(ctx context.Context, id string, timeout *time.Duration) error {
+	return nil
+}
+
+func (d *dryRunClient) ContainerKill(ctx context.Context, id string, signal string) error {
+	return nil
+}
+
+// ContainerWait reports the container as exited right away, as the calls which would have stopped it were faked
+func (d *dryRunClient) ContainerWait(ctx context.Context, id string, condition container.WaitCondition) (<-chan container.ContainerWaitOKBody, <-chan error) {
+	result := make(chan container.ContainerWaitOKBody, 1)
+	result <- container.ContainerWaitOKBody{}
+	return result, make(chan error)
+}
+
+func (d *dryRunClient) ContainerRestart(ctx context.Context, id string, timeout *time.Duration) error {
+	return nil
+}
+
+func (d *dryRunClient) ContainerPause(ctx context.Context, id string) error {
+	return nil
+}
+
+func (d *dryRunClient) ContainerUnpause(ctx context.Context, id string) error {
+	return nil
+}
+
+func (d *dryRunClient) ContainerRename(ctx context.Context, id string, name string) error {
+	return nil
+}
+
+func (d *dryRunClient) ContainerRemove(ctx context.Context, id string, options moby.ContainerRemoveOptions) error {
+	d.state.mtx.Lock()
+	defer d.state.mtx.Unlock()
+	delete(d.state.containers, id)
+	d.state.removed[id] = true
+	return nil
+}
+
+func (d *dryRunClient) ContainerExecCreate(ctx context.Context, id string, config moby.ExecConfig) (moby.IDResponse, error) {
+	return moby.IDResponse{ID: dryRunIDPrefix + id}, nil
+}
+
+func (d *dryRunClient) ContainerExecStart(ctx context.Context, execID string, config moby.ExecStartCheck) error {
+	return nil
+}
+
+func (d *dryRunClient) CopyToContainer(ctx context.Context, id, path string, content io.Reader, options moby.CopyToContainerOptions) error {
+	return nil
+}
+
+func (d *dryRunClient) NetworkCreate(ctx context.Context, name string, options moby.NetworkCreate) (moby.NetworkCreateResponse, error) {
+	return moby.NetworkCreateResponse{ID: dryRunIDPrefix + name}, nil
+}
+
+func (d *dryRunClient) NetworkRemove(ctx context.Context, id string) error {
+	return nil
+}
+
+func (d *dryRunClient) NetworkConnect(ctx context.Context, network, container string, config *network.EndpointSettings) error {
+	return nil
+}
+
+func (d *dryRunClient) NetworkDisconnect(ctx context.Context, network, container string, force bool) error {
+	return nil
+}
+
+func (d *dryRunClient) VolumeCreate(ctx context.Context, options volume_api.VolumeCreateBody) (moby.Volume, error) {
+	return moby.Volume{
+		Name:   options.Name,
+		Driver: options.Driver,
+		Labels: options.Labels,
+	}, nil
+}
+
+func (d *dryRunClient) VolumeRemove(ctx context.Context, id string, force bool) error {
+	return nil
+}
+
+func (d *dryRunClient) ImagePull(ctx context.Context, ref string, options moby.ImagePullOptions) (io.ReadCloser, error) {
+	d.state.mtx.Lock()
+	defer d.state.mtx.Unlock()
+	d.state.images[ref] = true
+	return ioutil.NopCloser(strings.NewReader("")), nil
+}
+
+func (d *dryRunClient) ImageInspectWithRaw(ctx context.Context, image string) (moby.ImageInspect, []byte, error) {
+	d.state.mtx.Lock()
+	pulled := d.state.images[image]
+	d.state.mtx.Unlock()
+	if pulled {
+		return moby.ImageInspect{ID: dryRunIDPrefix + image, Config: &container.Config{}}, nil, nil
+	}
+	return d.APIClient.ImageInspectWithRaw(ctx, image)
+}
+
+func (d *dryRunClient) ImagePush(ctx context.Context, ref string, options moby.ImagePushOptions) (io.ReadCloser, error) {
+	return ioutil.NopCloser(strings.NewReader("")), nil
+}
+
+func (d *dryRunClient) ImageRemove(ctx context.Context, image string, options moby.ImageRemoveOptions) ([]moby.ImageDeleteResponseItem, error) {
+	return nil, nil
+}
+
+// dryRunStart starts a one-off container in dry run mode, reporting it as started as there is no container to attach to
+func (s *composeService) dryRunStart(ctx context.Context, id string) error {
+	inspect, err := s.apiClient().ContainerInspect(ctx, id)
+	if err != nil {
+		return err
+	}
+	eventName := "Container " + strings.TrimPrefix(inspect.Name, "/")
+	w := progress.ContextWriter(ctx)
+	w.Event(progress.StartingEvent(eventName))
+	if err := s.apiClient().ContainerStart(ctx, id, moby.ContainerStartOptions{}); err != nil {
+		return err
+	}
+	w.Event(progress.StartedEvent(eventName))
+	return nil
+}
+
+// doBuildDryRun reports the images which would be built, without sending any build context to the engine
+func (s *composeService) doBuildDryRun(ctx context.Context, opts map[string]build.Options) map[string]string {
+	w := progress.ContextWriter(ctx)
+	digests := map[string]string{}
+	for name := range opts {
+		w.Event(progress.Event{
+			ID:     name,
+			Status: progress.Done,
+			Text:   "Built",
+		})
+		digests[name] = dryRunIDPrefix + name
+	}
+	return digests
+}
@@ -0,0 +1,90 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"testing"
+
+	moby "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/golang/mock/gomock"
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose/v2/pkg/api"
+	"github.com/docker/compose/v2/pkg/mocks"
+)
+
+func TestDryRunClient(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	apiClient := mocks.NewMockAPIClient(mockCtrl)
+	dryRun := &dryRunClient{APIClient: apiClient, state: newDryRunState()}
+	ctx := context.Background()
+
+	created, err := dryRun.ContainerCreate(ctx, &container.Config{
+		Image:  "nginx",
+		Labels: map[string]string{api.ProjectLabel: "test", api.ServiceLabel: "web"},
+	}, &container.HostConfig{}, nil, nil, "test-web-1")
+	assert.NilError(t, err)
+	assert.Equal(t, created.ID, "dryrun-test-web-1")
+
+	inspect, err := dryRun.ContainerInspect(ctx, created.ID)
+	assert.NilError(t, err)
+	assert.Equal(t, inspect.State.Status, "created")
+
+	assert.NilError(t, dryRun.ContainerStart(ctx, created.ID, moby.ContainerStartOptions{}))
+	inspect, err = dryRun.ContainerInspect(ctx, created.ID)
+	assert.NilError(t, err)
+	assert.Assert(t, inspect.State.Running)
+
+	options := moby.ContainerListOptions{
+		Filters: filters.NewArgs(projectFilter("test")),
+	}
+	apiClient.EXPECT().ContainerList(ctx, options).Return([]moby.Container{testContainer("db", "123", false)}, nil).Times(2)
+	containers, err := dryRun.ContainerList(ctx, options)
+	assert.NilError(t, err)
+	assert.Equal(t, len(containers), 2)
+
+	assert.NilError(t, dryRun.ContainerRemove(ctx, "123", moby.ContainerRemoveOptions{}))
+	containers, err = dryRun.ContainerList(ctx, options)
+	assert.NilError(t, err)
+	assert.Equal(t, len(containers), 1)
+	assert.Equal(t, containers[0].ID, created.ID)
+
+	waited, failed := dryRun.ContainerWait(ctx, created.ID, container.WaitConditionNotRunning)
+	select {
+	case <-waited:
+	case err := <-failed:
+		t.Fatal(err)
+	}
+
+	exec, err := dryRun.ContainerExecCreate(ctx, created.ID, moby.ExecConfig{Cmd: []string{"rm", "-rf", "/data"}})
+	assert.NilError(t, err)
+	assert.NilError(t, dryRun.ContainerExecStart(ctx, exec.ID, moby.ExecStartCheck{}))
+}
+
+func TestMatchLabels(t *testing.T) {
+	labels := map[string]string{api.ProjectLabel: "test", api.OneoffLabel: "False"}
+	assert.Assert(t, matchLabels(labels, nil))
+	assert.Assert(t, matchLabels(labels, []string{api.ProjectLabel}))
+	assert.Assert(t, matchLabels(labels, []string{api.ProjectLabel + "=test", api.OneoffLabel + "=False"}))
+	assert.Assert(t, !matchLabels(labels, []string{api.ProjectLabel + "=other"}))
+	assert.Assert(t, !matchLabels(labels, []string{api.ServiceLabel}))
+}
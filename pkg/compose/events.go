@@ -51,6 +51,9 @@ func (s *composeService) Events(ctx context.Context, projectName string, options
 			if len(options.Services) > 0 && !utils.StringContains(options.Services, service) {
 				continue
 			}
+			if len(options.Events) > 0 && !utils.StringContains(options.Events, eventType(event.Status)) {
+				continue
+			}
 
 			attributes := map[string]string{}
 			for k, v := range event.Actor.Attributes {
@@ -80,3 +83,8 @@ func (s *composeService) Events(ctx context.Context, projectName string, options
 		}
 	}
 }
+
+// eventType strips the details some events carry in their status, like `health_status: healthy`
+func eventType(status string) string {
+	return strings.TrimSpace(strings.SplitN(status, ":", 2)[0])
+}
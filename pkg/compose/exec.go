@@ -18,12 +18,21 @@ package compose
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/docker/cli/cli"
 	"github.com/docker/cli/cli/command/container"
 	"github.com/docker/compose/v2/pkg/api"
 	moby "github.com/docker/docker/api/types"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/pkg/ioutils"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 )
 
 func (s *composeService) Exec(ctx context.Context, projectName string, options api.RunOptions) (int, error) {
@@ -32,6 +41,11 @@ func (s *composeService) Exec(ctx context.Context, projectName string, options a
 	if err != nil {
 		return 0, err
 	}
+	if DryRun {
+		// only report the command, as running it could change the container state
+		fmt.Fprintf(s.stderr(), "Would run %q in container %s\n", strings.Join(options.Command, " "), getCanonicalContainerName(target)) // nolint:errcheck
+		return 0, nil
+	}
 
 	exec := container.NewExecOptions()
 	exec.Interactive = options.Interactive
@@ -50,12 +64,117 @@ func (s *composeService) Exec(ctx context.Context, projectName string, options a
 	}
 
 	err = container.RunExec(s.dockerCli, exec)
-	if sterr, ok := err.(cli.StatusError); ok {
+	if options.ReuseSession && errdefs.IsNotFound(err) {
+		// container was removed since it was resolved, resolve it again
+		s.execTargets.forget(projectName, options)
+		target, err = s.getExecTarget(ctx, projectName, options)
+		if err != nil {
+			return 0, err
+		}
+		exec.Container = target.ID
+		err = container.RunExec(s.dockerCli, exec)
+	}
+	var sterr cli.StatusError
+	if errors.As(err, &sterr) {
+		// exit status of the executed command, as reported by exec inspect
 		return sterr.StatusCode, nil
 	}
 	return 0, err
 }
 
 func (s *composeService) getExecTarget(ctx context.Context, projectName string, opts api.RunOptions) (moby.Container, error) {
-	return s.getSpecifiedContainer(ctx, projectName, oneOffInclude, false, opts.Service, opts.Index)
+	if !opts.ReuseSession {
+		return s.getSpecifiedContainer(ctx, projectName, oneOffInclude, false, opts.Service, opts.Index)
+	}
+	if target, ok := s.execTargets.get(projectName, opts); ok {
+		return target, nil
+	}
+	target, err := s.getSpecifiedContainer(ctx, projectName, oneOffInclude, false, opts.Service, opts.Index)
+	if err != nil {
+		return moby.Container{}, err
+	}
+	s.execTargets.set(projectName, opts, target)
+	return target, nil
+}
+
+// execTargetCache keeps the containers resolved by exec in a file per project, so that repeated execs against the
+// same service, each run by its own compose process, don't list containers again. A cache without directory keeps
+// nothing.
+type execTargetCache struct {
+	mtx sync.Mutex
+	dir string
+}
+
+// newExecTargetCache creates a cache in the docker-compose directory of the user cache
+func newExecTargetCache() *execTargetCache {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return &execTargetCache{}
+	}
+	return &execTargetCache{dir: filepath.Join(cacheDir, "docker-compose", "exec")}
+}
+
+func execTargetKey(opts api.RunOptions) string {
+	return fmt.Sprintf("%s/%d", opts.Service, opts.Index)
+}
+
+func (c *execTargetCache) path(projectName string) string {
+	return filepath.Join(c.dir, projectName+".json")
+}
+
+// load reads the targets resolved for a project, a missing or unreadable file being an empty cache
+func (c *execTargetCache) load(projectName string) map[string]moby.Container {
+	targets := map[string]moby.Container{}
+	if c.dir == "" {
+		return targets
+	}
+	content, err := os.ReadFile(c.path(projectName))
+	if err != nil {
+		return targets
+	}
+	if err := json.Unmarshal(content, &targets); err != nil {
+		logrus.Debugf("ignoring exec targets cache %s: %v", c.path(projectName), err)
+		return map[string]moby.Container{}
+	}
+	return targets
+}
+
+// save writes the targets resolved for a project, replacing the file so that concurrent execs never read it partially
+func (c *execTargetCache) save(projectName string, targets map[string]moby.Container) {
+	if c.dir == "" {
+		return
+	}
+	content, err := json.Marshal(targets)
+	if err == nil {
+		err = os.MkdirAll(c.dir, 0o700)
+	}
+	if err == nil {
+		err = ioutils.AtomicWriteFile(c.path(projectName), content, 0o600)
+	}
+	if err != nil {
+		logrus.Debugf("exec targets won't be reused: %v", err)
+	}
+}
+
+func (c *execTargetCache) get(projectName string, opts api.RunOptions) (moby.Container, bool) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	target, ok := c.load(projectName)[execTargetKey(opts)]
+	return target, ok
+}
+
+func (c *execTargetCache) set(projectName string, opts api.RunOptions, target moby.Container) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	targets := c.load(projectName)
+	targets[execTargetKey(opts)] = target
+	c.save(projectName, targets)
+}
+
+func (c *execTargetCache) forget(projectName string, opts api.RunOptions) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	targets := c.load(projectName)
+	delete(targets, execTargetKey(opts))
+	c.save(projectName, targets)
 }
@@ -0,0 +1,103 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"testing"
+
+	moby "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/golang/mock/gomock"
+	"gotest.tools/v3/assert"
+
+	compose "github.com/docker/compose/v2/pkg/api"
+	"github.com/docker/compose/v2/pkg/mocks"
+)
+
+func TestExecTargetSessionReuse(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	api := mocks.NewMockAPIClient(mockCtrl)
+	cli := mocks.NewMockCli(mockCtrl)
+	cli.EXPECT().Client().Return(api).AnyTimes()
+	dir := t.TempDir()
+	ctx := context.Background()
+	listOptions := func(index int) moby.ContainerListOptions {
+		return moby.ContainerListOptions{
+			Filters: filters.NewArgs(projectFilter("test"), serviceFilter("web"), containerNumberFilter(index)),
+		}
+	}
+	api.EXPECT().ContainerList(ctx, listOptions(1)).
+		Return([]moby.Container{testContainer("web", "123", false)}, nil).Times(1)
+	api.EXPECT().ContainerList(ctx, listOptions(2)).
+		Return([]moby.Container{testContainer("web", "456", false)}, nil).Times(1)
+
+	// each exec is run by its own compose process, sharing the cache directory
+	opts := compose.RunOptions{Service: "web", Index: 1, ReuseSession: true}
+	for i := 0; i < 3; i++ {
+		service := composeService{dockerCli: cli, execTargets: &execTargetCache{dir: dir}}
+		target, err := service.getExecTarget(ctx, "test", opts)
+		assert.NilError(t, err)
+		assert.Equal(t, target.ID, "123")
+	}
+
+	opts.Index = 2
+	service := composeService{dockerCli: cli, execTargets: &execTargetCache{dir: dir}}
+	target, err := service.getExecTarget(ctx, "test", opts)
+	assert.NilError(t, err)
+	assert.Equal(t, target.ID, "456")
+}
+
+func TestExecTargetWithoutSessionReuse(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	api := mocks.NewMockAPIClient(mockCtrl)
+	cli := mocks.NewMockCli(mockCtrl)
+	cli.EXPECT().Client().Return(api).AnyTimes()
+	service := composeService{
+		dockerCli:   cli,
+		execTargets: &execTargetCache{dir: t.TempDir()},
+	}
+
+	ctx := context.Background()
+	api.EXPECT().ContainerList(ctx, moby.ContainerListOptions{
+		Filters: filters.NewArgs(projectFilter("test"), serviceFilter("web"), containerNumberFilter(1)),
+	}).Return([]moby.Container{testContainer("web", "123", false)}, nil).Times(2)
+
+	opts := compose.RunOptions{Service: "web", Index: 1}
+	for i := 0; i < 2; i++ {
+		_, err := service.getExecTarget(ctx, "test", opts)
+		assert.NilError(t, err)
+	}
+}
+
+func TestExecTargetCacheForget(t *testing.T) {
+	cache := &execTargetCache{dir: t.TempDir()}
+	opts := compose.RunOptions{Service: "web", Index: 1}
+	cache.set("test", opts, moby.Container{ID: "123"})
+	cache.set("other", opts, moby.Container{ID: "456"})
+
+	cache.forget("test", opts)
+	_, ok := cache.get("test", opts)
+	assert.Assert(t, !ok)
+	target, ok := cache.get("other", opts)
+	assert.Assert(t, ok)
+	assert.Equal(t, target.ID, "456")
+}
@@ -35,3 +35,50 @@ func ServiceHash(o types.ServiceConfig) (string, error) {
 	}
 	return digest.SHA256.FromBytes(bytes).Encoded(), nil
 }
+
+// ServiceLabelsHash compute the hash of the labels declared by a service
+func ServiceLabelsHash(o types.ServiceConfig) (string, error) {
+	bytes, err := json.Marshal(o.Labels)
+	if err != nil {
+		return "", err
+	}
+	return digest.SHA256.FromBytes(bytes).Encoded(), nil
+}
+
+// ProjectServiceHashes computes the configuration hash of the project services, by name, as set on their containers
+// and compared by `up` to decide whether they must be recreated. The model is prepared the way containers are created
+// from it, built images being named after the project and dependencies implied by `volumes_from`, `links` or service
+// modes being added, without modifying the project.
+func ProjectServiceHashes(project *types.Project) (map[string]string, error) {
+	prepared := *project
+	prepared.Services = make(types.Services, len(project.Services))
+	for i, service := range project.Services {
+		if service.Build != nil {
+			service.Image = getImageName(service, project.Name)
+		}
+		dependsOn := make(types.DependsOnConfig, len(service.DependsOn))
+		for name, dependency := range service.DependsOn {
+			dependsOn[name] = dependency
+		}
+		if service.DependsOn != nil {
+			service.DependsOn = dependsOn
+		}
+		prepared.Services[i] = service
+	}
+	if err := prepareVolumes(&prepared); err != nil {
+		return nil, err
+	}
+	if err := prepareServicesDependsOn(&prepared); err != nil {
+		return nil, err
+	}
+
+	hashes := make(map[string]string, len(prepared.Services))
+	for _, service := range prepared.Services {
+		hash, err := ServiceHash(service)
+		if err != nil {
+			return nil, err
+		}
+		hashes[service.Name] = hash
+	}
+	return hashes, nil
+}
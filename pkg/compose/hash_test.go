@@ -0,0 +1,76 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"testing"
+
+	"github.com/compose-spec/compose-go/types"
+	"gotest.tools/v3/assert"
+)
+
+func TestProjectServiceHashes(t *testing.T) {
+	project := &types.Project{
+		Name: "test",
+		Services: types.Services{
+			{
+				Name:  "db",
+				Image: "postgres",
+			},
+			{
+				Name:        "web",
+				Build:       &types.BuildConfig{Context: "."},
+				VolumesFrom: []string{"db"},
+				DependsOn: types.DependsOnConfig{
+					"db": {Condition: types.ServiceConditionHealthy},
+				},
+			},
+			{
+				Name:        "sidecar",
+				Image:       "alpine",
+				NetworkMode: "service:web",
+			},
+		},
+	}
+	hashes, err := ProjectServiceHashes(project)
+	assert.NilError(t, err)
+
+	dbHash, err := ServiceHash(project.Services[0])
+	assert.NilError(t, err)
+	assert.Equal(t, hashes["db"], dbHash)
+
+	// hashes are computed from the services as containers are created from them
+	web := project.Services[1]
+	web.Image = "test_web"
+	web.VolumesFrom = []string{"container:test-db-1"}
+	web.DependsOn = types.DependsOnConfig{"db": {Condition: types.ServiceConditionStarted}}
+	webHash, err := ServiceHash(web)
+	assert.NilError(t, err)
+	assert.Equal(t, hashes["web"], webHash)
+
+	sidecar := project.Services[2]
+	sidecar.DependsOn = types.DependsOnConfig{"web": {Condition: types.ServiceConditionStarted}}
+	sidecarHash, err := ServiceHash(sidecar)
+	assert.NilError(t, err)
+	assert.Equal(t, hashes["sidecar"], sidecarHash)
+
+	// the project itself is left untouched
+	assert.Equal(t, project.Services[1].Image, "")
+	assert.DeepEqual(t, project.Services[1].VolumesFrom, []string{"db"})
+	assert.Equal(t, project.Services[1].DependsOn["db"].Condition, types.ServiceConditionHealthy)
+	assert.Assert(t, project.Services[2].DependsOn == nil)
+}
@@ -105,6 +105,7 @@ func (s *composeService) getImages(ctx context.Context, images []string) (map[st
 				ID:         inspect.ID,
 				Repository: repository,
 				Tag:        tag,
+				Digest:     repoDigest(repository, inspect.RepoDigests),
 				Size:       inspect.Size,
 			}
 			l.Unlock()
@@ -113,3 +114,22 @@ func (s *composeService) getImages(ctx context.Context, images []string) (map[st
 	}
 	return summary, eg.Wait()
 }
+
+// repoDigest returns the digest of the image in repository, or else the first one known, from `repository@digest`
+// references
+func repoDigest(repository string, repoDigests []string) string {
+	digest := ""
+	for _, ref := range repoDigests {
+		i := strings.LastIndex(ref, "@")
+		if i < 0 {
+			continue
+		}
+		if ref[:i] == repository {
+			return ref[i+1:]
+		}
+		if digest == "" {
+			digest = ref[i+1:]
+		}
+	}
+	return digest
+}
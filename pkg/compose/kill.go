@@ -21,17 +21,46 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/compose-spec/compose-go/types"
 	moby "github.com/docker/docker/api/types"
 	"golang.org/x/sync/errgroup"
 
 	"github.com/docker/compose/v2/pkg/api"
 	"github.com/docker/compose/v2/pkg/progress"
+	"github.com/docker/compose/v2/pkg/prompt"
 )
 
 func (s *composeService) Kill(ctx context.Context, projectName string, options api.KillOptions) error {
+	projectName = strings.ToLower(projectName)
+	if options.RemoveOrphans && options.Project != nil && !options.Force {
+		confirm, err := s.confirmKillOrphans(ctx, projectName, options)
+		if err != nil {
+			return err
+		}
+		if !confirm {
+			return nil
+		}
+	}
 	return progress.Run(ctx, func(ctx context.Context) error {
-		return s.kill(ctx, strings.ToLower(projectName), options)
+		return s.kill(ctx, projectName, options)
+	})
+}
+
+// confirmKillOrphans asks the user to confirm killing containers not belonging to the project's services
+func (s *composeService) confirmKillOrphans(ctx context.Context, projectName string, options api.KillOptions) (bool, error) {
+	containers, err := s.getContainers(ctx, projectName, oneOffInclude, false, options.Services...)
+	if err != nil {
+		return false, err
+	}
+	var names []string
+	containers.filter(isNotService(allServiceNames(options.Project)...)).forEach(func(c moby.Container) {
+		names = append(names, getCanonicalContainerName(c))
 	})
+	if len(names) == 0 {
+		return true, nil
+	}
+	msg := fmt.Sprintf("Going to kill %s, not defined in the Compose file", strings.Join(names, ", "))
+	return prompt.User{}.Confirm(msg, false)
 }
 
 func (s *composeService) kill(ctx context.Context, projectName string, options api.KillOptions) error {
@@ -45,6 +74,10 @@ func (s *composeService) kill(ctx context.Context, projectName string, options a
 		return err
 	}
 
+	if options.Project != nil && !options.RemoveOrphans {
+		containers = containers.filter(isService(allServiceNames(options.Project)...))
+	}
+
 	if len(containers) == 0 {
 		fmt.Fprintf(s.stderr(), "no container to kill")
 	}
@@ -66,3 +99,12 @@ func (s *composeService) kill(ctx context.Context, projectName string, options a
 		})
 	return eg.Wait()
 }
+
+// allServiceNames returns the names of all services defined in the project, including the ones disabled by profiles
+func allServiceNames(project *types.Project) []string {
+	var names []string
+	for _, service := range project.AllServices() {
+		names = append(names, service.Name)
+	}
+	return names
+}
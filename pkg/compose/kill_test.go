@@ -22,6 +22,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/compose-spec/compose-go/types"
 	moby "github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/filters"
 	"github.com/golang/mock/gomock"
@@ -59,6 +60,36 @@ func TestKillAll(t *testing.T) {
 	assert.NilError(t, err)
 }
 
+func TestKillIgnoresOrphans(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	api := mocks.NewMockAPIClient(mockCtrl)
+	cli := mocks.NewMockCli(mockCtrl)
+	tested.dockerCli = cli
+	cli.EXPECT().Client().Return(api).AnyTimes()
+
+	name := strings.ToLower(testProject)
+	project := &types.Project{
+		Name:     name,
+		Services: []types.ServiceConfig{{Name: "service1"}},
+	}
+
+	ctx := context.Background()
+	api.EXPECT().ContainerList(ctx, moby.ContainerListOptions{
+		Filters: filters.NewArgs(projectFilter(name)),
+	}).Return(
+		[]moby.Container{testContainer("service1", "123", false), testContainer("service_orphan", "789", false)}, nil).Times(2)
+	api.EXPECT().ContainerKill(anyCancellableContext(), "123", "").Return(nil).Times(2)
+	api.EXPECT().ContainerKill(anyCancellableContext(), "789", "").Return(nil)
+
+	err := tested.kill(ctx, name, compose.KillOptions{Project: project})
+	assert.NilError(t, err)
+
+	err = tested.kill(ctx, name, compose.KillOptions{Project: project, RemoveOrphans: true})
+	assert.NilError(t, err)
+}
+
 func TestKillSignal(t *testing.T) {
 	const serviceName = "service1"
 	mockCtrl := gomock.NewController(t)
@@ -18,8 +18,12 @@ package compose
 
 import (
 	"context"
+	"fmt"
 	"io"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/docker/compose/v2/pkg/api"
 	"github.com/docker/compose/v2/pkg/utils"
@@ -30,21 +34,62 @@ import (
 
 func (s *composeService) Logs(ctx context.Context, projectName string, consumer api.LogConsumer, options api.LogOptions) error {
 	projectName = strings.ToLower(projectName)
+	now := time.Now()
 	containers, err := s.getContainers(ctx, projectName, oneOffExclude, true, options.Services...)
 	if err != nil {
 		return err
 	}
+	if options.Index > 0 {
+		containers = containers.filter(isReplica(options.Index))
+		if len(containers) == 0 {
+			return fmt.Errorf("no container found with index %d", options.Index)
+		}
+	}
+
+	since := fmt.Sprintf("%d.%09d", now.Unix(), now.Nanosecond())
+	followOptions := options
+	if options.Follow && options.Tail == "0" && options.Since == "" {
+		// `--tail 0` means "from now on": containers (re)started while following must not replay their history,
+		// but must not miss lines logged before we attached either
+		followOptions.Tail = "all"
+		followOptions.Since = since
+	}
+
+	logOptions := options
+	if options.Merge {
+		err = s.logContainersMerged(ctx, consumer, containers, options, since)
+		if err != nil || !options.Follow {
+			return err
+		}
+		// history has already been printed, only stream new lines
+		followOptions.Tail = "all"
+		followOptions.Since = since
+		logOptions = followOptions
+	}
 
 	eg, ctx := errgroup.WithContext(ctx)
 	for _, c := range containers {
 		c := c
 		eg.Go(func() error {
-			return s.logContainers(ctx, consumer, c, options)
+			return s.logContainers(ctx, consumer, c, logOptions)
 		})
 	}
 
 	if options.Follow {
 		printer := newLogPrinter(consumer)
+		handleEvent := printer.HandleEvent
+		if options.Index > 0 {
+			// containers of other replicas are watched too, as they belong to the selected services
+			var selected []string
+			for _, c := range containers {
+				selected = append(selected, getContainerNameWithoutProject(c))
+			}
+			handleEvent = func(event api.ContainerEvent) {
+				if utils.StringContains(selected, event.Container) {
+					printer.HandleEvent(event)
+				}
+			}
+		}
 		eg.Go(func() error {
 			for _, c := range containers {
 				printer.HandleEvent(api.ContainerEvent{
@@ -57,13 +102,16 @@ func (s *composeService) Logs(ctx context.Context, projectName string, consumer
 		})
 
 		eg.Go(func() error {
-			return s.watchContainers(ctx, projectName, options.Services, printer.HandleEvent, containers, func(c types.Container) error {
+			return s.watchContainers(ctx, projectName, options.Services, handleEvent, containers, func(c types.Container) error {
+				if options.Index > 0 && !isReplica(options.Index)(c) {
+					return nil
+				}
 				printer.HandleEvent(api.ContainerEvent{
 					Type:      api.ContainerEventAttach,
 					Container: getContainerNameWithoutProject(c),
 					Service:   c.Labels[api.ServiceLabel],
 				})
-				return s.logContainers(ctx, consumer, c, options)
+				return s.logContainers(ctx, consumer, c, followOptions)
 			})
 		})
 
@@ -76,6 +124,76 @@ func (s *composeService) Logs(ctx context.Context, projectName string, consumer
 	return eg.Wait()
 }
 
+type logLine struct {
+	timestamp time.Time
+	container string
+	service   string
+	message   string
+}
+
+// logCollector is a LogConsumer collecting timestamped log lines
+type logCollector struct {
+	mutex sync.Mutex
+	lines []logLine
+}
+
+func (l *logCollector) Log(container, service, message string) {
+	var ts time.Time
+	if i := strings.IndexByte(message, ' '); i > 0 {
+		if t, err := time.Parse(time.RFC3339Nano, message[:i]); err == nil {
+			ts = t
+		}
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.lines = append(l.lines, logLine{
+		timestamp: ts,
+		container: container,
+		service:   service,
+		message:   message,
+	})
+}
+
+func (l *logCollector) Status(container, msg string) {}
+
+func (l *logCollector) Register(container string) {}
+
+// logContainersMerged prints logs of containers up to the given time, all lines being sorted by timestamp
+func (s *composeService) logContainersMerged(ctx context.Context, consumer api.LogConsumer, containers Containers, options api.LogOptions, until string) error {
+	historyOptions := options
+	historyOptions.Follow = false
+	historyOptions.Timestamps = true
+	if historyOptions.Until == "" {
+		historyOptions.Until = until
+	}
+
+	collector := &logCollector{}
+	eg, ctx := errgroup.WithContext(ctx)
+	for _, c := range containers {
+		c := c
+		eg.Go(func() error {
+			return s.logContainers(ctx, collector, c, historyOptions)
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+
+	sort.SliceStable(collector.lines, func(i, j int) bool {
+		return collector.lines[i].timestamp.Before(collector.lines[j].timestamp)
+	})
+	for _, line := range collector.lines {
+		message := line.message
+		if !options.Timestamps {
+			if i := strings.IndexByte(message, ' '); i > 0 && !line.timestamp.IsZero() {
+				message = message[i+1:]
+			}
+		}
+		consumer.Log(line.container, line.service, message)
+	}
+	return nil
+}
+
 func (s *composeService) logContainers(ctx context.Context, consumer api.LogConsumer, c types.Container, options api.LogOptions) error {
 	cnt, err := s.apiClient().ContainerInspect(ctx, c.ID)
 	if err != nil {
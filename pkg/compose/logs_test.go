@@ -0,0 +1,91 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/golang/mock/gomock"
+	"gotest.tools/v3/assert"
+
+	compose "github.com/docker/compose/v2/pkg/api"
+	"github.com/docker/compose/v2/pkg/mocks"
+)
+
+func TestLogsIndex(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	api := mocks.NewMockAPIClient(mockCtrl)
+	cli := mocks.NewMockCli(mockCtrl)
+	tested.dockerCli = cli
+	cli.EXPECT().Client().Return(api).AnyTimes()
+
+	ctx := context.Background()
+	projectName := strings.ToLower(testProject)
+	listOpts := types.ContainerListOptions{
+		Filters: filters.NewArgs(projectFilter(projectName), serviceFilter("web"), oneOffFilter(false)),
+		All:     true,
+	}
+
+	t.Run("selected replica only", func(t *testing.T) {
+		web2 := replicaContainer("web", 2, 0)
+		web2.Names = []string{"/web-2"}
+		api.EXPECT().ContainerList(ctx, listOpts).Return([]types.Container{
+			replicaContainer("web", 1, 0),
+			web2,
+		}, nil)
+		api.EXPECT().ContainerInspect(anyCancellableContext(), "web-2").Return(types.ContainerJSON{
+			ContainerJSONBase: &types.ContainerJSONBase{ID: "web-2"},
+			Config:            &container.Config{Tty: true},
+		}, nil)
+		api.EXPECT().ContainerLogs(anyCancellableContext(), "web-2", types.ContainerLogsOptions{
+			ShowStdout: true,
+			ShowStderr: true,
+			Tail:       "10",
+		}).Return(io.NopCloser(strings.NewReader("hello\n")), nil)
+
+		consumer := &logCollector{}
+		err := tested.Logs(ctx, projectName, consumer, compose.LogOptions{
+			Services: []string{"web"},
+			Tail:     "10",
+			Index:    2,
+		})
+		assert.NilError(t, err)
+		assert.Equal(t, len(consumer.lines), 1)
+		assert.Equal(t, consumer.lines[0].container, "web-2")
+		assert.Equal(t, consumer.lines[0].message, "hello")
+	})
+
+	t.Run("no such replica", func(t *testing.T) {
+		api.EXPECT().ContainerList(ctx, listOpts).Return([]types.Container{
+			replicaContainer("web", 1, 0),
+		}, nil)
+
+		err := tested.Logs(ctx, projectName, &logCollector{}, compose.LogOptions{
+			Services: []string{"web"},
+			Index:    2,
+		})
+		assert.Error(t, err, "no container found with index 2")
+	})
+}
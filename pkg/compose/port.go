@@ -19,6 +19,7 @@ package compose
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/docker/compose/v2/pkg/api"
@@ -29,24 +30,52 @@ import (
 
 func (s *composeService) Port(ctx context.Context, projectName string, service string, port int, options api.PortOptions) (string, int, error) {
 	projectName = strings.ToLower(projectName)
+	args := []filters.KeyValuePair{
+		projectFilter(projectName),
+		serviceFilter(service),
+	}
+	if options.Index > 0 {
+		args = append(args, containerNumberFilter(options.Index))
+	}
 	list, err := s.apiClient().ContainerList(ctx, moby.ContainerListOptions{
-		Filters: filters.NewArgs(
-			projectFilter(projectName),
-			serviceFilter(service),
-			containerNumberFilter(options.Index),
-		),
+		Filters: filters.NewArgs(args...),
 	})
 	if err != nil {
 		return "", 0, err
 	}
 	if len(list) == 0 {
-		return "", 0, fmt.Errorf("no container found for %s_%d", service, options.Index)
+		if options.Index > 0 {
+			return "", 0, fmt.Errorf("no container found for %s_%d", service, options.Index)
+		}
+		return "", 0, fmt.Errorf("no container found for %s", service)
+	}
+	container, number, err := lowestNumberedContainer(list)
+	if err != nil {
+		return "", 0, err
 	}
-	container := list[0]
 	for _, p := range container.Ports {
 		if p.PrivatePort == uint16(port) && p.Type == options.Protocol {
 			return p.IP, int(p.PublicPort), nil
 		}
 	}
-	return "", 0, err
+	return "", 0, fmt.Errorf("no port %d/%s published by %s_%d", port, options.Protocol, service, number)
+}
+
+// lowestNumberedContainer selects the replica with the lowest container number, so the port of a scaled service
+// doesn't depend on the order containers are listed by the engine
+func lowestNumberedContainer(containers []moby.Container) (moby.Container, int, error) {
+	var (
+		selected moby.Container
+		lowest   int
+	)
+	for _, c := range containers {
+		n, err := strconv.Atoi(c.Labels[api.ContainerNumberLabel])
+		if err != nil {
+			return moby.Container{}, 0, err
+		}
+		if lowest == 0 || n < lowest {
+			selected, lowest = c, n
+		}
+	}
+	return selected, lowest, nil
 }
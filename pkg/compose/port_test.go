@@ -0,0 +1,98 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+
+	moby "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/golang/mock/gomock"
+	"gotest.tools/v3/assert"
+
+	compose "github.com/docker/compose/v2/pkg/api"
+	"github.com/docker/compose/v2/pkg/mocks"
+)
+
+func replicaContainer(service string, number int, publicPort uint16) moby.Container {
+	c := testContainer(service, service+"-"+strconv.Itoa(number), false)
+	c.Labels[compose.ContainerNumberLabel] = strconv.Itoa(number)
+	if publicPort != 0 {
+		c.Ports = []moby.Port{{IP: "0.0.0.0", PrivatePort: 80, PublicPort: publicPort, Type: "tcp"}}
+	}
+	return c
+}
+
+func TestPort(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	api := mocks.NewMockAPIClient(mockCtrl)
+	cli := mocks.NewMockCli(mockCtrl)
+	tested.dockerCli = cli
+	cli.EXPECT().Client().Return(api).AnyTimes()
+
+	ctx := context.Background()
+	projectName := strings.ToLower(testProject)
+
+	t.Run("lowest-numbered replica by default", func(t *testing.T) {
+		listOpts := moby.ContainerListOptions{Filters: filters.NewArgs(projectFilter(projectName), serviceFilter("web"))}
+		api.EXPECT().ContainerList(ctx, listOpts).Return([]moby.Container{
+			replicaContainer("web", 3, 49155),
+			replicaContainer("web", 2, 49154),
+		}, nil)
+
+		ip, port, err := tested.Port(ctx, projectName, "web", 80, compose.PortOptions{Protocol: "tcp"})
+		assert.NilError(t, err)
+		assert.Equal(t, ip, "0.0.0.0")
+		assert.Equal(t, port, 49154)
+	})
+
+	t.Run("selected replica", func(t *testing.T) {
+		listOpts := moby.ContainerListOptions{
+			Filters: filters.NewArgs(projectFilter(projectName), serviceFilter("web"), containerNumberFilter(3)),
+		}
+		api.EXPECT().ContainerList(ctx, listOpts).Return([]moby.Container{replicaContainer("web", 3, 49155)}, nil)
+
+		_, port, err := tested.Port(ctx, projectName, "web", 80, compose.PortOptions{Protocol: "tcp", Index: 3})
+		assert.NilError(t, err)
+		assert.Equal(t, port, 49155)
+	})
+
+	t.Run("port not published", func(t *testing.T) {
+		listOpts := moby.ContainerListOptions{
+			Filters: filters.NewArgs(projectFilter(projectName), serviceFilter("web"), containerNumberFilter(2)),
+		}
+		api.EXPECT().ContainerList(ctx, listOpts).Return([]moby.Container{replicaContainer("web", 2, 0)}, nil)
+
+		_, _, err := tested.Port(ctx, projectName, "web", 80, compose.PortOptions{Protocol: "tcp", Index: 2})
+		assert.Error(t, err, "no port 80/tcp published by web_2")
+	})
+
+	t.Run("no such replica", func(t *testing.T) {
+		listOpts := moby.ContainerListOptions{
+			Filters: filters.NewArgs(projectFilter(projectName), serviceFilter("web"), containerNumberFilter(4)),
+		}
+		api.EXPECT().ContainerList(ctx, listOpts).Return(nil, nil)
+
+		_, _, err := tested.Port(ctx, projectName, "web", 80, compose.PortOptions{Protocol: "tcp", Index: 4})
+		assert.Error(t, err, "no container found for web_4")
+	})
+}
@@ -0,0 +1,235 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	moby "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	volume_api "github.com/docker/docker/api/types/volume"
+)
+
+type commandPrinterKey struct{}
+
+// commandPrinter prints the docker CLI commands equivalent to the engine API calls compose makes
+type commandPrinter struct {
+	mtx sync.Mutex
+	out io.Writer
+}
+
+// withCommandPrinter makes engine API calls made with the returned context be printed to out as docker CLI commands
+func withCommandPrinter(ctx context.Context, out io.Writer) context.Context {
+	return context.WithValue(ctx, commandPrinterKey{}, &commandPrinter{out: out})
+}
+
+// printCommand prints `docker <args>` if a command printer has been set on the context
+func printCommand(ctx context.Context, args ...string) {
+	p, ok := ctx.Value(commandPrinterKey{}).(*commandPrinter)
+	if !ok {
+		return
+	}
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = shellQuote(arg)
+	}
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	fmt.Fprintf(p.out, "docker %s\n", strings.Join(quoted, " ")) // nolint: errcheck
+}
+
+// shellQuote quotes arg for a POSIX shell, if it contains characters the shell would interpret
+func shellQuote(arg string) string {
+	if arg == "" {
+		return "''"
+	}
+	if strings.IndexFunc(arg, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_.,:/=@%+", r))
+	}) < 0 {
+		return arg
+	}
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
+
+// mapArgs returns a flag for each key=value entry of m, sorted by key
+func mapArgs(flag string, m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var args []string
+	for _, k := range keys {
+		args = append(args, flag, k+"="+m[k])
+	}
+	return args
+}
+
+func networkCreateArgs(name string, opts moby.NetworkCreate) []string {
+	args := []string{"network", "create"}
+	if opts.Driver != "" {
+		args = append(args, "--driver", opts.Driver)
+	}
+	args = append(args, mapArgs("--opt", opts.Options)...)
+	if opts.Internal {
+		args = append(args, "--internal")
+	}
+	if opts.Attachable {
+		args = append(args, "--attachable")
+	}
+	if opts.EnableIPv6 {
+		args = append(args, "--ipv6")
+	}
+	if opts.IPAM != nil {
+		if opts.IPAM.Driver != "" {
+			args = append(args, "--ipam-driver", opts.IPAM.Driver)
+		}
+		for _, config := range opts.IPAM.Config {
+			if config.Subnet != "" {
+				args = append(args, "--subnet", config.Subnet)
+			}
+			if config.IPRange != "" {
+				args = append(args, "--ip-range", config.IPRange)
+			}
+			if config.Gateway != "" {
+				args = append(args, "--gateway", config.Gateway)
+			}
+			args = append(args, mapArgs("--aux-address", config.AuxAddress)...)
+		}
+	}
+	args = append(args, mapArgs("--label", opts.Labels)...)
+	return append(args, name)
+}
+
+func volumeCreateArgs(opts volume_api.VolumeCreateBody) []string {
+	args := []string{"volume", "create"}
+	if opts.Driver != "" {
+		args = append(args, "--driver", opts.Driver)
+	}
+	args = append(args, mapArgs("--opt", opts.DriverOpts)...)
+	args = append(args, mapArgs("--label", opts.Labels)...)
+	return append(args, opts.Name)
+}
+
+func containerCreateArgs(name string, config *container.Config, hostConfig *container.HostConfig, networking *network.NetworkingConfig) []string {
+	args := []string{"container", "create", "--name", name}
+	if config.Hostname != "" {
+		args = append(args, "--hostname", config.Hostname)
+	}
+	if config.User != "" {
+		args = append(args, "--user", config.User)
+	}
+	if config.WorkingDir != "" {
+		args = append(args, "--workdir", config.WorkingDir)
+	}
+	if config.Tty {
+		args = append(args, "--tty")
+	}
+	if config.OpenStdin {
+		args = append(args, "--interactive")
+	}
+	for _, env := range config.Env {
+		args = append(args, "--env", env)
+	}
+	args = append(args, mapArgs("--label", config.Labels)...)
+	if len(config.Entrypoint) > 0 {
+		// --entrypoint only sets the executable, its arguments are passed before the command
+		args = append(args, "--entrypoint", config.Entrypoint[0])
+	}
+	if hostConfig != nil {
+		if hostConfig.NetworkMode != "" {
+			args = append(args, "--network", string(hostConfig.NetworkMode))
+		}
+		if hostConfig.RestartPolicy.Name != "" {
+			args = append(args, "--restart", hostConfig.RestartPolicy.Name)
+		}
+		if hostConfig.AutoRemove {
+			args = append(args, "--rm")
+		}
+		if hostConfig.Privileged {
+			args = append(args, "--privileged")
+		}
+		if hostConfig.ReadonlyRootfs {
+			args = append(args, "--read-only")
+		}
+		if hostConfig.Init != nil && *hostConfig.Init {
+			args = append(args, "--init")
+		}
+		for _, bind := range hostConfig.Binds {
+			args = append(args, "--volume", bind)
+		}
+		for _, m := range hostConfig.Mounts {
+			mount := fmt.Sprintf("type=%s,target=%s", m.Type, m.Target)
+			if m.Source != "" {
+				mount = fmt.Sprintf("type=%s,source=%s,target=%s", m.Type, m.Source, m.Target)
+			}
+			if m.ReadOnly {
+				mount += ",readonly"
+			}
+			args = append(args, "--mount", mount)
+		}
+		ports := make([]string, 0, len(hostConfig.PortBindings))
+		for port, bindings := range hostConfig.PortBindings {
+			for _, binding := range bindings {
+				published := binding.HostPort
+				if binding.HostIP != "" {
+					published = binding.HostIP + ":" + published
+				}
+				ports = append(ports, published+":"+string(port))
+			}
+		}
+		sort.Strings(ports)
+		for _, port := range ports {
+			args = append(args, "--publish", port)
+		}
+	}
+	if networking != nil {
+		for _, endpoint := range networking.EndpointsConfig {
+			for _, alias := range endpoint.Aliases {
+				args = append(args, "--network-alias", alias)
+			}
+		}
+	}
+	args = append(args, config.Image)
+	if len(config.Entrypoint) > 1 {
+		args = append(args, config.Entrypoint[1:]...)
+	}
+	return append(args, config.Cmd...)
+}
+
+func networkConnectArgs(netwrk string, id string, endpoint *network.EndpointSettings) []string {
+	args := []string{"network", "connect"}
+	for _, alias := range endpoint.Aliases {
+		args = append(args, "--alias", alias)
+	}
+	for _, link := range endpoint.Links {
+		args = append(args, "--link", link)
+	}
+	if endpoint.IPAddress != "" {
+		args = append(args, "--ip", endpoint.IPAddress)
+	}
+	if endpoint.GlobalIPv6Address != "" {
+		args = append(args, "--ip6", endpoint.GlobalIPv6Address)
+	}
+	return append(args, netwrk, id)
+}
@@ -0,0 +1,81 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	moby "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/go-connections/nat"
+	"gotest.tools/v3/assert"
+)
+
+func TestPrintCommand(t *testing.T) {
+	printCommand(context.Background(), "container", "start", "ignored")
+
+	var out bytes.Buffer
+	ctx := withCommandPrinter(context.Background(), &out)
+	printCommand(ctx, networkCreateArgs("myproject_default", moby.NetworkCreate{
+		Driver: "bridge",
+		Labels: map[string]string{
+			"com.docker.compose.project": "myproject",
+			"com.docker.compose.network": "default",
+		},
+	})...)
+	printCommand(ctx, containerCreateArgs("myproject-web-1",
+		&container.Config{
+			Image:  "nginx",
+			Env:    []string{"GREETING=hello world"},
+			Labels: map[string]string{"com.docker.compose.service": "web"},
+			Cmd:    []string{"nginx", "-g", "daemon off;"},
+		},
+		&container.HostConfig{
+			NetworkMode: "myproject_default",
+			PortBindings: nat.PortMap{
+				"80/tcp": []nat.PortBinding{{HostIP: "127.0.0.1", HostPort: "8080"}},
+			},
+		},
+		&network.NetworkingConfig{
+			EndpointsConfig: map[string]*network.EndpointSettings{
+				"myproject_default": {Aliases: []string{"web"}},
+			},
+		})...)
+	printCommand(ctx, "container", "start", "myproject-web-1")
+	printCommand(ctx, containerCreateArgs("myproject-job-1",
+		&container.Config{
+			Image:      "alpine",
+			Entrypoint: []string{"sh", "-c"},
+			Cmd:        []string{"echo $HOME"},
+		}, nil, nil)...)
+
+	assert.Equal(t, out.String(), `docker network create --driver bridge --label com.docker.compose.network=default --label com.docker.compose.project=myproject myproject_default
+docker container create --name myproject-web-1 --env 'GREETING=hello world' --label com.docker.compose.service=web --network myproject_default --publish 127.0.0.1:8080:80/tcp --network-alias web nginx nginx -g 'daemon off;'
+docker container start myproject-web-1
+docker container create --name myproject-job-1 --entrypoint sh alpine -c 'echo $HOME'
+`)
+}
+
+func TestShellQuote(t *testing.T) {
+	assert.Equal(t, shellQuote("com.docker.compose.project=demo"), "com.docker.compose.project=demo")
+	assert.Equal(t, shellQuote(""), "''")
+	assert.Equal(t, shellQuote("it's"), `'it'\''s'`)
+	assert.Equal(t, shellQuote("$HOME"), "'$HOME'")
+}
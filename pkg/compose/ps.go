@@ -55,6 +55,23 @@ func (s *composeService) Ps(ctx context.Context, projectName string, options api
 				})
 			}
 
+			var networks []string
+			if container.NetworkSettings != nil {
+				for name := range container.NetworkSettings.Networks {
+					networks = append(networks, name)
+				}
+				sort.Strings(networks)
+			}
+
+			var mounts []string
+			for _, m := range container.Mounts {
+				name := m.Name
+				if name == "" {
+					name = m.Source
+				}
+				mounts = append(mounts, name)
+			}
+
 			inspect, err := s.apiClient().ContainerInspect(ctx, container.ID)
 			if err != nil {
 				return err
@@ -78,13 +95,18 @@ func (s *composeService) Ps(ctx context.Context, projectName string, options api
 			summary[i] = api.ContainerSummary{
 				ID:         container.ID,
 				Name:       getCanonicalContainerName(container),
+				Image:      container.Image,
 				Project:    container.Labels[api.ProjectLabel],
 				Service:    container.Labels[api.ServiceLabel],
 				Command:    container.Command,
+				Created:    container.Created,
 				State:      container.State,
 				Health:     health,
 				ExitCode:   exitCode,
 				Publishers: publishers,
+				Networks:   networks,
+				Mounts:     mounts,
+				Labels:     container.Labels,
 			}
 			return nil
 		})
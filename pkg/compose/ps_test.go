@@ -26,6 +26,7 @@ import (
 
 	moby "github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/network"
 
 	compose "github.com/docker/compose/v2/pkg/api"
 	"github.com/docker/compose/v2/pkg/mocks"
@@ -45,9 +46,19 @@ func TestPs(t *testing.T) {
 	args.Add("label", "com.docker.compose.oneoff=False")
 	listOpts := moby.ContainerListOptions{Filters: args, All: true}
 	c1, inspect1 := containerDetails("service1", "123", "running", "healthy", 0)
+	c1.Image = "nginx"
+	c1.Created = 1650000000
+	c1.Mounts = []moby.MountPoint{
+		{Type: "volume", Name: "testproject_data", Source: "/var/lib/docker/volumes/testproject_data/_data"},
+		{Type: "bind", Source: "/src/static"},
+	}
 	c2, inspect2 := containerDetails("service1", "456", "running", "", 0)
 	c2.Ports = []moby.Port{{PublicPort: 80, PrivatePort: 90, IP: "localhost"}}
 	c3, inspect3 := containerDetails("service2", "789", "exited", "", 130)
+	c3.NetworkSettings = &moby.SummaryNetworkSettings{Networks: map[string]*network.EndpointSettings{
+		"testproject_default": {},
+		"testproject_backend": {},
+	}}
 	api.EXPECT().ContainerList(ctx, listOpts).Return([]moby.Container{c1, c2, c3}, nil)
 	api.EXPECT().ContainerInspect(anyCancellableContext(), "123").Return(inspect1, nil)
 	api.EXPECT().ContainerInspect(anyCancellableContext(), "456").Return(inspect2, nil)
@@ -56,10 +67,12 @@ func TestPs(t *testing.T) {
 	containers, err := tested.Ps(ctx, strings.ToLower(testProject), compose.PsOptions{})
 
 	expected := []compose.ContainerSummary{
-		{ID: "123", Name: "123", Project: strings.ToLower(testProject), Service: "service1", State: "running", Health: "healthy", Publishers: nil},
+		{ID: "123", Name: "123", Image: "nginx", Project: strings.ToLower(testProject), Service: "service1", Created: 1650000000, State: "running",
+			Health: "healthy", Publishers: nil, Mounts: []string{"testproject_data", "/src/static"}, Labels: c1.Labels},
 		{ID: "456", Name: "456", Project: strings.ToLower(testProject), Service: "service1", State: "running", Health: "", Publishers: []compose.PortPublisher{{URL: "localhost", TargetPort: 90,
-			PublishedPort: 80}}},
-		{ID: "789", Name: "789", Project: strings.ToLower(testProject), Service: "service2", State: "exited", Health: "", ExitCode: 130, Publishers: nil},
+			PublishedPort: 80}}, Labels: c2.Labels},
+		{ID: "789", Name: "789", Project: strings.ToLower(testProject), Service: "service2", State: "exited", Health: "", ExitCode: 130, Publishers: nil,
+			Networks: []string{"testproject_backend", "testproject_default"}, Labels: c3.Labels},
 	}
 	assert.NilError(t, err)
 	assert.DeepEqual(t, containers, expected)
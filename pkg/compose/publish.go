@@ -0,0 +1,208 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/cnabio/cnab-to-oci/remotes"
+	"github.com/compose-spec/compose-go/types"
+	"github.com/distribution/distribution/v3/reference"
+	"github.com/sirupsen/logrus"
+
+	"github.com/docker/compose/v2/pkg/api"
+	"github.com/docker/compose/v2/pkg/progress"
+	"github.com/docker/compose/v2/pkg/remote"
+)
+
+func (s *composeService) Publish(ctx context.Context, project *types.Project, repository string, options api.PublishOptions) error {
+	return progress.Run(ctx, func(ctx context.Context) error {
+		return s.publish(ctx, project, repository, options)
+	})
+}
+
+func (s *composeService) publish(ctx context.Context, project *types.Project, repository string, options api.PublishOptions) error {
+	named, err := remote.ParseReference(repository)
+	if err != nil {
+		return err
+	}
+	model, err := s.Convert(ctx, project, api.ConvertOptions{Format: "yaml"})
+	if err != nil {
+		return err
+	}
+	files, err := publishedFiles(project, model, options.EnvFile)
+	if err != nil {
+		return err
+	}
+
+	w := progress.ContextWriter(ctx)
+	id := reference.FamiliarString(named)
+	if DryRun {
+		return publishDryRun(w, id, files)
+	}
+	w.Event(progress.Event{
+		ID:     id,
+		Status: progress.Working,
+		Text:   "Publishing",
+	})
+	descriptor, err := remote.Push(ctx, remotes.CreateResolver(s.configFile()), named, files)
+	if err != nil {
+		w.Event(progress.ErrorEvent(id))
+		return err
+	}
+	w.Event(progress.Event{
+		ID:         id,
+		Status:     progress.Done,
+		Text:       "Published",
+		StatusText: descriptor.Digest.String(),
+	})
+	return nil
+}
+
+// publishDryRun reports the files and the manifest which would be pushed, without contacting the registry
+func publishDryRun(w progress.Writer, id string, files []remote.File) error {
+	artifact, err := remote.NewArtifact(files)
+	if err != nil {
+		return err
+	}
+	for i, file := range files {
+		w.Event(progress.Event{
+			ID:         file.Path,
+			ParentID:   id,
+			Status:     progress.Done,
+			Text:       "Would push",
+			StatusText: artifact.Layers[i].Digest.String(),
+		})
+	}
+	w.Event(progress.Event{
+		ID:         id,
+		Status:     progress.Done,
+		Text:       "Would publish",
+		StatusText: artifact.Manifest.Digest.String(),
+	})
+	return nil
+}
+
+// publishedFiles lists the files of a project to publish: its Compose file, the env file defining the defaults of its
+// variables, the files of its configs and the files bind mounted in its services. Secrets are never published. The
+// project is expected to be loaded without resolving paths, so that they remain relative to the project directory.
+func publishedFiles(project *types.Project, model []byte, envFile string) ([]remote.File, error) {
+	files := []remote.File{{
+		Path:      "compose.yaml",
+		MediaType: remote.ComposeYAMLMediaType,
+		Content:   model,
+	}}
+	if envFile != "" {
+		content, err := os.ReadFile(envFile)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, remote.File{
+			Path:      ".env",
+			MediaType: remote.ComposeEnvFileMediaType,
+			Content:   content,
+		})
+	}
+
+	seen := map[string]bool{}
+	addLocalFile := func(path string) error {
+		rel, err := projectRelativePath(project, path)
+		if err != nil {
+			return err
+		}
+		if seen[rel] {
+			return nil
+		}
+		seen[rel] = true
+		content, err := os.ReadFile(filepath.Join(project.WorkingDir, filepath.FromSlash(rel)))
+		if err != nil {
+			return err
+		}
+		files = append(files, remote.File{
+			Path:      rel,
+			MediaType: remote.ComposeLocalFileMediaType,
+			Content:   content,
+		})
+		return nil
+	}
+
+	var configs []types.ConfigObjConfig
+	for _, config := range project.Configs {
+		if config.File != "" && !config.External.External {
+			configs = append(configs, config)
+		}
+	}
+	sort.Slice(configs, func(i, j int) bool {
+		return configs[i].File < configs[j].File
+	})
+	for _, config := range configs {
+		if err := addLocalFile(config.File); err != nil {
+			return nil, err
+		}
+	}
+	for name, secret := range project.Secrets {
+		if secret.File != "" && !secret.External.External {
+			logrus.Warnf("secret %s is read from %s which is not published, it must be provided by the users of "+
+				"the published project", name, secret.File)
+		}
+	}
+	for _, service := range project.Services {
+		if service.Build != nil {
+			logrus.Warnf("service %s is built from a local context which is not published, push its image so that "+
+				"it can be pulled by the users of the published project", service.Name)
+		}
+		for _, volume := range service.Volumes {
+			if volume.Type != types.VolumeTypeBind {
+				continue
+			}
+			// bind mounts out of the project directory are host paths, expected on the hosts running the project
+			if _, err := projectRelativePath(project, volume.Source); err != nil {
+				continue
+			}
+			info, err := os.Stat(project.RelativePath(volume.Source))
+			if err != nil {
+				continue
+			}
+			if info.IsDir() {
+				logrus.Warnf("service %s bind mounts directory %s which is not published", service.Name, volume.Source)
+				continue
+			}
+			if err := addLocalFile(volume.Source); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return files, nil
+}
+
+// projectRelativePath returns the path of a file relative to the project directory, with forward slashes as in the
+// published project, failing if the file is out of that directory
+func projectRelativePath(project *types.Project, path string) (string, error) {
+	rel, err := filepath.Rel(project.WorkingDir, project.RelativePath(path))
+	if err != nil {
+		return "", err
+	}
+	if rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%s is out of the project directory %s and can't be published", path, project.WorkingDir)
+	}
+	return filepath.ToSlash(rel), nil
+}
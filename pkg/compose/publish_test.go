@@ -0,0 +1,78 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/compose-spec/compose-go/types"
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose/v2/pkg/remote"
+)
+
+func TestPublishedFiles(t *testing.T) {
+	dir := t.TempDir()
+	assert.NilError(t, os.WriteFile(filepath.Join(dir, ".env"), []byte("TAG=1.23\n"), 0o644))
+	assert.NilError(t, os.Mkdir(filepath.Join(dir, "conf"), 0o755))
+	assert.NilError(t, os.WriteFile(filepath.Join(dir, "conf", "nginx.conf"), []byte("server {}\n"), 0o644))
+	assert.NilError(t, os.WriteFile(filepath.Join(dir, "index.html"), []byte("hello\n"), 0o644))
+	assert.NilError(t, os.WriteFile(filepath.Join(dir, "password.txt"), []byte("secret\n"), 0o644))
+
+	project := &types.Project{
+		WorkingDir: dir,
+		Services: types.Services{
+			{
+				Name: "web",
+				Volumes: []types.ServiceVolumeConfig{
+					{Type: types.VolumeTypeBind, Source: "./index.html", Target: "/usr/share/nginx/html/index.html"},
+					{Type: types.VolumeTypeBind, Source: "./conf", Target: "/etc/nginx/conf.d"},
+					{Type: types.VolumeTypeBind, Source: "/var/run/docker.sock", Target: "/var/run/docker.sock"},
+					{Type: types.VolumeTypeVolume, Source: "data", Target: "/data"},
+				},
+			},
+		},
+		Configs: types.Configs{
+			"nginx": {File: "./conf/nginx.conf"},
+		},
+		Secrets: types.Secrets{
+			"password": {File: "./password.txt"},
+		},
+	}
+	files, err := publishedFiles(project, []byte("services: {}\n"), filepath.Join(dir, ".env"))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, files, []remote.File{
+		{Path: "compose.yaml", MediaType: remote.ComposeYAMLMediaType, Content: []byte("services: {}\n")},
+		{Path: ".env", MediaType: remote.ComposeEnvFileMediaType, Content: []byte("TAG=1.23\n")},
+		{Path: "conf/nginx.conf", MediaType: remote.ComposeLocalFileMediaType, Content: []byte("server {}\n")},
+		{Path: "index.html", MediaType: remote.ComposeLocalFileMediaType, Content: []byte("hello\n")},
+	})
+}
+
+func TestPublishedFilesOutOfProject(t *testing.T) {
+	dir := t.TempDir()
+	project := &types.Project{
+		WorkingDir: filepath.Join(dir, "project"),
+		Configs: types.Configs{
+			"shared": {File: "../shared.conf"},
+		},
+	}
+	_, err := publishedFiles(project, []byte("services: {}\n"), "")
+	assert.ErrorContains(t, err, "../shared.conf is out of the project directory")
+}
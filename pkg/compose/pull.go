@@ -184,14 +184,14 @@ func (s *composeService) pullServiceImage(ctx context.Context, service types.Ser
 		Text:   "Pulled",
 	})
 
-	inspected, _, err := s.dockerCli.Client().ImageInspectWithRaw(ctx, service.Image)
+	inspected, _, err := s.apiClient().ImageInspectWithRaw(ctx, service.Image)
 	if err != nil {
 		return "", err
 	}
 	return inspected.ID, nil
 }
 
-func (s *composeService) pullRequiredImages(ctx context.Context, project *types.Project, images map[string]string, quietPull bool) error {
+func (s *composeService) pullRequiredImages(ctx context.Context, project *types.Project, images map[string]string, quietPull bool, preferBuild bool) error {
 	info, err := s.apiClient().Info(ctx)
 	if err != nil {
 		return err
@@ -201,7 +201,7 @@ func (s *composeService) pullRequiredImages(ctx context.Context, project *types.
 		info.IndexServerAddress = registry.IndexServer
 	}
 
-	var needPull []types.ServiceConfig
+	var needPull, willBuild []types.ServiceConfig
 	for _, service := range project.Services {
 		if service.Image == "" {
 			continue
@@ -211,6 +211,10 @@ func (s *composeService) pullRequiredImages(ctx context.Context, project *types.
 			if _, ok := images[service.Image]; ok {
 				continue
 			}
+			if preferBuild && service.Build != nil {
+				willBuild = append(willBuild, service)
+				continue
+			}
 		case types.PullPolicyNever, types.PullPolicyBuild:
 			continue
 		case types.PullPolicyAlways:
@@ -218,12 +222,20 @@ func (s *composeService) pullRequiredImages(ctx context.Context, project *types.
 		}
 		needPull = append(needPull, service)
 	}
-	if len(needPull) == 0 {
+	if len(needPull) == 0 && len(willBuild) == 0 {
 		return nil
 	}
 
 	return progress.Run(ctx, func(ctx context.Context) error {
 		w := progress.ContextWriter(ctx)
+		for _, service := range willBuild {
+			w.Event(progress.Event{
+				ID:         service.Name,
+				Status:     progress.Done,
+				Text:       "Skipped",
+				StatusText: "Image can be built",
+			})
+		}
 		eg, ctx := errgroup.WithContext(ctx)
 		pulledImages := make([]string, len(needPull))
 		for i, service := range needPull {
@@ -238,15 +250,12 @@ func (s *composeService) pullRequiredImages(ctx context.Context, project *types.
 				return err
 			})
 		}
+		err := eg.Wait()
 		for i, service := range needPull {
 			if pulledImages[i] != "" {
 				images[service.Image] = pulledImages[i]
 			}
 		}
-		err := eg.Wait()
-		if err != nil {
-			return err
-		}
 		return err
 	})
 }
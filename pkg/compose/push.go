@@ -20,8 +20,8 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
-	"fmt"
 	"io"
+	"strings"
 
 	"github.com/compose-spec/compose-go/types"
 	"github.com/distribution/distribution/v3/reference"
@@ -53,8 +53,13 @@ func (s *composeService) push(ctx context.Context, project *types.Project, optio
 		info.IndexServerAddress = registry.IndexServer
 	}
 
+	services, err := project.GetServices(options.Services...)
+	if err != nil {
+		return err
+	}
+
 	w := progress.ContextWriter(ctx)
-	for _, service := range project.Services {
+	for _, service := range services {
 		if service.Build == nil || service.Image == "" {
 			w.Event(progress.Event{
 				ID:     service.Name,
@@ -65,13 +70,32 @@ func (s *composeService) push(ctx context.Context, project *types.Project, optio
 		}
 		service := service
 		eg.Go(func() error {
+			w.Event(progress.Event{
+				ID:         service.Name,
+				Status:     progress.Working,
+				Text:       "Pushing",
+				StatusText: service.Image,
+			})
 			err := s.pushServiceImage(ctx, service, info, s.configFile(), w)
 			if err != nil {
+				w.Event(progress.Event{
+					ID:         service.Name,
+					Status:     progress.Error,
+					Text:       "Error",
+					StatusText: service.Image,
+				})
 				if !options.IgnoreFailures {
 					return err
 				}
 				w.TailMsgf("Pushing %s: %s", service.Name, err.Error())
+				return nil
 			}
+			w.Event(progress.Event{
+				ID:         service.Name,
+				Status:     progress.Done,
+				Text:       "Pushed",
+				StatusText: service.Image,
+			})
 			return nil
 		})
 	}
@@ -126,7 +150,7 @@ func (s *composeService) pushServiceImage(ctx context.Context, service types.Ser
 	return nil
 }
 
-func toPushProgressEvent(prefix string, jm jsonmessage.JSONMessage, w progress.Writer) {
+func toPushProgressEvent(parent string, jm jsonmessage.JSONMessage, w progress.Writer) {
 	if jm.ID == "" {
 		// skipped
 		return
@@ -135,7 +159,7 @@ func toPushProgressEvent(prefix string, jm jsonmessage.JSONMessage, w progress.W
 		text   string
 		status = progress.Working
 	)
-	if jm.Status == "Pull complete" || jm.Status == "Already exists" {
+	if jm.Status == "Pushed" || jm.Status == "Layer already exists" || strings.HasPrefix(jm.Status, "Mounted from") {
 		status = progress.Done
 	}
 	if jm.Error != nil {
@@ -146,7 +170,8 @@ func toPushProgressEvent(prefix string, jm jsonmessage.JSONMessage, w progress.W
 		text = jm.Progress.String()
 	}
 	w.Event(progress.Event{
-		ID:         fmt.Sprintf("Pushing %s: %s", prefix, jm.ID),
+		ID:         jm.ID,
+		ParentID:   parent,
 		Text:       jm.Status,
 		Status:     status,
 		StatusText: text,
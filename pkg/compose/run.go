@@ -24,6 +24,10 @@ import (
 	"github.com/docker/cli/cli"
 	cmd "github.com/docker/cli/cli/command/container"
 	"github.com/docker/compose/v2/pkg/api"
+	"github.com/docker/compose/v2/pkg/progress"
+	"github.com/docker/compose/v2/pkg/utils"
+	moby "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/pkg/stringid"
 )
 
@@ -32,10 +36,16 @@ func (s *composeService) RunOneOffContainer(ctx context.Context, project *types.
 	if err != nil {
 		return 0, err
 	}
+	if DryRun {
+		return 0, progress.Run(ctx, func(ctx context.Context) error {
+			return s.dryRunStart(ctx, containerID)
+		})
+	}
 
 	start := cmd.NewStartOptions()
 	start.OpenStdin = !opts.Detach && opts.Interactive
 	start.Attach = !opts.Detach
+	start.DetachKeys = opts.DetachKeys
 	start.Containers = []string{containerID}
 
 	err = cmd.RunStart(s.dockerCli, &start)
@@ -56,6 +66,12 @@ func (s *composeService) prepareRun(ctx context.Context, project *types.Project,
 
 	applyRunOptions(project, &service, opts)
 
+	if opts.Network != "" {
+		if err := s.applyRunNetwork(ctx, project, &service, opts.Network); err != nil {
+			return "", err
+		}
+	}
+
 	if err := s.dockerCli.In().CheckTty(opts.Interactive, service.Tty); err != nil {
 		return "", err
 	}
@@ -73,7 +89,7 @@ func (s *composeService) prepareRun(ctx context.Context, project *types.Project,
 		Add(api.SlugLabel, slug).
 		Add(api.OneoffLabel, "True")
 
-	if err := s.ensureImagesExists(ctx, project, opts.QuietPull); err != nil { // all dependencies already checked, but might miss service img
+	if err := s.ensureImagesExists(ctx, project, opts.QuietPull, false, false); err != nil { // all dependencies already checked, but might miss service img
 		return "", err
 	}
 	if !opts.NoDeps {
@@ -86,6 +102,9 @@ func (s *composeService) prepareRun(ctx context.Context, project *types.Project,
 	if err != nil {
 		return "", err
 	}
+	if opts.Name != "" && utils.StringContains(observedState.names(), opts.Name) {
+		return "", fmt.Errorf("container name %q is already in use in project %q", opts.Name, project.Name)
+	}
 	updateServices(&service, observedState)
 
 	created, err := s.createContainer(ctx, project, service, service.ContainerName, 1,
@@ -128,3 +147,37 @@ func applyRunOptions(project *types.Project, service *types.ServiceConfig, opts
 		service.Labels = service.Labels.Add(k, v)
 	}
 }
+
+// applyRunNetwork makes the one-off container join the requested network instead of the service networks
+func (s *composeService) applyRunNetwork(ctx context.Context, project *types.Project, service *types.ServiceConfig, name string) error {
+	if _, ok := project.Networks[name]; ok {
+		service.NetworkMode = ""
+		service.Networks = map[string]*types.ServiceNetworkConfig{name: nil}
+		return nil
+	}
+	switch name {
+	case "host", "none":
+		// legacy links can't be used without a bridge network
+		service.Links = nil
+	case "bridge":
+	default:
+		networks, err := s.apiClient().NetworkList(ctx, moby.NetworkListOptions{
+			Filters: filters.NewArgs(filters.Arg("name", name)),
+		})
+		if err != nil {
+			return err
+		}
+		found := false
+		for _, n := range networks {
+			if n.Name == name {
+				found = true
+			}
+		}
+		if !found {
+			return fmt.Errorf("network %q not found. Should be a project network, an existing network, or one of host, none, bridge", name)
+		}
+	}
+	service.NetworkMode = name
+	service.Networks = nil
+	return nil
+}
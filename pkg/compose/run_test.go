@@ -0,0 +1,90 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"testing"
+
+	"github.com/compose-spec/compose-go/types"
+	moby "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/golang/mock/gomock"
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose/v2/pkg/mocks"
+)
+
+func TestApplyRunNetwork(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	apiClient := mocks.NewMockAPIClient(mockCtrl)
+	cli := mocks.NewMockCli(mockCtrl)
+	tested.dockerCli = cli
+	cli.EXPECT().Client().Return(apiClient).AnyTimes()
+
+	ctx := context.Background()
+	project := &types.Project{
+		Name: "test",
+		Networks: types.Networks{
+			"default": {Name: "test_default"},
+			"back":    {Name: "test_back"},
+		},
+	}
+	service := func() types.ServiceConfig {
+		return types.ServiceConfig{
+			Name:     "web",
+			Links:    []string{"db"},
+			Networks: map[string]*types.ServiceNetworkConfig{"default": nil},
+		}
+	}
+
+	t.Run("project network", func(t *testing.T) {
+		s := service()
+		assert.NilError(t, tested.applyRunNetwork(ctx, project, &s, "back"))
+		assert.Equal(t, s.NetworkMode, "")
+		assert.DeepEqual(t, s.Networks, map[string]*types.ServiceNetworkConfig{"back": nil})
+	})
+
+	t.Run("host mode", func(t *testing.T) {
+		s := service()
+		assert.NilError(t, tested.applyRunNetwork(ctx, project, &s, "host"))
+		assert.Equal(t, s.NetworkMode, "host")
+		assert.Equal(t, len(s.Networks), 0)
+		assert.Equal(t, len(s.Links), 0)
+	})
+
+	t.Run("existing network", func(t *testing.T) {
+		apiClient.EXPECT().NetworkList(ctx, moby.NetworkListOptions{
+			Filters: filters.NewArgs(filters.Arg("name", "shared")),
+		}).Return([]moby.NetworkResource{{Name: "shared-other"}, {Name: "shared"}}, nil)
+		s := service()
+		assert.NilError(t, tested.applyRunNetwork(ctx, project, &s, "shared"))
+		assert.Equal(t, s.NetworkMode, "shared")
+		assert.Equal(t, len(s.Networks), 0)
+	})
+
+	t.Run("unknown network", func(t *testing.T) {
+		apiClient.EXPECT().NetworkList(ctx, moby.NetworkListOptions{
+			Filters: filters.NewArgs(filters.Arg("name", "missing")),
+		}).Return([]moby.NetworkResource{{Name: "missing-other"}}, nil)
+		s := service()
+		err := tested.applyRunNetwork(ctx, project, &s, "missing")
+		assert.ErrorContains(t, err, `network "missing" not found`)
+	})
+}
@@ -18,6 +18,7 @@ package compose
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
 	"github.com/compose-spec/compose-go/types"
@@ -27,12 +28,15 @@ import (
 
 	"github.com/docker/compose/v2/pkg/api"
 	"github.com/docker/compose/v2/pkg/progress"
+	"github.com/docker/compose/v2/pkg/utils"
 )
 
 func (s *composeService) Start(ctx context.Context, projectName string, options api.StartOptions) error {
-	return progress.Run(ctx, func(ctx context.Context) error {
+	err := progress.Run(ctx, func(ctx context.Context) error {
 		return s.start(ctx, strings.ToLower(projectName), options, nil)
 	})
+	s.printWaitLogs(err)
+	return err
 }
 
 func (s *composeService) start(ctx context.Context, projectName string, options api.StartOptions, listener api.ContainerEventListener) error {
@@ -79,12 +83,27 @@ func (s *composeService) start(ctx context.Context, projectName string, options
 	if options.Wait {
 		depends := types.DependsOnConfig{}
 		for _, s := range project.Services {
+			if len(options.WaitServices) > 0 && !utils.StringContains(options.WaitServices, s.Name) {
+				continue
+			}
 			depends[s.Name] = types.ServiceDependency{
 				Condition: ServiceConditionRunningOrHealthy,
 			}
 		}
-		err = s.waitDependencies(ctx, project, depends)
+		waitCtx := ctx
+		if options.WaitTimeout > 0 {
+			var cancel context.CancelFunc
+			waitCtx, cancel = context.WithTimeout(ctx, options.WaitTimeout)
+			defer cancel()
+		}
+		err = s.waitDependencies(waitCtx, project, depends)
 		if err != nil {
+			if errors.Is(waitCtx.Err(), context.DeadlineExceeded) {
+				err = fmt.Errorf("timeout after %s waiting for services to be running|healthy", options.WaitTimeout)
+			}
+			if options.WaitLogLines > 0 {
+				return s.withWaitLogs(ctx, project, depends, options.WaitLogLines, err)
+			}
 			return err
 		}
 	}
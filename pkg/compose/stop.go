@@ -37,8 +37,11 @@ func (s *composeService) stop(ctx context.Context, projectName string, options a
 	if err != nil {
 		return err
 	}
+	if len(options.Labels) > 0 {
+		containers = containers.filter(hasLabels(options.Labels...))
+	}
 
 	return InReverseDependencyOrder(ctx, project, func(c context.Context, service string) error {
-		return s.stopContainers(ctx, w, containers.filter(isService(service)), options.Timeout)
+		return s.stopContainers(ctx, w, containers.filter(isService(service)), options.Timeout, "")
 	})
 }
@@ -57,3 +57,42 @@ func TestStopTimeout(t *testing.T) {
 	})
 	assert.NilError(t, err)
 }
+
+func TestStopLabels(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	api := mocks.NewMockAPIClient(mockCtrl)
+	cli := mocks.NewMockCli(mockCtrl)
+	tested.dockerCli = cli
+	cli.EXPECT().Client().Return(api).AnyTimes()
+
+	labelled := func(service string, id string, labels map[string]string) moby.Container {
+		c := testContainer(service, id, false)
+		for k, v := range labels {
+			c.Labels[k] = v
+		}
+		return c
+	}
+
+	ctx := context.Background()
+	api.EXPECT().ContainerList(gomock.Any(), projectFilterListOpt()).Return(
+		[]moby.Container{
+			labelled("web", "123", map[string]string{"tier": "frontend", "team": "a"}),
+			labelled("proxy", "456", map[string]string{"tier": "frontend"}),
+			labelled("db", "789", map[string]string{"tier": "backend", "team": "a"}),
+		}, nil).Times(2)
+
+	api.EXPECT().ContainerStop(gomock.Any(), "123", nil).Return(nil).Times(2)
+	api.EXPECT().ContainerStop(gomock.Any(), "456", nil).Return(nil)
+
+	err := tested.Stop(ctx, strings.ToLower(testProject), compose.StopOptions{
+		Labels: []string{"tier=frontend"},
+	})
+	assert.NilError(t, err)
+
+	err = tested.Stop(ctx, strings.ToLower(testProject), compose.StopOptions{
+		Labels: []string{"tier=frontend", "team"},
+	})
+	assert.NilError(t, err)
+}
@@ -24,28 +24,29 @@ import (
 	"golang.org/x/sync/errgroup"
 )
 
-func (s *composeService) Top(ctx context.Context, projectName string, services []string) ([]api.ContainerProcSummary, error) {
+func (s *composeService) Top(ctx context.Context, projectName string, options api.TopOptions) ([]api.ContainerProcSummary, error) {
 	projectName = strings.ToLower(projectName)
 	var containers Containers
 	containers, err := s.getContainers(ctx, projectName, oneOffInclude, false)
 	if err != nil {
 		return nil, err
 	}
-	if len(services) > 0 {
-		containers = containers.filter(isService(services...))
+	if len(options.Services) > 0 {
+		containers = containers.filter(isService(options.Services...))
 	}
 	summary := make([]api.ContainerProcSummary, len(containers))
 	eg, ctx := errgroup.WithContext(ctx)
 	for i, container := range containers {
 		i, container := i, container
 		eg.Go(func() error {
-			topContent, err := s.apiClient().ContainerTop(ctx, container.ID, []string{})
+			topContent, err := s.apiClient().ContainerTop(ctx, container.ID, options.PsArgs)
 			if err != nil {
 				return err
 			}
 			summary[i] = api.ContainerProcSummary{
 				ID:        container.ID,
 				Name:      getCanonicalContainerName(container),
+				Service:   container.Labels[api.ServiceLabel],
 				Processes: topContent.Processes,
 				Titles:    topContent.Titles,
 			}
@@ -28,21 +28,37 @@ import (
 
 	"github.com/compose-spec/compose-go/types"
 	"github.com/docker/cli/cli"
+	moby "github.com/docker/docker/api/types"
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
 	"golang.org/x/sync/errgroup"
 )
 
 func (s *composeService) Up(ctx context.Context, project *types.Project, options api.UpOptions) error {
-	err := progress.Run(ctx, func(ctx context.Context) error {
-		err := s.create(ctx, project, options.Create)
+	if options.Create.PrintCommands {
+		ctx = withCommandPrinter(ctx, s.stdout())
+	}
+	var previous Containers
+	if options.Rollback {
+		var err error
+		previous, err = s.getContainers(ctx, project.Name, oneOffExclude, true)
 		if err != nil {
 			return err
 		}
-		if options.Start.Attach == nil {
-			return s.start(ctx, project.Name, options.Start, nil)
+	}
+
+	err := progress.Run(ctx, func(ctx context.Context) error {
+		err := s.create(ctx, project, options.Create)
+		if err == nil && options.Start.Attach == nil {
+			err = s.start(ctx, project.Name, options.Start, nil)
 		}
-		return nil
+		if err != nil && options.Rollback {
+			return s.rollback(ctx, project.Name, previous, err)
+		}
+		return err
 	})
 	if err != nil {
+		s.printWaitLogs(err)
 		return err
 	}
 
@@ -67,6 +83,7 @@ func (s *composeService) Up(ctx context.Context, project *types.Project, options
 
 			return s.Stop(ctx, project.Name, api.StopOptions{
 				Services: project.ServiceNames(),
+				Timeout:  options.StopTimeout,
 			})
 		})
 	}
@@ -87,6 +104,12 @@ func (s *composeService) Up(ctx context.Context, project *types.Project, options
 
 	err = s.start(ctx, project.Name, options.Start, printer.HandleEvent)
 	if err != nil {
+		if options.Rollback {
+			printer.Cancel()
+			return progress.Run(context.Background(), func(ctx context.Context) error {
+				return s.rollback(ctx, project.Name, previous, err)
+			})
+		}
 		return err
 	}
 
@@ -100,3 +123,23 @@ func (s *composeService) Up(ctx context.Context, project *types.Project, options
 	}
 	return err
 }
+
+// rollback removes containers of services which had none when previous state was collected, then returns the error
+// which caused `up` to fail. Containers of other services are kept, as the ones they replaced are already removed
+func (s *composeService) rollback(ctx context.Context, projectName string, previous Containers, cause error) error {
+	existing := map[string]bool{}
+	for _, c := range previous {
+		existing[c.Labels[api.ServiceLabel]] = true
+	}
+	containers, err := s.getContainers(ctx, projectName, oneOffExclude, true)
+	if err == nil {
+		created := containers.filter(func(c moby.Container) bool {
+			return !existing[c.Labels[api.ServiceLabel]]
+		})
+		err = s.removeContainers(ctx, progress.ContextWriter(ctx), created, nil, "", true)
+	}
+	if err != nil {
+		return multierror.Append(cause, errors.Wrap(err, "rollback failed"))
+	}
+	return cause
+}
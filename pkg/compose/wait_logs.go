@@ -0,0 +1,96 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/compose-spec/compose-go/types"
+	"github.com/pkg/errors"
+
+	"github.com/docker/compose/v2/pkg/api"
+)
+
+// waitError is returned when services failed to get running|healthy, with the recent logs of these services
+type waitError struct {
+	cause error
+	logs  []byte
+}
+
+func (e *waitError) Error() string {
+	return e.cause.Error()
+}
+
+func (e *waitError) Unwrap() error {
+	return e.cause
+}
+
+// withWaitLogs collects the last lines of the logs of the waited services which are not running|healthy, to be printed
+// once progress is done
+func (s *composeService) withWaitLogs(ctx context.Context, project *types.Project, waited types.DependsOnConfig, lines int, cause error) error {
+	var failing []string
+	for name := range waited {
+		healthy, err := s.isServiceHealthy(ctx, project, name, true)
+		if err != nil || !healthy {
+			failing = append(failing, name)
+		}
+	}
+	if len(failing) == 0 {
+		return cause
+	}
+	sort.Strings(failing)
+
+	consumer := &bufferLogConsumer{}
+	err := s.Logs(ctx, project.Name, consumer, api.LogOptions{
+		Services: failing,
+		Tail:     strconv.Itoa(lines),
+	})
+	if err != nil {
+		// logs are only collected to diagnose the failure, don't hide it
+		return cause
+	}
+	return &waitError{cause: cause, logs: consumer.buffer.Bytes()}
+}
+
+// printWaitLogs prints the logs collected when waiting for services failed
+func (s *composeService) printWaitLogs(err error) {
+	var werr *waitError
+	if errors.As(err, &werr) && len(werr.logs) > 0 {
+		_, _ = s.stderr().Write(werr.logs)
+	}
+}
+
+// bufferLogConsumer collects log lines prefixed by the container name
+type bufferLogConsumer struct {
+	mtx    sync.Mutex
+	buffer bytes.Buffer
+}
+
+func (b *bufferLogConsumer) Log(container, service, message string) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	fmt.Fprintf(&b.buffer, "%s  | %s\n", container, message) // nolint:errcheck
+}
+
+func (b *bufferLogConsumer) Status(container, msg string) {}
+
+func (b *bufferLogConsumer) Register(container string) {}
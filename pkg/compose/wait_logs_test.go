@@ -0,0 +1,55 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose/v2/pkg/mocks"
+)
+
+func TestPrintWaitLogs(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	cli := mocks.NewMockCli(mockCtrl)
+	var stderr bytes.Buffer
+	cli.EXPECT().Err().Return(&stderr).AnyTimes()
+	service := composeService{dockerCli: cli}
+
+	consumer := &bufferLogConsumer{}
+	consumer.Log("web-1", "web", "starting")
+	consumer.Log("web-1", "web", "cannot connect to database")
+	cause := errors.New(`container for service "web" is unhealthy`)
+	err := &waitError{cause: cause, logs: consumer.buffer.Bytes()}
+	assert.Equal(t, err.Error(), cause.Error())
+
+	// logs are still found when the error was wrapped by a rollback
+	service.printWaitLogs(multierror.Append(err, errors.New("rollback failed")))
+	assert.Equal(t, stderr.String(), "web-1  | starting\nweb-1  | cannot connect to database\n")
+
+	stderr.Reset()
+	service.printWaitLogs(cause)
+	service.printWaitLogs(nil)
+	assert.Equal(t, stderr.String(), "")
+}
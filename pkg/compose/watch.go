@@ -0,0 +1,383 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/compose-spec/compose-go/types"
+	moby "github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/urlutil"
+	"github.com/pkg/errors"
+
+	"github.com/docker/compose/v2/pkg/api"
+	"github.com/docker/compose/v2/pkg/utils"
+)
+
+const (
+	extDevelop = "x-develop"
+
+	watchActionSync    = "sync"
+	watchActionRebuild = "rebuild"
+	watchActionRestart = "restart"
+
+	defaultWatchInterval = 500 * time.Millisecond
+)
+
+// developConfig is the content of the x-develop extension of a service
+type developConfig struct {
+	Watch []watchTrigger `json:"watch,omitempty"`
+}
+
+// watchTrigger binds a path on the host to the action to apply on the service when a file under it changes
+type watchTrigger struct {
+	Path   string `json:"path"`
+	Action string `json:"action"`
+	// Target is the path in the containers the files under Path are synced to
+	Target string `json:"target,omitempty"`
+
+	service string
+}
+
+// matches tells if file is the path of the trigger or belongs to it
+func (t watchTrigger) matches(file string) bool {
+	return file == t.Path || strings.HasPrefix(file, t.Path+string(filepath.Separator))
+}
+
+// syncedFile is a file to be copied to, or removed from, the containers of a service
+type syncedFile struct {
+	local   string
+	target  string
+	removed bool
+}
+
+// loadWatchTriggers returns the watch triggers declared by a service, its paths being resolved relative to the
+// project's working directory. A service with a build section and no x-develop.watch triggers gets rebuilt on any
+// change to its build context.
+func loadWatchTriggers(project *types.Project, service types.ServiceConfig) ([]watchTrigger, error) {
+	var config developConfig
+	if ext, ok := service.Extensions[extDevelop]; ok {
+		b, err := json.Marshal(ext)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(b, &config); err != nil {
+			return nil, errors.Wrapf(err, "invalid %s section for service %q", extDevelop, service.Name)
+		}
+	}
+	if len(config.Watch) == 0 && service.Build != nil {
+		buildContext := service.Build.Context
+		if urlutil.IsGitURL(buildContext) || urlutil.IsURL(buildContext) {
+			return nil, nil
+		}
+		config.Watch = append(config.Watch, watchTrigger{
+			Path:   buildContext,
+			Action: watchActionRebuild,
+		})
+	}
+
+	triggers := make([]watchTrigger, 0, len(config.Watch))
+	for _, trigger := range config.Watch {
+		if trigger.Path == "" {
+			return nil, fmt.Errorf("service %q: watch trigger requires a path", service.Name)
+		}
+		switch trigger.Action {
+		case watchActionSync:
+			if !isUnixAbs(trigger.Target) {
+				return nil, fmt.Errorf("service %q: sync of %q requires an absolute target path in the container", service.Name, trigger.Path)
+			}
+		case watchActionRebuild:
+			if service.Build == nil {
+				return nil, fmt.Errorf("service %q: cannot rebuild on changes to %q as the service has no build section", service.Name, trigger.Path)
+			}
+		case watchActionRestart:
+		default:
+			return nil, fmt.Errorf("service %q: invalid watch action %q, expected one of %s, %s or %s",
+				service.Name, trigger.Action, watchActionSync, watchActionRebuild, watchActionRestart)
+		}
+		if !filepath.IsAbs(trigger.Path) {
+			trigger.Path = filepath.Join(project.WorkingDir, trigger.Path)
+		}
+		trigger.Path = filepath.Clean(trigger.Path)
+		trigger.service = service.Name
+		triggers = append(triggers, trigger)
+	}
+	return triggers, nil
+}
+
+func (s *composeService) Watch(ctx context.Context, project *types.Project, services []string, options api.WatchOptions) error {
+	if len(services) == 0 {
+		services = project.ServiceNames()
+	}
+	if options.Interval <= 0 {
+		options.Interval = defaultWatchInterval
+	}
+
+	var (
+		triggers []watchTrigger
+		paths    []string
+	)
+	for _, name := range services {
+		service, err := project.GetService(name)
+		if err != nil {
+			return err
+		}
+		serviceTriggers, err := loadWatchTriggers(project, service)
+		if err != nil {
+			return err
+		}
+		for _, trigger := range serviceTriggers {
+			if !utils.StringContains(paths, trigger.Path) {
+				paths = append(paths, trigger.Path)
+			}
+		}
+		triggers = append(triggers, serviceTriggers...)
+	}
+	if len(triggers) == 0 {
+		return fmt.Errorf("none of the selected services is configured for watch, add a build section or %s.watch triggers", extDevelop)
+	}
+
+	if options.NoUp {
+		containers, err := s.getContainers(ctx, project.Name, oneOffExclude, false, services...)
+		if err != nil {
+			return err
+		}
+		if len(containers) == 0 {
+			return fmt.Errorf("no running container found for project %q, run watch without --no-up to start it", project.Name)
+		}
+	} else {
+		err := s.Up(ctx, project, api.UpOptions{
+			Create: api.CreateOptions{
+				Services:             services,
+				Recreate:             api.RecreateDiverged,
+				RecreateDependencies: api.RecreateDiverged,
+				Inherit:              true,
+			},
+			Start: api.StartOptions{
+				Project: project,
+			},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, trigger := range triggers {
+		fmt.Fprintf(s.stderr(), "Watching %s to %s service %s\n", trigger.Path, trigger.Action, trigger.service)
+	}
+	watcher := newFileWatcher(options.Interval, paths...)
+	return watcher.Watch(ctx, func(changes fileChanges) error {
+		s.applyWatchTriggers(ctx, project, triggers, changes)
+		return nil
+	})
+}
+
+// applyWatchTriggers runs, for each service impacted by the changes, the most disruptive action among the matching
+// triggers: a rebuild supersedes a restart, which supersedes a sync. Failures are reported but don't stop watching, as
+// the next change is likely to fix them.
+func (s *composeService) applyWatchTriggers(ctx context.Context, project *types.Project, triggers []watchTrigger, changes fileChanges) {
+	actions := map[string]string{}
+	synced := map[string][]syncedFile{}
+	var services []string
+	for _, file := range changes.all() {
+		for _, trigger := range triggers {
+			if !trigger.matches(file) {
+				continue
+			}
+			if _, ok := actions[trigger.service]; !ok {
+				services = append(services, trigger.service)
+			}
+			switch {
+			case trigger.Action == watchActionRebuild:
+				actions[trigger.service] = watchActionRebuild
+			case trigger.Action == watchActionRestart && actions[trigger.service] != watchActionRebuild:
+				actions[trigger.service] = watchActionRestart
+			case actions[trigger.service] == "":
+				actions[trigger.service] = watchActionSync
+			}
+			if trigger.Action == watchActionSync {
+				synced[trigger.service] = append(synced[trigger.service], syncTarget(trigger, file, utils.StringContains(changes.removed, file)))
+			}
+		}
+	}
+
+	for _, service := range services {
+		var err error
+		switch actions[service] {
+		case watchActionRebuild:
+			fmt.Fprintf(s.stderr(), "Rebuilding service %s after changes\n", service)
+			err = s.rebuildService(ctx, project, service)
+		case watchActionRestart:
+			fmt.Fprintf(s.stderr(), "Restarting service %s after changes\n", service)
+			err = s.Restart(ctx, project.Name, api.RestartOptions{Services: []string{service}})
+		case watchActionSync:
+			fmt.Fprintf(s.stderr(), "Syncing %d file(s) to service %s\n", len(synced[service]), service)
+			err = s.syncFiles(ctx, project, service, synced[service])
+		}
+		if err != nil {
+			fmt.Fprintf(s.stderr(), "Failed to %s service %s: %v\n", actions[service], service, err)
+		}
+	}
+}
+
+// syncTarget maps a changed file to its path in the containers, according to the sync trigger it matched
+func syncTarget(trigger watchTrigger, file string, removed bool) syncedFile {
+	target := trigger.Target
+	if rel, err := filepath.Rel(trigger.Path, file); err == nil && rel != "." {
+		target = path.Join(target, filepath.ToSlash(rel))
+	}
+	return syncedFile{
+		local:   file,
+		target:  target,
+		removed: removed,
+	}
+}
+
+// rebuildService builds the image of the service, then recreates its containers if the image changed. Up is run on a
+// copy of the project restricted to the service, so other services of the project are left as they are.
+func (s *composeService) rebuildService(ctx context.Context, project *types.Project, service string) error {
+	err := s.Build(ctx, project, api.BuildOptions{
+		Services: []string{service},
+	})
+	if err != nil {
+		return err
+	}
+	config, err := project.GetService(service)
+	if err != nil {
+		return err
+	}
+	rebuilt := *project
+	rebuilt.Services = types.Services{config}
+	return s.Up(ctx, &rebuilt, api.UpOptions{
+		Create: api.CreateOptions{
+			Services:             []string{service},
+			Recreate:             api.RecreateDiverged,
+			RecreateDependencies: api.RecreateNever,
+			IgnoreOrphans:        true,
+		},
+		Start: api.StartOptions{
+			Project: &rebuilt,
+		},
+	})
+}
+
+// syncFiles copies the changed files into the running containers of the service, and removes the deleted ones
+func (s *composeService) syncFiles(ctx context.Context, project *types.Project, service string, files []syncedFile) error {
+	containers, err := s.getContainers(ctx, project.Name, oneOffExclude, false, service)
+	if err != nil {
+		return err
+	}
+	if len(containers) == 0 {
+		return fmt.Errorf("no running container found for service %q", service)
+	}
+
+	var removed []string
+	for _, file := range files {
+		if file.removed {
+			removed = append(removed, file.target)
+		}
+	}
+	for _, container := range containers {
+		content, err := createSyncTar(files)
+		if err != nil {
+			return err
+		}
+		if content.Len() > 0 {
+			err = s.apiClient().CopyToContainer(ctx, container.ID, "/", content, moby.CopyToContainerOptions{})
+			if err != nil {
+				return err
+			}
+		}
+		if len(removed) > 0 {
+			exec, err := s.apiClient().ContainerExecCreate(ctx, container.ID, moby.ExecConfig{
+				Cmd: append([]string{"rm", "-rf", "--"}, removed...),
+			})
+			if err != nil {
+				return err
+			}
+			err = s.apiClient().ContainerExecStart(ctx, exec.ID, moby.ExecStartCheck{})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// createSyncTar archives the files which haven't been removed, each entry being named after its path in the
+// container so that the archive can be extracted at the root of the container file system
+func createSyncTar(files []syncedFile) (*bytes.Buffer, error) {
+	b := &bytes.Buffer{}
+	tarWriter := tar.NewWriter(b)
+	written := 0
+	for _, file := range files {
+		if file.removed {
+			continue
+		}
+		ok, err := addSyncedFile(tarWriter, file)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			written++
+		}
+	}
+	if written == 0 {
+		return &bytes.Buffer{}, nil
+	}
+	if err := tarWriter.Close(); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// addSyncedFile writes file to the archive, ignoring it if it has been removed since it was detected as changed
+func addSyncedFile(tarWriter *tar.Writer, file syncedFile) (bool, error) {
+	f, err := os.Open(file.local)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	defer f.Close() //nolint:errcheck
+
+	info, err := f.Stat()
+	if err != nil {
+		return false, err
+	}
+	header, err := tar.FileInfoHeader(info, "")
+	if err != nil {
+		return false, err
+	}
+	header.Name = file.target
+	if err := tarWriter.WriteHeader(header); err != nil {
+		return false, err
+	}
+	_, err = io.Copy(tarWriter, f)
+	return err == nil, err
+}
@@ -0,0 +1,233 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"archive/tar"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/compose-spec/compose-go/types"
+	moby "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/golang/mock/gomock"
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose/v2/pkg/api"
+	"github.com/docker/compose/v2/pkg/mocks"
+)
+
+func TestLoadWatchTriggers(t *testing.T) {
+	project := &types.Project{WorkingDir: "/src"}
+
+	t.Run("explicit triggers", func(t *testing.T) {
+		service := types.ServiceConfig{
+			Name:  "web",
+			Build: &types.BuildConfig{Context: "/src/web"},
+			Extensions: map[string]interface{}{
+				extDevelop: map[string]interface{}{
+					"watch": []interface{}{
+						map[string]interface{}{"action": "sync", "path": "./web/static", "target": "/app/static"},
+						map[string]interface{}{"action": "rebuild", "path": "/src/web/package.json"},
+						map[string]interface{}{"action": "restart", "path": "config"},
+					},
+				},
+			},
+		}
+		triggers, err := loadWatchTriggers(project, service)
+		assert.NilError(t, err)
+		assertWatchTriggers(t, triggers, []watchTrigger{
+			{Path: "/src/web/static", Action: watchActionSync, Target: "/app/static", service: "web"},
+			{Path: "/src/web/package.json", Action: watchActionRebuild, service: "web"},
+			{Path: "/src/config", Action: watchActionRestart, service: "web"},
+		})
+	})
+
+	t.Run("build context is rebuilt by default", func(t *testing.T) {
+		service := types.ServiceConfig{
+			Name:  "web",
+			Build: &types.BuildConfig{Context: "/src/web"},
+		}
+		triggers, err := loadWatchTriggers(project, service)
+		assert.NilError(t, err)
+		assertWatchTriggers(t, triggers, []watchTrigger{
+			{Path: "/src/web", Action: watchActionRebuild, service: "web"},
+		})
+	})
+
+	t.Run("remote build context is not watched", func(t *testing.T) {
+		service := types.ServiceConfig{
+			Name:  "web",
+			Build: &types.BuildConfig{Context: "https://github.com/docker/compose.git"},
+		}
+		triggers, err := loadWatchTriggers(project, service)
+		assert.NilError(t, err)
+		assert.Equal(t, len(triggers), 0)
+	})
+
+	t.Run("invalid triggers", func(t *testing.T) {
+		for _, trigger := range []map[string]interface{}{
+			{"action": "sync", "path": "static"},
+			{"action": "sync", "path": "static", "target": "app/static"},
+			{"action": "rebuild", "path": "static"},
+			{"action": "reload", "path": "static"},
+			{"action": "restart"},
+		} {
+			service := types.ServiceConfig{
+				Name:  "web",
+				Image: "nginx",
+				Extensions: map[string]interface{}{
+					extDevelop: map[string]interface{}{"watch": []interface{}{trigger}},
+				},
+			}
+			_, err := loadWatchTriggers(project, service)
+			assert.Check(t, err != nil, "%v", trigger)
+		}
+	})
+}
+
+func assertWatchTriggers(t *testing.T, actual, expected []watchTrigger) {
+	t.Helper()
+	assert.Equal(t, len(actual), len(expected))
+	for i := range expected {
+		assert.Equal(t, actual[i], expected[i])
+	}
+}
+
+func TestWatchTriggerMatches(t *testing.T) {
+	trigger := watchTrigger{Path: "/src/web"}
+	assert.Check(t, trigger.matches("/src/web"))
+	assert.Check(t, trigger.matches("/src/web/index.html"))
+	assert.Check(t, !trigger.matches("/src/website/index.html"))
+	assert.Check(t, !trigger.matches("/src"))
+}
+
+func TestSyncTarget(t *testing.T) {
+	trigger := watchTrigger{Path: filepath.FromSlash("/src/static"), Target: "/app/static"}
+	file := syncTarget(trigger, filepath.FromSlash("/src/static/css/main.css"), false)
+	assert.Equal(t, file.local, filepath.FromSlash("/src/static/css/main.css"))
+	assert.Equal(t, file.target, "/app/static/css/main.css")
+	assert.Check(t, !file.removed)
+
+	trigger = watchTrigger{Path: filepath.FromSlash("/src/nginx.conf"), Target: "/etc/nginx/nginx.conf"}
+	file = syncTarget(trigger, filepath.FromSlash("/src/nginx.conf"), true)
+	assert.Equal(t, file.local, filepath.FromSlash("/src/nginx.conf"))
+	assert.Equal(t, file.target, "/etc/nginx/nginx.conf")
+	assert.Check(t, file.removed)
+}
+
+func TestCreateSyncTar(t *testing.T) {
+	dir := t.TempDir()
+	local := filepath.Join(dir, "index.html")
+	assert.NilError(t, os.WriteFile(local, []byte("hello"), 0o644))
+
+	b, err := createSyncTar([]syncedFile{
+		{local: local, target: "/usr/share/nginx/html/index.html"},
+		{local: filepath.Join(dir, "gone.html"), target: "/usr/share/nginx/html/gone.html"},
+		{local: filepath.Join(dir, "old.html"), target: "/usr/share/nginx/html/old.html", removed: true},
+	})
+	assert.NilError(t, err)
+
+	reader := tar.NewReader(b)
+	header, err := reader.Next()
+	assert.NilError(t, err)
+	assert.Equal(t, header.Name, "/usr/share/nginx/html/index.html")
+	content, err := io.ReadAll(reader)
+	assert.NilError(t, err)
+	assert.Equal(t, string(content), "hello")
+	_, err = reader.Next()
+	assert.Equal(t, err, io.EOF)
+
+	b, err = createSyncTar([]syncedFile{
+		{local: filepath.Join(dir, "old.html"), target: "/usr/share/nginx/html/old.html", removed: true},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, b.Len(), 0)
+}
+
+func TestFileWatcherChanges(t *testing.T) {
+	dir := t.TempDir()
+	kept := filepath.Join(dir, "kept.txt")
+	modified := filepath.Join(dir, "modified.txt")
+	removed := filepath.Join(dir, "removed.txt")
+	added := filepath.Join(dir, "sub", "added.txt")
+	for _, file := range []string{kept, modified, removed} {
+		assert.NilError(t, os.WriteFile(file, []byte("content"), 0o644))
+	}
+	assert.NilError(t, os.MkdirAll(filepath.Join(dir, ".git"), 0o755))
+
+	watcher := newFileWatcher(time.Second, dir, filepath.Join(dir, "missing"))
+	changes, err := watcher.changes()
+	assert.NilError(t, err)
+	assert.Check(t, changes.empty())
+
+	assert.NilError(t, os.WriteFile(modified, []byte("modified content"), 0o644))
+	assert.NilError(t, os.Remove(removed))
+	assert.NilError(t, os.MkdirAll(filepath.Dir(added), 0o755))
+	assert.NilError(t, os.WriteFile(added, []byte("content"), 0o644))
+	assert.NilError(t, os.WriteFile(filepath.Join(dir, ".git", "index"), []byte("content"), 0o644))
+
+	changes, err = watcher.changes()
+	assert.NilError(t, err)
+	assert.DeepEqual(t, changes.added, []string{added})
+	assert.DeepEqual(t, changes.modified, []string{modified})
+	assert.DeepEqual(t, changes.removed, []string{removed})
+	assert.DeepEqual(t, changes.all(), []string{modified, removed, added})
+
+	changes, err = watcher.changes()
+	assert.NilError(t, err)
+	assert.Check(t, changes.empty())
+}
+
+func TestWatchNoUpWithoutContainers(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	apiClient := mocks.NewMockAPIClient(mockCtrl)
+	cli := mocks.NewMockCli(mockCtrl)
+	cli.EXPECT().Client().Return(apiClient).AnyTimes()
+	service := composeService{dockerCli: cli}
+
+	project := &types.Project{
+		Name:       "test",
+		WorkingDir: "/src",
+		Services: types.Services{
+			{
+				Name: "app",
+				Extensions: map[string]interface{}{
+					extDevelop: map[string]interface{}{
+						"watch": []interface{}{
+							map[string]interface{}{"action": "sync", "path": "data", "target": "/data"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	ctx := context.Background()
+	apiClient.EXPECT().ContainerList(ctx, moby.ContainerListOptions{
+		Filters: filters.NewArgs(projectFilter("test"), serviceFilter("app"), oneOffFilter(false)),
+	}).Return([]moby.Container{}, nil)
+
+	err := service.Watch(ctx, project, nil, api.WatchOptions{NoUp: true})
+	assert.ErrorContains(t, err, `no running container found for project "test"`)
+}
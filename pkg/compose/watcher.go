@@ -0,0 +1,175 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// fileState is the subset of a file's metadata used to detect it changed between two scans
+type fileState struct {
+	modTime time.Time
+	size    int64
+	mode    fs.FileMode
+}
+
+func (f fileState) equal(other fileState) bool {
+	return f.modTime.Equal(other.modTime) && f.size == other.size && f.mode == other.mode
+}
+
+// fileChanges lists the regular files which have been added, modified or removed since the previous scan
+type fileChanges struct {
+	added    []string
+	modified []string
+	removed  []string
+}
+
+func (c fileChanges) empty() bool {
+	return len(c.added)+len(c.modified)+len(c.removed) == 0
+}
+
+// all returns every changed path, sorted
+func (c fileChanges) all() []string {
+	var paths []string
+	paths = append(paths, c.added...)
+	paths = append(paths, c.modified...)
+	paths = append(paths, c.removed...)
+	sort.Strings(paths)
+	return paths
+}
+
+// fileWatcher detects changes on a set of files and directories by periodically scanning them, so that it works the
+// same on every platform and for bind-mounted or network file systems where file system events are unreliable
+type fileWatcher struct {
+	paths    []string
+	interval time.Duration
+	state    map[string]fileState
+}
+
+func newFileWatcher(interval time.Duration, paths ...string) *fileWatcher {
+	return &fileWatcher{
+		paths:    paths,
+		interval: interval,
+	}
+}
+
+// scan walks the watched paths and returns the state of all the regular files they contain. Version control
+// directories are skipped, and paths which don't exist (yet) are ignored.
+func (w *fileWatcher) scan() (map[string]fileState, error) {
+	state := map[string]fileState{}
+	for _, root := range w.paths {
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			}
+			if d.IsDir() {
+				if d.Name() == ".git" {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !d.Type().IsRegular() {
+				return nil
+			}
+			info, err := d.Info()
+			if err != nil {
+				if os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			}
+			state[path] = fileState{
+				modTime: info.ModTime(),
+				size:    info.Size(),
+				mode:    info.Mode(),
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return state, nil
+}
+
+// changes scans the watched paths and compares them with the previous scan. The first call only records the
+// initial state and reports no change.
+func (w *fileWatcher) changes() (fileChanges, error) {
+	var changes fileChanges
+	state, err := w.scan()
+	if err != nil {
+		return changes, err
+	}
+	if w.state == nil {
+		w.state = state
+		return changes, nil
+	}
+	for path, current := range state {
+		previous, ok := w.state[path]
+		switch {
+		case !ok:
+			changes.added = append(changes.added, path)
+		case !previous.equal(current):
+			changes.modified = append(changes.modified, path)
+		}
+	}
+	for path := range w.state {
+		if _, ok := state[path]; !ok {
+			changes.removed = append(changes.removed, path)
+		}
+	}
+	sort.Strings(changes.added)
+	sort.Strings(changes.modified)
+	sort.Strings(changes.removed)
+	w.state = state
+	return changes, nil
+}
+
+// Watch records the current state of the watched paths, then calls fn with the changes detected by each scan until
+// ctx is done or fn returns an error
+func (w *fileWatcher) Watch(ctx context.Context, fn func(fileChanges) error) error {
+	if _, err := w.changes(); err != nil {
+		return err
+	}
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			changes, err := w.changes()
+			if err != nil {
+				return err
+			}
+			if changes.empty() {
+				continue
+			}
+			if err := fn(changes); err != nil {
+				return err
+			}
+		}
+	}
+}
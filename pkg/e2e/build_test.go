@@ -17,7 +17,10 @@
 package e2e
 
 import (
+	"encoding/json"
 	"net/http"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -202,6 +205,27 @@ func TestBuildTags(t *testing.T) {
 	})
 }
 
+func TestBuildCacheOptions(t *testing.T) {
+	c := NewParallelCLI(t)
+
+	t.Run("invalid cache option", func(t *testing.T) {
+		res := c.RunDockerComposeCmdNoCheck(t, "--project-directory", "./fixtures/simple-build-test", "build",
+			"--cache-to", "ref=foo")
+		res.Assert(t, icmd.Expected{ExitCode: 1, Err: "invalid --cache-to"})
+	})
+
+	t.Run("cache export reaches the builder", func(t *testing.T) {
+		// the default docker driver rejects cache exports, which proves the option was passed through to BuildKit
+		res := icmd.RunCmd(c.NewDockerComposeCmd(t, "--project-directory", "./fixtures/simple-build-test", "build",
+			"--cache-from", "type=local,src=/tmp/compose-e2e-cache",
+			"--cache-to", "type=local,dest=/tmp/compose-e2e-cache"),
+			func(cmd *icmd.Cmd) {
+				cmd.Env = append(cmd.Env, "DOCKER_BUILDKIT=1")
+			})
+		res.Assert(t, icmd.Expected{ExitCode: 1, Err: "cache export feature is currently not supported for docker driver"})
+	})
+}
+
 func TestBuildImageDependencies(t *testing.T) {
 	doTest := func(t *testing.T, cli *CLI) {
 		resetState := func() {
@@ -238,3 +262,182 @@ func TestBuildImageDependencies(t *testing.T) {
 		t.Skip("See https://github.com/docker/compose/issues/9232")
 	})
 }
+
+func TestUpPullPolicyPerService(t *testing.T) {
+	c := NewParallelCLI(t)
+	const projectName = "e2e-pull-policy-per-service"
+	t.Cleanup(func() {
+		c.RunDockerComposeCmd(t, "--project-name", projectName, "down", "--rmi", "all")
+	})
+	c.RunDockerOrExitError(t, "rmi", "e2e-pull-policy-built", "busybox:1.33")
+
+	res := c.RunDockerComposeCmd(t, "--project-directory", "fixtures/pull-policy", "--project-name", projectName,
+		"up", "-d", "--pull-policy-per-service")
+	assert.Assert(t, strings.Contains(res.Stderr(), "built Skipped Image can be built"), res.Stderr())
+	assert.Assert(t, !strings.Contains(res.Stderr(), "built Pulling"), res.Stderr())
+	assert.Assert(t, strings.Contains(res.Stderr(), "pulled Pulled"), res.Stderr())
+	c.RunDockerCmd(t, "image", "inspect", "e2e-pull-policy-built")
+}
+
+func TestBuildProgressRawJSON(t *testing.T) {
+	c := NewParallelCLI(t)
+
+	res := c.RunDockerComposeCmd(t, "--project-directory", "fixtures/build-test", "-p", "build-rawjson",
+		"build", "--progress", "rawjson", "--no-cache", "nginx")
+	lines := Lines(res.Stdout())
+	assert.Assert(t, len(lines) > 0, res.Combined())
+	for _, line := range lines {
+		var status struct {
+			Service string          `json:"service"`
+			Status  json.RawMessage `json:"status"`
+		}
+		assert.NilError(t, json.Unmarshal([]byte(line), &status), line)
+		assert.Equal(t, status.Service, "nginx", line)
+	}
+}
+
+func TestBuildTagOverride(t *testing.T) {
+	c := NewParallelCLI(t)
+	const image = "compose-e2e-build-tag/nginx:ci-123"
+	t.Cleanup(func() {
+		c.RunDockerOrExitError(t, "rmi", image)
+	})
+
+	c.RunDockerComposeCmd(t, "--project-directory", "./fixtures/simple-build-test", "-p", "build-tag",
+		"build", "--tag", "nginx="+image, "nginx")
+
+	res := c.RunDockerCmd(t, "image", "inspect", "--format", "{{join .RepoTags \",\"}}", image)
+	assert.Assert(t, strings.Contains(res.Stdout(), image), res.Stdout())
+
+	res = c.RunDockerOrExitError(t, "image", "inspect", "build-tag_nginx")
+	res.Assert(t, icmd.Expected{ExitCode: 1, Err: "No such image"})
+}
+
+func TestBuildCheck(t *testing.T) {
+	c := NewParallelCLI(t)
+	const projectName = "e2e-build-check"
+
+	res := c.RunDockerComposeCmd(t, "-f", "fixtures/simple-build-test/check.yaml", "--project-name", projectName, "build", "--check")
+	res.Assert(t, icmd.Expected{Out: "nginx: Dockerfile.check:2 maintainer-deprecated: Maintainer instruction is deprecated in favor of using label"})
+	res.Assert(t, icmd.Expected{Out: "Check complete, 1 warning(s) found"})
+	// no image is built
+	res = c.RunDockerOrExitError(t, "image", "inspect", projectName+"_nginx")
+	res.Assert(t, icmd.Expected{ExitCode: 1})
+
+	res = c.RunDockerComposeCmdNoCheck(t, "-f", "fixtures/simple-build-test/check.yaml", "--project-name", projectName, "build", "--check", "--error-on-warnings")
+	res.Assert(t, icmd.Expected{ExitCode: 1, Err: "build check failed with 1 warning(s)"})
+
+	res = c.RunDockerComposeCmd(t, "--project-directory", "fixtures/simple-build-test", "--project-name", projectName, "build", "--check", "--error-on-warnings")
+	res.Assert(t, icmd.Expected{Out: "Check complete, 0 warning(s) found"})
+}
+
+func TestBuildPrint(t *testing.T) {
+	c := NewParallelCLI(t)
+	const projectName = "e2e-build-print"
+
+	buildContext, err := filepath.Abs("fixtures/simple-build-test/nginx-build")
+	assert.NilError(t, err)
+
+	res := c.RunDockerComposeCmd(t, "-f", "fixtures/simple-build-test/print.yaml", "--project-name", projectName,
+		"build", "--print", "nginx")
+	res.Assert(t, icmd.Expected{Out: "context: " + buildContext})
+	res.Assert(t, icmd.Expected{Out: "dockerfile: " + filepath.Join(buildContext, "Dockerfile")})
+
+	// the image set with --tag is the one reported
+	res = c.RunDockerComposeCmd(t, "-f", "fixtures/simple-build-test/print.yaml", "--project-name", projectName,
+		"build", "--print", "--tag", "custom:1.0", "nginx")
+	res.Assert(t, icmd.Expected{Out: "image: custom:1.0"})
+
+	cmd := c.NewDockerComposeCmd(t, "-f", "fixtures/simple-build-test/print.yaml", "--project-name", projectName,
+		"build", "--print=json", "--build-arg", "EXTRA=1")
+	cmd.Env = append(cmd.Env, "VERSION=2.0")
+	res = icmd.RunCmd(cmd)
+	res.Assert(t, icmd.Success)
+
+	var configs map[string]struct {
+		Image      string            `json:"image"`
+		Context    string            `json:"context"`
+		Dockerfile string            `json:"dockerfile"`
+		Args       map[string]string `json:"args"`
+		CacheFrom  []string          `json:"cache_from"`
+	}
+	assert.NilError(t, json.Unmarshal([]byte(res.Stdout()), &configs), res.Stdout())
+	assert.Equal(t, len(configs), 1, res.Stdout())
+	nginx := configs["nginx"]
+	assert.Equal(t, nginx.Image, projectName+"_nginx")
+	assert.Equal(t, nginx.Context, buildContext)
+	assert.Equal(t, nginx.Dockerfile, filepath.Join(buildContext, "Dockerfile"))
+	assert.DeepEqual(t, nginx.Args, map[string]string{"VERSION": "2.0", "EXTRA": "1"})
+	assert.DeepEqual(t, nginx.CacheFrom, []string{"type=registry,ref=nginx:cache"})
+
+	// nothing is built
+	res = c.RunDockerOrExitError(t, "image", "inspect", projectName+"_nginx")
+	res.Assert(t, icmd.Expected{ExitCode: 1})
+}
+
+func TestBuildAddHost(t *testing.T) {
+	c := NewParallelCLI(t)
+	const projectName = "e2e-build-add-host"
+	t.Cleanup(func() {
+		c.RunDockerOrExitError(t, "rmi", projectName+"_nginx")
+	})
+
+	res := c.RunDockerComposeCmd(t, "-f", "fixtures/simple-build-test/extra-hosts.yaml", "--project-name", projectName,
+		"build", "--print", "--add-host", "registry.internal:10.0.0.5")
+	res.Assert(t, icmd.Expected{Out: "- registry.internal:10.0.0.5"})
+
+	res = c.RunDockerComposeCmdNoCheck(t, "-f", "fixtures/simple-build-test/extra-hosts.yaml", "--project-name", projectName,
+		"build", "--progress", "plain")
+	res.Assert(t, icmd.Expected{ExitCode: 17})
+
+	c.RunDockerComposeCmd(t, "-f", "fixtures/simple-build-test/extra-hosts.yaml", "--project-name", projectName,
+		"build", "--add-host", "registry.internal:10.0.0.5")
+	c.RunDockerCmd(t, "image", "inspect", projectName+"_nginx")
+
+	res = c.RunDockerComposeCmdNoCheck(t, "-f", "fixtures/simple-build-test/extra-hosts.yaml", "--project-name", projectName,
+		"build", "--add-host", "registry.internal")
+	res.Assert(t, icmd.Expected{ExitCode: 1, Err: `bad format for add-host: "registry.internal"`})
+}
+
+func TestUpQuietBuild(t *testing.T) {
+	c := NewParallelCLI(t)
+	const projectName = "e2e-up-quiet-build"
+	t.Cleanup(func() {
+		c.RunDockerComposeCmd(t, "--project-name", projectName, "down", "--rmi", "local", "-t", "0")
+	})
+
+	res := c.RunDockerComposeCmd(t, "-f", "fixtures/simple-build-test/compose.yaml", "--project-name", projectName,
+		"up", "-d", "--build", "--quiet-build")
+	res.Assert(t, icmd.Expected{Err: "Service nginx: built"})
+	assert.Assert(t, !strings.Contains(res.Combined(), "COPY static"), res.Combined())
+}
+
+func TestRunBuild(t *testing.T) {
+	c := NewParallelCLI(t)
+	const projectName = "e2e-run-build"
+	t.Cleanup(func() {
+		c.RunDockerOrExitError(t, "rmi", projectName+"_web")
+	})
+
+	dir := t.TempDir()
+	for _, file := range []string{"compose.yaml", "Dockerfile"} {
+		CopyFile(t, filepath.Join("fixtures", "simple-build-test", "run-build", file), filepath.Join(dir, file))
+	}
+	message := filepath.Join(dir, "message.txt")
+	assert.NilError(t, os.WriteFile(message, []byte("before\n"), 0o644))
+
+	// image is missing, so built the first time
+	res := c.RunDockerComposeCmd(t, "--project-directory", dir, "--project-name", projectName, "run", "--rm", "web")
+	lines := Lines(res.Stdout())
+	assert.Equal(t, lines[len(lines)-1], "before", res.Stdout())
+
+	assert.NilError(t, os.WriteFile(message, []byte("after\n"), 0o644))
+
+	// without --build, the existing image is used
+	res = c.RunDockerComposeCmd(t, "--project-directory", dir, "--project-name", projectName, "run", "--rm", "web")
+	assert.Equal(t, strings.TrimSpace(res.Stdout()), "before")
+
+	res = c.RunDockerComposeCmd(t, "--project-directory", dir, "--project-name", projectName, "run", "--rm", "--build", "web")
+	lines = Lines(res.Stdout())
+	assert.Equal(t, lines[len(lines)-1], "after", res.Stdout())
+}
@@ -65,6 +65,34 @@ func TestComposeCancel(t *testing.T) {
 	})
 }
 
+func TestUpStopTimeout(t *testing.T) {
+	c := NewParallelCLI(t)
+	const projectName = "e2e-up-stop-timeout"
+	t.Cleanup(func() {
+		c.RunDockerComposeCmd(t, "--project-name", projectName, "down", "-t", "0")
+	})
+
+	cmd, stdout, stderr, err := StartWithNewGroupID(c.NewDockerComposeCmd(t, "-f", "fixtures/stop-timeout/compose.yaml",
+		"--project-name", projectName, "up", "--stop-timeout", "2"))
+	assert.NilError(t, err)
+
+	c.WaitForCondition(t, func() (bool, string) {
+		out := stdout.String()
+		return strings.Contains(out, "ready"), fmt.Sprintf("'ready' not found in : \n%s\nStderr: \n%s\n", out,
+			stderr.String())
+	}, 30*time.Second, 1*time.Second)
+
+	// the service ignores SIGTERM, so without --stop-timeout the stop would last its 2m stop_grace_period
+	start := time.Now()
+	err = syscall.Kill(-cmd.Process.Pid, syscall.SIGINT)
+	assert.NilError(t, err)
+	_ = cmd.Wait()
+	assert.Assert(t, time.Since(start) < 20*time.Second, "stopping took %s", time.Since(start))
+
+	res := c.RunDockerComposeCmd(t, "--project-name", projectName, "ps", "--all", "--format", "{{.State}}")
+	assert.Equal(t, strings.TrimSpace(res.Stdout()), "exited")
+}
+
 func StartWithNewGroupID(command icmd.Cmd) (*exec.Cmd, *bytes.Buffer, *bytes.Buffer, error) {
 	cmd := exec.Command(command.Command[0], command.Command[1:]...)
 	cmd.Env = command.Env
@@ -17,8 +17,12 @@
 package e2e
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"gotest.tools/v3/assert"
 	"gotest.tools/v3/icmd"
 )
 
@@ -32,3 +36,69 @@ func TestDown(t *testing.T) {
 		res.Assert(t, icmd.Expected{ExitCode: 0, Err: `No resource found to remove for project "e2e-down"`})
 	})
 }
+
+func TestDownWithoutComposeFile(t *testing.T) {
+	c := NewParallelCLI(t)
+
+	const projectName = "e2e-down-without-file"
+	dir := t.TempDir()
+	for _, file := range []string{"compose.yaml", "Dockerfile"} {
+		CopyFile(t, filepath.Join("fixtures", "down-without-file", file), filepath.Join(dir, file))
+	}
+	c.RunDockerComposeCmd(t, "--project-directory", dir, "--project-name", projectName, "up", "-d")
+
+	assert.NilError(t, os.Remove(filepath.Join(dir, "compose.yaml")))
+
+	c.RunDockerComposeCmd(t, "--project-name", projectName, "down", "--volumes", "--rmi", "local")
+
+	res := c.RunDockerOrExitError(t, "image", "inspect", projectName+"_app")
+	res.Assert(t, icmd.Expected{ExitCode: 1, Err: "No such image"})
+	c.RunDockerCmd(t, "image", "inspect", "alpine")
+
+	res = c.RunDockerCmd(t, "ps", "--all", "--filter", "label=com.docker.compose.project="+projectName, "--quiet")
+	assert.Equal(t, strings.TrimSpace(res.Stdout()), "")
+	res = c.RunDockerCmd(t, "network", "ls", "--filter", "label=com.docker.compose.project="+projectName, "--quiet")
+	assert.Equal(t, strings.TrimSpace(res.Stdout()), "")
+	res = c.RunDockerCmd(t, "volume", "ls", "--filter", "label=com.docker.compose.project="+projectName, "--quiet")
+	assert.Equal(t, strings.TrimSpace(res.Stdout()), "")
+}
+
+func TestDownSignal(t *testing.T) {
+	c := NewParallelCLI(t)
+
+	const projectName = "e2e-down-signal"
+	dir := t.TempDir()
+	compose := func(args ...string) *icmd.Result {
+		cmd := c.NewDockerComposeCmd(t, append([]string{"-f", "./fixtures/down-signal/compose.yaml",
+			"--project-name", projectName}, args...)...)
+		cmd.Env = append(cmd.Env, "SIGNAL_DIR="+dir)
+		res := icmd.RunCmd(cmd)
+		res.Assert(t, icmd.Success)
+		return res
+	}
+	received := func() string {
+		content, err := os.ReadFile(filepath.Join(dir, "received"))
+		assert.NilError(t, err)
+		return strings.TrimSpace(string(content))
+	}
+	t.Cleanup(func() {
+		compose("down", "-t", "0")
+	})
+
+	t.Run("stop_signal of the service", func(t *testing.T) {
+		compose("up", "-d")
+		compose("down", "-t", "10")
+		assert.Equal(t, received(), "QUIT")
+	})
+
+	t.Run("--signal overrides stop_signal", func(t *testing.T) {
+		compose("up", "-d")
+		compose("down", "-t", "10", "--signal", "SIGUSR1")
+		assert.Equal(t, received(), "USR1")
+	})
+
+	t.Run("invalid signal", func(t *testing.T) {
+		res := c.RunDockerComposeCmdNoCheck(t, "--project-name", projectName, "down", "--signal", "SIGNOPE")
+		res.Assert(t, icmd.Expected{ExitCode: 1, Err: "invalid signal: SIGNOPE"})
+	})
+}
@@ -141,6 +141,30 @@ func TestEnvInterpolation(t *testing.T) {
 	})
 }
 
+func TestEnvResolution(t *testing.T) {
+	c := NewParallelCLI(t)
+
+	projectDir := "./fixtures/environment/env-resolution"
+
+	t.Run("environment file is used for interpolation", func(t *testing.T) {
+		res := c.RunDockerComposeCmd(t, "--project-directory", projectDir, "config")
+		res.Assert(t, icmd.Expected{Out: `image: alpine:from-env-file`})
+	})
+
+	t.Run("no environment file resolution", func(t *testing.T) {
+		res := c.RunDockerComposeCmd(t, "--project-directory", projectDir, "config", "--no-env-resolution")
+		res.Assert(t, icmd.Expected{Out: `image: alpine:default`})
+		assert.Assert(t, !strings.Contains(res.Stdout(), "from-env-file"), res.Stdout())
+	})
+
+	t.Run("shell environment is still resolved", func(t *testing.T) {
+		cmd := c.NewDockerComposeCmd(t, "--project-directory", projectDir, "config", "--no-env-resolution")
+		cmd.Env = append(cmd.Env, "TAG=shell")
+		res := icmd.RunCmd(cmd)
+		res.Assert(t, icmd.Expected{Out: `image: alpine:shell`})
+	})
+}
+
 func TestCommentsInEnvFile(t *testing.T) {
 	c := NewParallelCLI(t)
 
@@ -161,3 +185,27 @@ func TestCommentsInEnvFile(t *testing.T) {
 		c.RunDockerComposeCmd(t, "--project-directory", projectDir, "down", "--rmi", "all")
 	})
 }
+
+func TestUpEnvironmentInjection(t *testing.T) {
+	c := NewParallelCLI(t)
+
+	const projectName = "compose-e2e-env-injection"
+	t.Cleanup(func() {
+		c.RunDockerComposeCmd(t, "--project-name", projectName, "down", "-t", "0")
+	})
+
+	c.RunDockerComposeCmd(t, "-f", "./fixtures/environment/env-injection/compose.yaml", "--project-name", projectName,
+		"up", "-d", "--environment", "DEBUG=1", "--environment", "web:LOG_LEVEL=debug")
+
+	res := c.RunDockerCmd(t, "exec", projectName+"-web-1", "env")
+	res.Assert(t, icmd.Expected{Out: "LOG_LEVEL=debug"})
+	res.Assert(t, icmd.Expected{Out: "DEBUG=1"})
+
+	res = c.RunDockerCmd(t, "exec", projectName+"-worker-1", "env")
+	res.Assert(t, icmd.Expected{Out: "LOG_LEVEL=info"})
+	res.Assert(t, icmd.Expected{Out: "DEBUG=1"})
+
+	res = c.RunDockerComposeCmdNoCheck(t, "-f", "./fixtures/environment/env-injection/compose.yaml", "--project-name",
+		projectName, "up", "-d", "--environment", "db:LOG_LEVEL=debug")
+	res.Assert(t, icmd.Expected{ExitCode: 1, Err: "no such service: db"})
+}
@@ -46,6 +46,16 @@ func TestLocalComposeExec(t *testing.T) {
 		res.Assert(t, icmd.Expected{ExitCode: 1})
 	})
 
+	t.Run("exec exit code", func(t *testing.T) {
+		res := c.RunDockerComposeCmdNoCheck(t, cmdArgs("exec", "simple", "sh", "-c", "exit 42")...)
+		res.Assert(t, icmd.Expected{ExitCode: 42})
+	})
+
+	t.Run("exec killed command", func(t *testing.T) {
+		res := c.RunDockerComposeCmdNoCheck(t, cmdArgs("exec", "simple", "sh", "-c", "kill -9 $$")...)
+		res.Assert(t, icmd.Expected{ExitCode: 137})
+	})
+
 	t.Run("exec with env set", func(t *testing.T) {
 		res := icmd.RunCmd(c.NewDockerComposeCmd(t, cmdArgs("exec", "-e", "FOO", "simple", "/usr/bin/env")...),
 			func(cmd *icmd.Cmd) {
@@ -59,3 +69,42 @@ func TestLocalComposeExec(t *testing.T) {
 		assert.Check(t, !strings.Contains(res.Stdout(), "FOO="), res.Combined())
 	})
 }
+
+func TestComposeExecUser(t *testing.T) {
+	c := NewParallelCLI(t)
+
+	const projectName = "compose-e2e-exec-user"
+	cmdArgs := func(cmd string, args ...string) []string {
+		ret := []string{"-f", "fixtures/exec/compose.yaml", "--project-name", projectName, cmd}
+		return append(ret, args...)
+	}
+	t.Cleanup(func() {
+		c.RunDockerComposeCmd(t, cmdArgs("down", "-t", "0")...)
+	})
+
+	c.RunDockerComposeCmd(t, cmdArgs("up", "-d")...)
+
+	t.Run("exec as the service user", func(t *testing.T) {
+		res := c.RunDockerComposeCmd(t, cmdArgs("exec", "-T", "web", "id", "-u")...)
+		assert.Equal(t, strings.TrimSpace(res.Stdout()), "65534")
+	})
+
+	t.Run("exec --user root", func(t *testing.T) {
+		res := c.RunDockerComposeCmd(t, cmdArgs("exec", "-T", "--user", "root", "web", "id", "-u")...)
+		assert.Equal(t, strings.TrimSpace(res.Stdout()), "0")
+	})
+
+	t.Run("exec --user uid:gid --privileged", func(t *testing.T) {
+		capabilities := func(args ...string) string {
+			args = append(append([]string{"exec", "-T", "--user", "0:0"}, args...), "web", "grep", "CapEff", "/proc/self/status")
+			return strings.TrimSpace(c.RunDockerComposeCmd(t, cmdArgs(args[0], args[1:]...)...).Stdout())
+		}
+		// privileged processes get all capabilities, beyond the default set granted to root
+		assert.Assert(t, capabilities("--privileged") != capabilities())
+	})
+
+	t.Run("exec invalid --user", func(t *testing.T) {
+		res := c.RunDockerComposeCmdNoCheck(t, cmdArgs("exec", "--user", "a:b:c", "web", "id")...)
+		res.Assert(t, icmd.Expected{ExitCode: 1, Err: `invalid --user option "a:b:c"`})
+	})
+}
@@ -18,6 +18,7 @@ package e2e
 
 import (
 	"os"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -78,6 +79,31 @@ func TestLocalComposeRun(t *testing.T) {
 		assert.Assert(t, strings.Contains(res.Stdout(), "run-test_back"), res.Stdout())
 	})
 
+	t.Run("compose run --name", func(t *testing.T) {
+		res := c.RunDockerComposeCmd(t, "-f", "./fixtures/run-test/compose.yaml", "run", "--name", "my-debug", "back")
+		lines := Lines(res.Stdout())
+		assert.Equal(t, lines[len(lines)-1], "Hello there!!", res.Stdout())
+
+		res = c.RunDockerCmd(t, "inspect", "my-debug")
+		res.Assert(t, icmd.Expected{Out: `"com.docker.compose.project": "run-test"`})
+		res.Assert(t, icmd.Expected{Out: `"com.docker.compose.oneoff": "True",`})
+
+		res = c.RunDockerComposeCmdNoCheck(t, "-f", "./fixtures/run-test/compose.yaml", "run", "--name", "my-debug", "back")
+		res.Assert(t, icmd.Expected{ExitCode: 1, Err: `container name "my-debug" is already in use in project "run-test"`})
+	})
+
+	t.Run("compose run --label-file", func(t *testing.T) {
+		c.RunDockerComposeCmd(t, "-f", "./fixtures/run-test/compose.yaml", "run", "--name", "run-test-labels",
+			"--label-file", "./fixtures/run-test/labels.txt", "-l", "ci.branch=feature", "back")
+
+		res := c.RunDockerCmd(t, "inspect", "run-test-labels")
+		res.Assert(t, icmd.Expected{Out: `"ci.build": "42"`})
+		res.Assert(t, icmd.Expected{Out: `"ci.commit": "abc123"`})
+		res.Assert(t, icmd.Expected{Out: `"ci.branch": "feature"`})
+		res.Assert(t, icmd.Expected{Out: `"com.docker.compose.project": "run-test"`})
+		res.Assert(t, icmd.Expected{Out: `"com.docker.compose.oneoff": "True",`})
+	})
+
 	t.Run("down", func(t *testing.T) {
 		c.RunDockerComposeCmd(t, "-f", "./fixtures/run-test/compose.yaml", "down")
 		res := c.RunDockerCmd(t, "ps", "--all")
@@ -95,6 +121,18 @@ func TestLocalComposeRun(t *testing.T) {
 		assert.Assert(t, strings.Contains(res.Stdout(), "run-test_back"), res.Stdout())
 	})
 
+	t.Run("compose run --volume overrides service volume", func(t *testing.T) {
+		wd, err := os.Getwd()
+		assert.NilError(t, err)
+		res := c.RunDockerComposeCmd(t, "-f", "./fixtures/run-test/compose.yaml", "run", "--rm", "--no-deps",
+			"-v", wd+":/test", "db", "ls", "/test")
+		res.Assert(t, icmd.Expected{Out: "compose_run_test.go"})
+
+		res = c.RunDockerComposeCmdNoCheck(t, "-f", "./fixtures/run-test/compose.yaml", "run", "--rm",
+			"-v", "/tmp:/a:/b:/c", "back")
+		res.Assert(t, icmd.Expected{ExitCode: 1, Err: `invalid --volume option "/tmp:/a:/b:/c"`})
+	})
+
 	t.Run("compose run --publish", func(t *testing.T) {
 		c.RunDockerComposeCmd(t, "-f", "./fixtures/run-test/compose.yaml", "run", "--publish", "8081:80", "-d", "back",
 			"/bin/sh", "-c", "sleep 1")
@@ -124,6 +162,30 @@ func TestLocalComposeRun(t *testing.T) {
 		assert.Assert(t, !strings.Contains(res.Stdout(), "run-test"), res.Stdout())
 	})
 
+	t.Run("compose run --quiet-pull", func(t *testing.T) {
+		c.RunDockerOrExitError(t, "rmi", "busybox:1.35", "busybox:1.34")
+		defer c.RunDockerComposeCmd(t, "-f", "./fixtures/run-test/quiet-pull.yaml", "down", "--remove-orphans")
+
+		res := c.RunDockerComposeCmd(t, "-f", "./fixtures/run-test/quiet-pull.yaml", "run", "--rm", "--quiet-pull", "quiet")
+		lines := Lines(res.Stdout())
+		assert.Equal(t, lines[len(lines)-1], "Hello quietly", res.Stdout())
+		assert.Assert(t, strings.Contains(res.Stderr(), "Pulled"), res.Stderr())
+		assert.Assert(t, !strings.Contains(res.Combined(), "Pull complete"), res.Combined())
+		assert.Assert(t, !strings.Contains(res.Combined(), "Downloading"), res.Combined())
+	})
+
+	t.Run("compose run --quiet", func(t *testing.T) {
+		defer c.RunDockerComposeCmd(t, "-f", "./fixtures/run-test/quiet.yaml", "down", "--remove-orphans", "-t", "0")
+
+		res := c.RunDockerComposeCmd(t, "-f", "./fixtures/run-test/quiet.yaml", "run", "--rm", "--quiet", "web", "echo", "hello")
+		assert.Equal(t, res.Stdout(), "hello\n")
+		assert.Equal(t, res.Stderr(), "")
+
+		res = c.RunDockerComposeCmdNoCheck(t, "-f", "./fixtures/run-test/quiet.yaml", "run", "--rm", "--quiet", "web",
+			"sh", "-c", "exit 3")
+		res.Assert(t, icmd.Expected{ExitCode: 3})
+	})
+
 	t.Run("run starts only container and dependencies", func(t *testing.T) {
 		// ensure that even if another service is up run does not start it: https://github.com/docker/compose/issues/9459
 		res := c.RunDockerComposeCmd(t, "-f", "./fixtures/run-test/deps.yaml", "up", "service_b")
@@ -135,4 +197,54 @@ func TestLocalComposeRun(t *testing.T) {
 
 		c.RunDockerComposeCmd(t, "-f", "./fixtures/run-test/deps.yaml", "down", "--remove-orphans")
 	})
+
+	t.Run("compose run uses the service working_dir", func(t *testing.T) {
+		defer c.RunDockerComposeCmd(t, "-f", "./fixtures/run-test/workdir.yaml", "down", "--remove-orphans")
+
+		res := c.RunDockerComposeCmd(t, "-f", "./fixtures/run-test/workdir.yaml", "run", "--rm", "web", "pwd")
+		lines := Lines(res.Stdout())
+		assert.Equal(t, lines[len(lines)-1], "/app", res.Stdout())
+
+		res = c.RunDockerComposeCmd(t, "-f", "./fixtures/run-test/workdir.yaml", "run", "--rm", "--workdir", "/tmp", "web", "pwd")
+		lines = Lines(res.Stdout())
+		assert.Equal(t, lines[len(lines)-1], "/tmp", res.Stdout())
+	})
+
+	t.Run("compose run --cap-add", func(t *testing.T) {
+		defer c.RunDockerComposeCmd(t, "-f", "./fixtures/run-test/capabilities.yaml", "down", "--remove-orphans")
+
+		res := c.RunDockerComposeCmd(t, "-f", "./fixtures/run-test/capabilities.yaml", "run", "--rm",
+			"--cap-add", "SYS_PTRACE", "--cap-drop", "CHOWN", "debug", "grep", "CapEff", "/proc/self/status")
+		lines := Lines(res.Stdout())
+		fields := strings.Fields(lines[len(lines)-1])
+		assert.Equal(t, len(fields), 2, res.Stdout())
+		caps, err := strconv.ParseUint(fields[1], 16, 64)
+		assert.NilError(t, err)
+
+		const (
+			capChown     = 0
+			capSysPtrace = 19
+			capSysNice   = 23
+		)
+		assert.Assert(t, caps&(1<<capSysPtrace) != 0, "SYS_PTRACE not added: %s", fields[1])
+		assert.Assert(t, caps&(1<<capSysNice) != 0, "SYS_NICE declared by the service not kept: %s", fields[1])
+		assert.Assert(t, caps&(1<<capChown) == 0, "CHOWN not dropped: %s", fields[1])
+	})
+	t.Run("compose run --network", func(t *testing.T) {
+		defer c.RunDockerComposeCmd(t, "-f", "./fixtures/run-test/network.yaml", "down", "--remove-orphans")
+
+		c.RunDockerComposeCmd(t, "-f", "./fixtures/run-test/network.yaml", "run", "--name", "run-test-back",
+			"--network", "back", "debug", "true")
+		res := c.RunDockerCmd(t, "inspect", "--format", "{{ range $k, $v := .NetworkSettings.Networks }}{{ $k }} {{ end }}", "run-test-back")
+		assert.Equal(t, strings.TrimSpace(res.Stdout()), "run-test_back")
+
+		c.RunDockerComposeCmd(t, "-f", "./fixtures/run-test/network.yaml", "run", "--name", "run-test-host",
+			"--network", "host", "debug", "true")
+		res = c.RunDockerCmd(t, "inspect", "--format", "{{ .HostConfig.NetworkMode }}", "run-test-host")
+		assert.Equal(t, strings.TrimSpace(res.Stdout()), "host")
+
+		res = c.RunDockerComposeCmdNoCheck(t, "-f", "./fixtures/run-test/network.yaml", "run", "--rm",
+			"--network", "no-such-network", "debug", "true")
+		res.Assert(t, icmd.Expected{ExitCode: 1, Err: `network "no-such-network" not found`})
+	})
 }
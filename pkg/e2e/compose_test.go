@@ -22,6 +22,8 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -87,6 +89,17 @@ func TestLocalComposeUp(t *testing.T) {
 
 	})
 
+	t.Run("check compose ls config files", func(t *testing.T) {
+		configFile, err := filepath.Abs("./fixtures/sentences/compose.yaml")
+		assert.NilError(t, err)
+
+		res := c.RunDockerComposeCmd(t, "ls", "--filter", "name="+projectName, "--format", "{{.ConfigFiles}}")
+		assert.Equal(t, strings.TrimSpace(res.Stdout()), configFile)
+
+		res = c.RunDockerComposeCmd(t, "ls", "--filter", "name="+projectName, "--format", "json")
+		res.Assert(t, icmd.Expected{Out: `"ConfigFiles":["` + configFile + `"]`})
+	})
+
 	t.Run("check healthcheck output", func(t *testing.T) {
 		c.WaitForCmdResult(t, c.NewDockerComposeCmd(t, "-p", projectName, "ps", "--format", "json"),
 			StdoutContains(`"Name":"compose-e2e-demo-web-1","Command":"/dispatcher","Project":"compose-e2e-demo","Service":"web","State":"running","Health":"healthy"`),
@@ -96,6 +109,20 @@ func TestLocalComposeUp(t *testing.T) {
 		res.Assert(t, icmd.Expected{Out: `NAME                       COMMAND                  SERVICE             STATUS              PORTS`})
 		res.Assert(t, icmd.Expected{Out: `compose-e2e-demo-web-1     "/dispatcher"            web                 running (healthy)   0.0.0.0:90->80/tcp`})
 		res.Assert(t, icmd.Expected{Out: `compose-e2e-demo-db-1      "docker-entrypoint.s…"   db                  running             5432/tcp`})
+
+		res = c.RunDockerComposeCmd(t, "-p", projectName, "ps", "--format", `table {{.Name}}\t{{.Health}}`, "web")
+		assert.DeepEqual(t, Lines(res.Stdout()), []string{
+			"NAME                     HEALTH",
+			"compose-e2e-demo-web-1   healthy",
+		})
+
+		res = c.RunDockerComposeCmd(t, "-p", projectName, "ps", "--format", `table {{.Service}}\t{{.State}}\t{{.Health}}`)
+		assert.DeepEqual(t, Lines(res.Stdout()), []string{
+			"SERVICE   STATE     HEALTH",
+			"db        running   ",
+			"web       running   healthy",
+			"words     running   ",
+		})
 	})
 
 	t.Run("images", func(t *testing.T) {
@@ -105,6 +132,42 @@ func TestLocalComposeUp(t *testing.T) {
 		res.Assert(t, icmd.Expected{Out: `compose-e2e-demo-words-1   gtardif/sentences-api   latest`})
 	})
 
+	t.Run("images --digests", func(t *testing.T) {
+		res := c.RunDockerComposeCmd(t, "-p", projectName, "images", "--digests")
+		lines := Lines(res.Stdout())
+		assert.Assert(t, strings.Contains(lines[0], "DIGEST"), res.Stdout())
+		for _, line := range lines[1:] {
+			// pulled images have a known repository digest
+			fields := strings.Fields(line)
+			assert.Assert(t, len(fields) > 3 && strings.HasPrefix(fields[3], "sha256:"), line)
+		}
+	})
+
+	t.Run("images filtered by reference", func(t *testing.T) {
+		res := c.RunDockerComposeCmd(t, "-p", projectName, "images", "--filter", "reference=gtardif/sentences-[dw]*")
+		res.Assert(t, icmd.Expected{Out: `compose-e2e-demo-db-1      gtardif/sentences-db    latest`})
+		res.Assert(t, icmd.Expected{Out: `compose-e2e-demo-web-1     gtardif/sentences-web   latest`})
+		assert.Assert(t, !strings.Contains(res.Stdout(), "sentences-api"), res.Stdout())
+
+		res = c.RunDockerComposeCmd(t, "-p", projectName, "images", "--filter", "dangling=true")
+		assert.Assert(t, !strings.Contains(res.Stdout(), "gtardif/"), res.Stdout())
+	})
+
+	t.Run("port --all", func(t *testing.T) {
+		res := c.RunDockerComposeCmd(t, "-p", projectName, "port", "--all")
+		lines := Lines(res.Stdout())
+		assert.Assert(t, strings.Contains(res.Stdout(), "0.0.0.0:90->80/tcp"), res.Stdout())
+		for _, line := range lines {
+			if strings.Contains(line, "0.0.0.0:90->80/tcp") {
+				assert.Assert(t, strings.HasPrefix(line, "web "), line)
+				assert.Assert(t, strings.Contains(line, "compose-e2e-demo-web-1"), line)
+			}
+		}
+
+		res = c.RunDockerComposeCmd(t, "-p", projectName, "port", "--all", "--format", "json")
+		res.Assert(t, icmd.Expected{Out: `{"Service":"web","Container":"compose-e2e-demo-web-1","URL":"0.0.0.0","TargetPort":80,"PublishedPort":90,"Protocol":"tcp"}`})
+	})
+
 	t.Run("down", func(t *testing.T) {
 		_ = c.RunDockerComposeCmd(t, "--project-name", projectName, "down")
 	})
@@ -145,6 +208,27 @@ func TestDownComposefileInParentFolder(t *testing.T) {
 	res.Assert(t, icmd.Expected{Err: "Removed", ExitCode: 0})
 }
 
+func TestInvalidProjectNameFromDirectory(t *testing.T) {
+	c := NewParallelCLI(t)
+	const projectDir = "./fixtures/project-name/My.App"
+	t.Cleanup(func() {
+		c.RunDockerComposeCmd(t, "--project-directory", projectDir, "down", "-t", "0")
+	})
+
+	res := c.RunDockerComposeCmdNoCheck(t, "--project-directory", projectDir, "--strict-project-name", "up", "-d")
+	res.Assert(t, icmd.Expected{ExitCode: 1, Err: `invalid project name "My.App"`})
+
+	res = c.RunDockerComposeCmd(t, "--project-directory", projectDir, "up", "-d")
+	assert.Assert(t, strings.Contains(res.Stderr(), `project name "My.App" is not valid, using "myapp" instead`), res.Stderr())
+
+	res = c.RunDockerCmd(t, "ps", "--filter", "label=com.docker.compose.project=myapp", "--format", "{{.Names}}")
+	res.Assert(t, icmd.Expected{Out: "myapp-web-1"})
+
+	// the name set with --project-name is normalized the same way
+	res = c.RunDockerComposeCmd(t, "-p", "My.App", "ps", "--format", "{{.Name}}")
+	res.Assert(t, icmd.Expected{Out: "myapp-web-1"})
+}
+
 func TestAttachRestart(t *testing.T) {
 	c := NewParallelCLI(t)
 
@@ -168,6 +252,9 @@ func TestInitContainer(t *testing.T) {
 	res := c.RunDockerComposeCmd(t, "--ansi=never", "--project-directory", "./fixtures/init-container", "up")
 	defer c.RunDockerComposeCmd(t, "-p", "init-container", "down")
 	testify.Regexp(t, "foo-1  | hello(?m:.*)bar-1  | world", res.Stdout())
+
+	res = c.RunDockerComposeCmd(t, "--ansi=never", "--project-directory", "./fixtures/init-container", "logs", "--merge-dependencies", "bar")
+	assert.DeepEqual(t, Lines(res.Stdout()), []string{"init-container-foo-1  | hello", "init-container-bar-1  | world"})
 }
 
 func TestRm(t *testing.T) {
@@ -244,4 +331,550 @@ networks:
   default:
     name: compose-e2e-convert_default`, filepath.Join(wd, "fixtures", "simple-build-test", "nginx-build")), ExitCode: 0})
 	})
+
+	t.Run("without resolving paths", func(t *testing.T) {
+		res := c.RunDockerComposeCmd(t, "-f", "./fixtures/simple-build-test/compose.yaml", "-p", projectName, "convert", "--resolve-paths=false")
+		res.Assert(t, icmd.Expected{Out: `services:
+  nginx:
+    build:
+      context: nginx-build
+      dockerfile: Dockerfile
+    networks:
+      default: null
+networks:
+  default:
+    name: compose-e2e-convert_default`, ExitCode: 0})
+	})
+
+	t.Run("keep relative bind mount sources", func(t *testing.T) {
+		res := c.RunDockerComposeCmd(t, "-f", "./fixtures/relative-bind/compose.yaml", "-p", projectName, "convert")
+		res.Assert(t, icmd.Expected{Out: fmt.Sprintf("source: %s", filepath.Join(wd, "fixtures", "relative-bind", "data"))})
+
+		res = c.RunDockerComposeCmd(t, "-f", "./fixtures/relative-bind/compose.yaml", "-p", projectName, "convert", "--no-paths-normalization")
+		res.Assert(t, icmd.Expected{Out: "source: ./data"})
+	})
+}
+
+func TestUpAlwaysRecreateDeps(t *testing.T) {
+	c := NewParallelCLI(t)
+
+	const projectName = "compose-e2e-recreate-deps"
+	t.Cleanup(func() {
+		c.RunDockerComposeCmd(t, "--project-name", projectName, "down", "-t", "0")
+	})
+
+	dbID := func() string {
+		res := c.RunDockerCmd(t, "inspect", "--format", "{{.Id}}", projectName+"-db-1")
+		return strings.TrimSpace(res.Stdout())
+	}
+
+	c.RunDockerComposeCmd(t, "-f", "./fixtures/dependencies/recreate-deps.yaml", "--project-name", projectName, "up", "-d", "web")
+	previous := dbID()
+
+	c.RunDockerComposeCmd(t, "-f", "./fixtures/dependencies/recreate-deps.yaml", "--project-name", projectName, "up", "-d", "web")
+	assert.Equal(t, dbID(), previous, "db should not be recreated as its configuration didn't change")
+
+	for i := 0; i < 2; i++ {
+		c.RunDockerComposeCmd(t, "-f", "./fixtures/dependencies/recreate-deps.yaml", "--project-name", projectName,
+			"up", "-d", "web", "--always-recreate-deps")
+		current := dbID()
+		assert.Assert(t, current != previous, "db should be recreated on each up with --always-recreate-deps")
+		previous = current
+	}
+}
+
+func TestConvertCircularExtends(t *testing.T) {
+	c := NewParallelCLI(t)
+
+	res := c.RunDockerComposeCmdNoCheck(t, "-f", "./fixtures/extends/circular.yaml", "-p", "compose-e2e-circular-extends", "convert")
+	res.Assert(t, icmd.Expected{ExitCode: 1, Err: "Circular reference:"})
+	assert.Assert(t, strings.Contains(res.Stderr(), "extends base in "), res.Stderr())
+	assert.Assert(t, strings.Contains(res.Stderr(), "extends web in "), res.Stderr())
+}
+
+func TestConvertCheckPaths(t *testing.T) {
+	c := NewParallelCLI(t)
+
+	const projectName = "compose-e2e-check-paths"
+	res := c.RunDockerComposeCmd(t, "-f", "./fixtures/missing-context/compose.yaml", "-p", projectName, "convert")
+	res.Assert(t, icmd.Expected{Out: "not-checked-out"})
+
+	res = c.RunDockerComposeCmdNoCheck(t, "-f", "./fixtures/missing-context/compose.yaml", "-p", projectName, "convert", "--check-paths")
+	res.Assert(t, icmd.Expected{ExitCode: 1, Err: `build context "./not-checked-out" of service "app" does not exist`})
+}
+
+func TestConvertServicesFilterProfile(t *testing.T) {
+	c := NewParallelCLI(t)
+
+	const projectName = "compose-e2e-services-profile"
+	res := c.RunDockerComposeCmd(t, "-f", "./fixtures/profiles/compose.yaml", "-p", projectName, "convert", "--services", "--filter", "profile=debug")
+	services := Lines(res.Stdout())
+	sort.Strings(services)
+	assert.DeepEqual(t, services, []string{"debugger", "profiler"})
+
+	res = c.RunDockerComposeCmd(t, "-f", "./fixtures/profiles/compose.yaml", "-p", projectName, "convert", "--services")
+	services = Lines(res.Stdout())
+	sort.Strings(services)
+	assert.DeepEqual(t, services, []string{"db", "web"})
+
+	res = c.RunDockerComposeCmdNoCheck(t, "-f", "./fixtures/profiles/compose.yaml", "-p", projectName, "convert", "--services", "--filter", "name=web")
+	res.Assert(t, icmd.Expected{ExitCode: 1, Err: "unknown filter name"})
+}
+
+func TestPortIndex(t *testing.T) {
+	c := NewParallelCLI(t)
+
+	const projectName = "compose-e2e-port-index"
+	t.Cleanup(func() {
+		c.RunDockerComposeCmd(t, "--project-name", projectName, "down", "-t", "0")
+	})
+	c.RunDockerComposeCmd(t, "-f", "./fixtures/port-index/compose.yaml", "--project-name", projectName, "up", "-d")
+
+	// only compare host ports, as the engine may list the IPv4 and IPv6 bindings in any order
+	hostPort := func(binding string) string {
+		binding = strings.TrimSpace(binding)
+		return binding[strings.LastIndex(binding, ":")+1:]
+	}
+	binding := func(container string) string {
+		res := c.RunDockerCmd(t, "port", container, "80/tcp")
+		return hostPort(Lines(res.Stdout())[0])
+	}
+
+	t.Run("selected replica", func(t *testing.T) {
+		res := c.RunDockerComposeCmd(t, "--project-name", projectName, "port", "--index", "2", "web", "80")
+		assert.Equal(t, hostPort(res.Stdout()), binding(projectName+"-web-2"))
+	})
+
+	t.Run("lowest-numbered replica by default", func(t *testing.T) {
+		c.RunDockerCmd(t, "rm", "-f", projectName+"-web-1")
+		res := c.RunDockerComposeCmd(t, "--project-name", projectName, "port", "web", "80")
+		assert.Equal(t, hostPort(res.Stdout()), binding(projectName+"-web-2"))
+	})
+
+	t.Run("port not published", func(t *testing.T) {
+		res := c.RunDockerComposeCmdNoCheck(t, "--project-name", projectName, "port", "--index", "3", "web", "8080")
+		res.Assert(t, icmd.Expected{ExitCode: 1, Err: "no port 8080/tcp published by web_3"})
+	})
+}
+
+func TestConvertOnlyBuildable(t *testing.T) {
+	c := NewParallelCLI(t)
+
+	const projectName = "compose-e2e-only-buildable"
+	res := c.RunDockerComposeCmd(t, "-f", "./fixtures/simple-build-test/buildable.yaml", "-p", projectName, "convert", "--only-buildable")
+	out := res.Stdout()
+	assert.Assert(t, strings.Contains(out, "nginx:"), out)
+	assert.Assert(t, strings.Contains(out, "front:"), out)
+	assert.Assert(t, !strings.Contains(out, "db:"), out)
+	assert.Assert(t, !strings.Contains(out, "postgres"), out)
+	assert.Assert(t, !strings.Contains(out, "back:"), out)
+	assert.Assert(t, !strings.Contains(out, "volumes:"), out)
+
+	res = c.RunDockerComposeCmd(t, "-f", "./fixtures/simple-build-test/buildable.yaml", "-p", projectName, "convert", "--only-buildable", "--services")
+	assert.DeepEqual(t, Lines(res.Stdout()), []string{"nginx"})
+}
+
+func TestConvertModels(t *testing.T) {
+	c := NewParallelCLI(t)
+
+	const projectName = "compose-e2e-convert-models"
+	dir := t.TempDir()
+	c.RunDockerComposeCmd(t, "-f", "./fixtures/sentences/compose.yaml", "-p", projectName, "convert", "--models",
+		"--output-dir", dir)
+
+	files, err := os.ReadDir(dir)
+	assert.NilError(t, err)
+	var names []string
+	for _, f := range files {
+		names = append(names, f.Name())
+	}
+	assert.DeepEqual(t, names, []string{"db.yaml", "web.yaml", "words.yaml"})
+
+	content, err := os.ReadFile(filepath.Join(dir, "web.yaml"))
+	assert.NilError(t, err)
+	web := string(content)
+	assert.Assert(t, strings.Contains(web, "name: "+projectName), web)
+	assert.Assert(t, strings.Contains(web, "image: gtardif/sentences-web"), web)
+	assert.Assert(t, strings.Contains(web, "my-label: test"), web)
+	assert.Assert(t, strings.Contains(web, "name: "+projectName+"_default"), web)
+	assert.Assert(t, !strings.Contains(web, "sentences-db"), web)
+	assert.Assert(t, !strings.Contains(web, "sentences-api"), web)
+
+	res := c.RunDockerComposeCmdNoCheck(t, "-f", "./fixtures/sentences/compose.yaml", "-p", projectName, "convert", "--models")
+	res.Assert(t, icmd.Expected{ExitCode: 1, Err: "--models requires --output-dir"})
+}
+
+func TestConvertSchemaValidation(t *testing.T) {
+	c := NewParallelCLI(t)
+
+	const projectName = "compose-e2e-schema-validation"
+	const file = "./fixtures/schema/unknown-key.yaml"
+
+	t.Run("unknown keys are ignored by default", func(t *testing.T) {
+		res := c.RunDockerComposeCmd(t, "-f", file, "-p", projectName, "convert")
+		res.Assert(t, icmd.Expected{Out: "image: nginx:alpine"})
+		assert.Assert(t, strings.Contains(res.Stderr(), file+": services.web.restart_policy is not defined by the Compose specification"), res.Stderr())
+		assert.Assert(t, strings.Contains(res.Stderr(), file+": tweaks is not defined by the Compose specification"), res.Stderr())
+	})
+
+	t.Run("values are still validated along with unknown keys", func(t *testing.T) {
+		res := c.RunDockerComposeCmdNoCheck(t, "-f", "./fixtures/schema/invalid-value.yaml", "-p", projectName, "convert")
+		res.Assert(t, icmd.Expected{ExitCode: 1, Err: "container_name must be a string"})
+	})
+
+	t.Run("unknown keys are rejected with --strict", func(t *testing.T) {
+		res := c.RunDockerComposeCmdNoCheck(t, "-f", file, "-p", projectName, "convert", "--strict")
+		res.Assert(t, icmd.Expected{ExitCode: 1, Err: "2 unknown key(s) in Compose file:"})
+		assert.Assert(t, strings.Contains(res.Stderr(), file+": services.web.restart_policy\n"), res.Stderr())
+		assert.Assert(t, strings.Contains(res.Stderr(), file+": tweaks"), res.Stderr())
+	})
+
+	t.Run("no validation with --skip-validation", func(t *testing.T) {
+		res := c.RunDockerComposeCmd(t, "-f", file, "-p", projectName, "convert", "--skip-validation")
+		res.Assert(t, icmd.Expected{Out: "image: nginx:alpine"})
+		assert.Assert(t, !strings.Contains(res.Stderr(), "not defined by the Compose specification"), res.Stderr())
+	})
+
+	t.Run("--strict and --skip-validation are incompatible", func(t *testing.T) {
+		res := c.RunDockerComposeCmdNoCheck(t, "-f", file, "-p", projectName, "convert", "--strict", "--skip-validation")
+		res.Assert(t, icmd.Expected{ExitCode: 1, Err: "--strict and --skip-validation are incompatible"})
+	})
+}
+
+func TestConvertEscapedDollarRoundTrip(t *testing.T) {
+	c := NewParallelCLI(t)
+
+	const projectName = "compose-e2e-escaped-dollar"
+	res := c.RunDockerComposeCmd(t, "-f", "./fixtures/environment/escaped-dollar/compose.yaml", "-p", projectName, "convert")
+	res.Assert(t, icmd.Expected{Out: "PASSWORD: pa$$word"})
+	converted := res.Stdout()
+
+	file := filepath.Join(t.TempDir(), "compose.yaml")
+	assert.NilError(t, os.WriteFile(file, []byte(converted), 0o644))
+	res = c.RunDockerComposeCmd(t, "-f", file, "-p", projectName, "convert")
+	assert.Equal(t, res.Stdout(), converted)
+
+	res = c.RunDockerComposeCmd(t, "-f", "./fixtures/environment/escaped-dollar/compose.yaml", "-p", projectName,
+		"convert", "--no-interpolate")
+	res.Assert(t, icmd.Expected{Out: "PASSWORD: pa$$word"})
+	assert.Assert(t, !strings.Contains(res.Stdout(), "$$$$"), res.Stdout())
+}
+
+func TestConvertTemplate(t *testing.T) {
+	c := NewParallelCLI(t)
+
+	res := c.RunDockerComposeCmd(t, "-f", "./fixtures/sentences/compose.yaml", "-p", "compose-e2e-convert-template",
+		"convert", "--format", `{{range .Services}}{{.Name}}={{.Image}}{{"\n"}}{{end}}`)
+	lines := Lines(res.Stdout())
+	sort.Strings(lines)
+	assert.DeepEqual(t, lines, []string{
+		"db=gtardif/sentences-db",
+		"web=gtardif/sentences-web",
+		"words=gtardif/sentences-api",
+	})
+
+	res = c.RunDockerComposeCmdNoCheck(t, "-f", "./fixtures/sentences/compose.yaml", "convert", "--format", "{{.Unknown}}")
+	res.Assert(t, icmd.Expected{ExitCode: 1, Err: "can't evaluate field Unknown"})
+}
+
+func TestProgressPlain(t *testing.T) {
+	c := NewParallelCLI(t)
+
+	const projectName = "e2e-progress-plain"
+
+	res := c.RunDockerComposeCmd(t, "--progress", "plain", "--project-directory", "fixtures/simple-composefile", "-p", projectName, "up", "-d")
+	defer c.RunDockerComposeCmd(t, "-p", projectName, "down")
+	assert.Equal(t, strings.Count(res.Stderr(), "Container "+projectName+"-simple-1  Started"), 1, res.Combined())
+	assert.Assert(t, !strings.Contains(res.Stderr(), "\x1b["), res.Combined())
+
+	res = c.RunDockerComposeCmdNoCheck(t, "--progress", "unknown", "-p", projectName, "ps")
+	res.Assert(t, icmd.Expected{ExitCode: 1, Err: `unsupported --progress value "unknown"`})
+}
+
+func TestUpWaitSelectedServices(t *testing.T) {
+	c := NewParallelCLI(t)
+
+	const projectName = "compose-e2e-wait-services"
+	t.Cleanup(func() {
+		c.RunDockerComposeCmd(t, "--project-name", projectName, "down", "-t", "0")
+	})
+
+	start := time.Now()
+	c.RunDockerComposeCmd(t, "-f", "./fixtures/wait/compose.yaml", "--project-name", projectName, "up", "-d", "--wait", "web")
+	assert.Assert(t, time.Since(start) < 60*time.Second, "up --wait web should not wait for db to be healthy")
+
+	res := c.RunDockerComposeCmd(t, "--project-name", projectName, "ps", "--format", "{{.Service}}={{.Health}}")
+	assert.DeepEqual(t, Lines(res.Stdout()), []string{
+		"db=starting",
+		"web=healthy",
+	})
+}
+
+func TestUpWaitTimeout(t *testing.T) {
+	c := NewParallelCLI(t)
+
+	const projectName = "compose-e2e-wait-timeout"
+	t.Cleanup(func() {
+		c.RunDockerComposeCmd(t, "--project-name", projectName, "down", "-t", "0")
+	})
+
+	start := time.Now()
+	res := c.RunDockerComposeCmdNoCheck(t, "-f", "./fixtures/wait/compose.yaml", "--project-name", projectName,
+		"up", "--wait", "--wait-timeout", "3", "--wait-log-lines", "0")
+	res.Assert(t, icmd.Expected{ExitCode: 1, Err: "timeout after 3s waiting for services to be running|healthy"})
+	assert.Assert(t, time.Since(start) < 30*time.Second, "up --wait-timeout 3 should not wait for db to be healthy")
+}
+
+func TestUpWaitFailureLogs(t *testing.T) {
+	c := NewParallelCLI(t)
+
+	const projectName = "compose-e2e-wait-failure-logs"
+	t.Cleanup(func() {
+		c.RunDockerComposeCmd(t, "--project-name", projectName, "down", "-t", "0")
+	})
+
+	res := c.RunDockerComposeCmdNoCheck(t, "-f", "./fixtures/wait/unhealthy.yaml", "--project-name", projectName,
+		"up", "--wait", "--wait-log-lines", "3")
+	res.Assert(t, icmd.Expected{ExitCode: 1, Err: `container for service "web" is unhealthy`})
+	assert.Assert(t, strings.Contains(res.Stderr(), "web-1  | cannot reach backend"), res.Stderr())
+	assert.Assert(t, strings.Contains(res.Stderr(), "web-1  | line 4"), res.Stderr())
+	// only the requested number of lines, and only for the failing service
+	assert.Assert(t, !strings.Contains(res.Stderr(), "line 3"), res.Stderr())
+	assert.Assert(t, !strings.Contains(res.Stderr(), "db ready"), res.Stderr())
+
+	res = c.RunDockerComposeCmdNoCheck(t, "-f", "./fixtures/wait/unhealthy.yaml", "--project-name", projectName,
+		"up", "--wait", "--wait-log-lines", "0")
+	res.Assert(t, icmd.Expected{ExitCode: 1, Err: `container for service "web" is unhealthy`})
+	assert.Assert(t, !strings.Contains(res.Stderr(), "cannot reach backend"), res.Stderr())
+}
+
+func TestUpNoHealthcheck(t *testing.T) {
+	// sentences fixture publishes fixed ports, don't run in parallel with other tests using it
+	c := NewCLI(t)
+
+	const projectName = "compose-e2e-no-healthcheck"
+	t.Cleanup(func() {
+		c.RunDockerComposeCmd(t, "--project-name", projectName, "down", "-t", "0")
+	})
+
+	c.RunDockerComposeCmd(t, "-f", "./fixtures/sentences/compose.yaml", "--project-name", projectName, "up", "-d", "--no-healthcheck", "web")
+
+	res := c.RunDockerCmd(t, "inspect", "--format", "{{if .State.Health}}{{.State.Health.Status}}{{else}}none{{end}}", projectName+"-web-1")
+	assert.Equal(t, strings.TrimSpace(res.Stdout()), "none")
+
+	res = c.RunDockerComposeCmd(t, "--project-name", projectName, "ps", "--format", "{{.Service}}={{.Health}}")
+	assert.DeepEqual(t, Lines(res.Stdout()), []string{"web="})
+}
+
+func TestUpPrintCommand(t *testing.T) {
+	c := NewParallelCLI(t)
+
+	const projectName = "compose-e2e-print-command"
+	t.Cleanup(func() {
+		c.RunDockerComposeCmd(t, "--project-name", projectName, "down", "-t", "0")
+	})
+
+	res := c.RunDockerComposeCmd(t, "--project-directory", "fixtures/simple-composefile", "--project-name", projectName, "up", "-d", "--print-command")
+	output := res.Stdout()
+	var networkCreate string
+	for _, line := range Lines(output) {
+		if strings.HasPrefix(line, "docker network create") {
+			networkCreate = line
+		}
+	}
+	assert.Assert(t, strings.Contains(networkCreate, "--label com.docker.compose.project="+projectName), output)
+	assert.Assert(t, strings.HasSuffix(networkCreate, " "+projectName+"_default"), output)
+	for _, service := range []string{"simple", "another"} {
+		container := projectName + "-" + service + "-1"
+		assert.Assert(t, strings.Contains(output, "docker container create --name "+container), output)
+		assert.Assert(t, strings.Contains(output, "docker container start "+container), output)
+	}
+	assert.Assert(t, strings.Contains(output, "--label com.docker.compose.service=simple"), output)
+
+	// commands don't replace the actual operations
+	res = c.RunDockerComposeCmd(t, "--project-name", projectName, "ps", "--services", "--status", "running")
+	services := Lines(res.Stdout())
+	sort.Strings(services)
+	assert.DeepEqual(t, services, []string{"another", "simple"})
+}
+
+func TestUpRecreateOnLabelChange(t *testing.T) {
+	c := NewParallelCLI(t)
+
+	const projectName = "compose-e2e-recreate-on-label"
+	t.Cleanup(func() {
+		c.RunDockerComposeCmd(t, "--project-name", projectName, "down", "-t", "0")
+	})
+
+	up := func(env []string, args ...string) string {
+		cmd := c.NewDockerComposeCmd(t, append([]string{"-f", "./fixtures/recreate-on-label/compose.yaml",
+			"--project-name", projectName, "up", "-d"}, args...)...)
+		cmd.Env = append(cmd.Env, env...)
+		res := icmd.RunCmd(cmd)
+		res.Assert(t, icmd.Success)
+		return res.Combined()
+	}
+	containerID := func() string {
+		return strings.TrimSpace(c.RunDockerComposeCmd(t, "--project-name", projectName, "ps", "-q").Stdout())
+	}
+
+	up(nil)
+	id := containerID()
+
+	t.Run("other configuration change is ignored", func(t *testing.T) {
+		output := up([]string{"LEVEL=debug"}, "--recreate-on-label-change")
+		assert.Assert(t, !strings.Contains(output, "Recreated"), output)
+		assert.Equal(t, containerID(), id)
+	})
+
+	t.Run("label change recreates", func(t *testing.T) {
+		output := up([]string{"RELEASE=2"}, "--recreate-on-label-change")
+		assert.Assert(t, strings.Contains(output, "Container "+projectName+"-app-1  Recreated"), output)
+		id = containerID()
+		res := c.RunDockerCmd(t, "inspect", "--format", `{{ index .Config.Labels "com.example.release" }}`, id)
+		assert.Equal(t, strings.TrimSpace(res.Stdout()), "2")
+	})
+
+	t.Run("any configuration change recreates without the flag", func(t *testing.T) {
+		env := []string{"RELEASE=2", "LEVEL=debug"}
+		output := up(env, "--recreate-on-label-change")
+		assert.Assert(t, !strings.Contains(output, "Recreated"), output)
+		output = up(env)
+		assert.Assert(t, strings.Contains(output, "Recreated"), output)
+	})
+}
+
+func TestUpRecreateRolling(t *testing.T) {
+	c := NewParallelCLI(t)
+
+	const projectName = "compose-e2e-recreate-rolling"
+	t.Cleanup(func() {
+		c.RunDockerComposeCmd(t, "--project-name", projectName, "down", "-t", "0")
+	})
+
+	c.RunDockerComposeCmd(t, "-f", "./fixtures/recreate-rolling/compose.yaml", "--project-name", projectName, "up", "-d", "--wait")
+
+	cmd := c.NewDockerComposeCmd(t, "-f", "./fixtures/recreate-rolling/compose.yaml", "--project-name", projectName,
+		"--progress", "plain", "up", "-d", "--recreate-strategy", "rolling")
+	cmd.Env = append(cmd.Env, "VERSION=2")
+	res := icmd.RunCmd(cmd)
+	res.Assert(t, icmd.Success)
+	output := res.Combined()
+	for i := 1; i <= 3; i++ {
+		name := fmt.Sprintf("Container %s-web-%d", projectName, i)
+		assert.Assert(t, strings.Contains(output, name+"  Recreated"), output)
+		assert.Assert(t, strings.Contains(output, name+"  Healthy"), output)
+	}
+
+	res = c.RunDockerComposeCmd(t, "--project-name", projectName, "ps", "--format", "{{.Name}}={{.Health}}")
+	assert.DeepEqual(t, Lines(res.Stdout()), []string{
+		projectName + "-web-1=healthy",
+		projectName + "-web-2=healthy",
+		projectName + "-web-3=healthy",
+	})
+	for i := 1; i <= 3; i++ {
+		res = c.RunDockerComposeCmd(t, "--project-name", projectName, "exec", "--index", strconv.Itoa(i), "web", "printenv", "VERSION")
+		assert.Equal(t, strings.TrimSpace(res.Stdout()), "2")
+	}
+}
+
+func TestUpMissingRequiredVariables(t *testing.T) {
+	c := NewParallelCLI(t)
+
+	const projectName = "compose-e2e-required-variables"
+	t.Cleanup(func() {
+		c.RunDockerComposeCmd(t, "--project-name", projectName, "down", "-t", "0")
+	})
+
+	res := c.RunDockerComposeCmdNoCheck(t, "-f", "./fixtures/required-variables/compose.yaml", "--project-name", projectName, "up", "-d")
+	res.Assert(t, icmd.Expected{ExitCode: 1, Err: "2 required variable(s) not set"})
+	assert.Assert(t, strings.Contains(res.Stderr(), "required variable API_TOKEN is missing a value: API_TOKEN must be set"), res.Stderr())
+	assert.Assert(t, strings.Contains(res.Stderr(), "required variable DB_PASSWORD is missing a value: DB_PASSWORD must be set"), res.Stderr())
+
+	// nothing was created
+	res = c.RunDockerCmd(t, "ps", "--all", "--filter", "label=com.docker.compose.project="+projectName, "--quiet")
+	assert.Equal(t, strings.TrimSpace(res.Stdout()), "")
+	res = c.RunDockerCmd(t, "network", "ls", "--filter", "label=com.docker.compose.project="+projectName, "--quiet")
+	assert.Equal(t, strings.TrimSpace(res.Stdout()), "")
+}
+
+func TestLogLevel(t *testing.T) {
+	c := NewParallelCLI(t)
+	const projectName = "e2e-log-level"
+	t.Cleanup(func() {
+		c.RunDockerComposeCmd(t, "--project-name", projectName, "down", "-t", "0")
+	})
+
+	c.RunDockerComposeCmd(t, "-f", "./fixtures/log-level/compose.yaml", "--project-name", projectName, "up", "-d")
+
+	res := c.RunDockerComposeCmdNoCheck(t, "--log-level", "verbose", "--project-name", projectName, "ps")
+	res.Assert(t, icmd.Expected{ExitCode: 1, Err: `unsupported --log-level value "verbose"`})
+
+	cmd := c.NewDockerComposeCmd(t, "--log-level", "debug", "-f", "./fixtures/log-level/compose.yaml",
+		"--project-name", projectName, "up", "-d", "-t", "0")
+	cmd.Env = append(cmd.Env, "VALUE=two")
+	res = icmd.RunCmd(cmd)
+	res.Assert(t, icmd.Success)
+	assert.Assert(t, strings.Contains(res.Stderr(), `msg="container recreated: configuration changed"`), res.Stderr())
+	assert.Assert(t, strings.Contains(res.Stderr(), "service=web"), res.Stderr())
+	assert.Assert(t, strings.Contains(res.Stderr(), `msg="engine API call"`), res.Stderr())
+	assert.Assert(t, !strings.Contains(res.Stdout(), "level=debug"), res.Stdout())
+
+	res = c.RunDockerComposeCmd(t, "-f", "./fixtures/log-level/compose.yaml", "--project-name", projectName, "up", "-d")
+	assert.Assert(t, !strings.Contains(res.Stderr(), "level=debug"), res.Stderr())
+}
+
+func TestConvertHash(t *testing.T) {
+	c := NewParallelCLI(t)
+	const projectName = "e2e-config-hash"
+	t.Cleanup(func() {
+		c.RunDockerComposeCmd(t, "--project-name", projectName, "down", "-t", "0")
+	})
+
+	c.RunDockerComposeCmd(t, "-f", "./fixtures/config-hash/compose.yaml", "--project-name", projectName, "up", "-d")
+
+	res := c.RunDockerComposeCmd(t, "-f", "./fixtures/config-hash/compose.yaml", "--project-name", projectName,
+		"convert", "--hash", "*")
+	lines := Lines(res.Stdout())
+	assert.Equal(t, len(lines), 2, res.Stdout())
+	for _, line := range lines {
+		service, hash, _ := strings.Cut(line, " ")
+		res = c.RunDockerCmd(t, "inspect", "--format", `{{ index .Config.Labels "com.docker.compose.config-hash" }}`,
+			projectName+"-"+service+"-1")
+		assert.Equal(t, strings.TrimSpace(res.Stdout()), hash, service)
+	}
+
+	// dependencies of the selected services are not printed
+	res = c.RunDockerComposeCmd(t, "-f", "./fixtures/config-hash/compose.yaml", "--project-name", projectName,
+		"convert", "--hash", "app")
+	lines = Lines(res.Stdout())
+	assert.Equal(t, len(lines), 1, res.Stdout())
+	assert.Assert(t, strings.HasPrefix(lines[0], "app "), res.Stdout())
+}
+
+func TestUpProfilesOfDependencies(t *testing.T) {
+	c := NewParallelCLI(t)
+	const projectName = "e2e-profiles-dependencies"
+	const file = "./fixtures/profiles/dependencies.yaml"
+	t.Cleanup(func() {
+		c.RunDockerComposeCmd(t, "-f", file, "--project-name", projectName, "--profile", "frontend", "--profile", "storage", "--profile", "debug", "down", "-t", "0")
+	})
+
+	res := c.RunDockerComposeCmd(t, "-f", file, "--project-name", projectName, "config", "--profiles")
+	assert.DeepEqual(t, Lines(res.Stdout()), []string{"debug", "frontend", "storage"})
+
+	// the profiles of the targeted service and of its dependencies are enabled
+	c.RunDockerComposeCmd(t, "-f", file, "--project-name", projectName, "up", "-d", "web")
+	res = c.RunDockerComposeCmd(t, "--project-name", projectName, "ps", "--services", "--status", "running")
+	services := Lines(res.Stdout())
+	sort.Strings(services)
+	assert.DeepEqual(t, services, []string{"db", "web"})
+
+	cmd := c.NewDockerComposeCmd(t, "-f", file, "--project-name", projectName, "up", "-d")
+	cmd.Env = append(cmd.Env, "COMPOSE_PROFILES=debug")
+	icmd.RunCmd(cmd).Assert(t, icmd.Success)
+	res = c.RunDockerComposeCmd(t, "--project-name", projectName, "ps", "--services", "--status", "running")
+	services = Lines(res.Stdout())
+	sort.Strings(services)
+	assert.DeepEqual(t, services, []string{"db", "debugger", "web"})
 }
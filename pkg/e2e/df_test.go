@@ -0,0 +1,73 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package e2e
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/icmd"
+)
+
+func TestDiskUsage(t *testing.T) {
+	c := NewParallelCLI(t)
+	const projectName = "e2e-df"
+
+	t.Cleanup(func() {
+		c.RunDockerComposeCmd(t, "--project-name", projectName, "down", "-v")
+	})
+
+	c.RunDockerComposeCmd(t, "-f", "./fixtures/df/compose.yaml", "--project-name", projectName, "up", "-d")
+	// wait for the volume to be filled
+	c.WaitForCmdResult(t, c.NewDockerCmd(t, "exec", projectName+"-db-1", "test", "-f", "/data/blob"),
+		func(res *icmd.Result) bool { return res.ExitCode == 0 }, 10*time.Second, 500*time.Millisecond)
+
+	t.Run("table", func(t *testing.T) {
+		res := c.RunDockerComposeCmd(t, "--project-name", projectName, "alpha", "df")
+		out := res.Stdout()
+		assert.Assert(t, strings.Contains(out, "alpine:latest"), out)
+		assert.Assert(t, strings.Contains(out, projectName+"-db-1"), out)
+		assert.Assert(t, strings.Contains(out, projectName+"_data"), out)
+		assert.Assert(t, strings.Contains(out, "TOTAL"), out)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		res := c.RunDockerComposeCmd(t, "--project-name", projectName, "alpha", "df", "--format", "json")
+		var usage struct {
+			Resources []struct {
+				Type string
+				Name string
+				Size int64
+			}
+			Total int64
+		}
+		assert.NilError(t, json.Unmarshal([]byte(res.Stdout()), &usage), res.Stdout())
+
+		sizes := map[string]int64{}
+		var sum int64
+		for _, r := range usage.Resources {
+			sizes[r.Type+" "+r.Name] = r.Size
+			sum += r.Size
+		}
+		assert.Assert(t, sizes["image alpine:latest"] > 0, res.Stdout())
+		assert.Assert(t, sizes["volume "+projectName+"_data"] > 0, res.Stdout())
+		assert.Equal(t, usage.Total, sum)
+	})
+}
@@ -0,0 +1,104 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package e2e
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/icmd"
+)
+
+func TestDiff(t *testing.T) {
+	c := NewParallelCLI(t)
+	const projectName = "e2e-diff"
+
+	t.Cleanup(func() {
+		c.RunDockerComposeCmd(t, "--project-name", projectName, "down", "-v", "-t", "0")
+	})
+
+	compose := func(env []string, args ...string) *icmd.Result {
+		cmd := c.NewDockerComposeCmd(t, append([]string{"-f", "./fixtures/diff/compose.yaml",
+			"--project-name", projectName}, args...)...)
+		cmd.Env = append(cmd.Env, env...)
+		res := icmd.RunCmd(cmd)
+		res.Assert(t, icmd.Success)
+		return res
+	}
+
+	t.Run("nothing running", func(t *testing.T) {
+		out := compose(nil, "alpha", "diff").Stdout()
+		assert.Assert(t, strings.Contains(out, "app"), out)
+		assert.Assert(t, strings.Contains(out, "create"), out)
+		assert.Assert(t, strings.Contains(out, projectName+"_default"), out)
+		assert.Assert(t, strings.Contains(out, projectName+"_data"), out)
+	})
+
+	compose(nil, "up", "-d")
+
+	t.Run("up to date", func(t *testing.T) {
+		res := compose(nil, "alpha", "diff", "--format", "json")
+		var changes []struct{}
+		assert.NilError(t, json.Unmarshal([]byte(res.Stdout()), &changes), res.Stdout())
+		assert.Equal(t, len(changes), 0, res.Stdout())
+	})
+
+	t.Run("environment change", func(t *testing.T) {
+		res := compose([]string{"GREETING=bonjour"}, "alpha", "diff", "--format", "json")
+		var changes []struct {
+			Type   string
+			Name   string
+			Action string
+			Fields []string
+		}
+		assert.NilError(t, json.Unmarshal([]byte(res.Stdout()), &changes), res.Stdout())
+		assert.Equal(t, len(changes), 1, res.Stdout())
+		assert.Equal(t, changes[0].Type, "service")
+		assert.Equal(t, changes[0].Name, "app")
+		assert.Equal(t, changes[0].Action, "recreate")
+		assert.DeepEqual(t, changes[0].Fields, []string{"environment.GREETING"})
+
+		out := compose([]string{"GREETING=bonjour"}, "alpha", "diff").Stdout()
+		assert.Assert(t, strings.Contains(out, "environment.GREETING"), out)
+	})
+
+	t.Run("inactive profile", func(t *testing.T) {
+		compose(nil, "--profile", "debug", "up", "-d", "debug")
+		out := compose(nil, "alpha", "diff").Stdout()
+		assert.Assert(t, !strings.Contains(out, "debug"), out)
+	})
+
+	t.Run("orphans", func(t *testing.T) {
+		res := c.RunDockerComposeCmd(t, "-f", "./fixtures/diff/renamed.yaml", "--project-name", projectName,
+			"alpha", "diff", "--format", "json")
+		var changes []struct {
+			Type   string
+			Name   string
+			Action string
+		}
+		assert.NilError(t, json.Unmarshal([]byte(res.Stdout()), &changes), res.Stdout())
+		actions := map[string]string{}
+		for _, change := range changes {
+			actions[change.Name] = change.Action
+		}
+		assert.Equal(t, actions["application"], "create", res.Stdout())
+		assert.Equal(t, actions["app"], "orphan", res.Stdout())
+		assert.Equal(t, actions["debug"], "orphan", res.Stdout())
+	})
+}
@@ -0,0 +1,81 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package e2e
+
+import (
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/icmd"
+)
+
+func TestDryRun(t *testing.T) {
+	c := NewParallelCLI(t)
+	const projectName = "e2e-dry-run"
+
+	t.Cleanup(func() {
+		c.RunDockerComposeCmd(t, "--project-name", projectName, "down", "-v")
+	})
+
+	t.Run("dry run up", func(t *testing.T) {
+		res := c.RunDockerComposeCmd(t, "--dry-run", "-f", "./fixtures/dry-run/compose.yaml", "--project-name", projectName, "up")
+		out := res.Combined()
+		assert.Assert(t, strings.Contains(out, "DRY-RUN Network e2e-dry-run_front  Created"), out)
+		assert.Assert(t, strings.Contains(out, "DRY-RUN Volume \"e2e-dry-run_data\"  Created"), out)
+		assert.Assert(t, strings.Contains(out, "DRY-RUN Container e2e-dry-run-db-1  Started"), out)
+		assert.Assert(t, strings.Contains(out, "DRY-RUN Container e2e-dry-run-web-1  Started"), out)
+
+		res = c.RunDockerCmd(t, "ps", "--all", "--filter", "label=com.docker.compose.project="+projectName, "--quiet")
+		assert.Equal(t, strings.TrimSpace(res.Stdout()), "")
+		res = c.RunDockerCmd(t, "network", "ls", "--filter", "label=com.docker.compose.project="+projectName, "--quiet")
+		assert.Equal(t, strings.TrimSpace(res.Stdout()), "")
+		res = c.RunDockerCmd(t, "volume", "ls", "--filter", "label=com.docker.compose.project="+projectName, "--quiet")
+		assert.Equal(t, strings.TrimSpace(res.Stdout()), "")
+	})
+
+	t.Run("dry run down", func(t *testing.T) {
+		c.RunDockerComposeCmd(t, "-f", "./fixtures/dry-run/compose.yaml", "--project-name", projectName, "up", "-d")
+
+		res := c.RunDockerComposeCmd(t, "--dry-run", "-f", "./fixtures/dry-run/compose.yaml", "--project-name", projectName, "down", "-v")
+		out := res.Combined()
+		assert.Assert(t, strings.Contains(out, "DRY-RUN Container e2e-dry-run-web-1  Removed"), out)
+		assert.Assert(t, strings.Contains(out, "DRY-RUN Network e2e-dry-run_back  Removed"), out)
+
+		res = c.RunDockerComposeCmd(t, "--project-name", projectName, "ps", "--services", "--status", "running")
+		assert.Equal(t, strings.TrimSpace(res.Stdout()), "db\nweb")
+	})
+
+	t.Run("dry run run", func(t *testing.T) {
+		res := c.RunDockerComposeCmd(t, "--dry-run", "-f", "./fixtures/dry-run/compose.yaml", "--project-name", projectName, "run", "--rm", "db", "echo", "hello")
+		out := res.Combined()
+		assert.Assert(t, strings.Contains(out, "DRY-RUN Container e2e-dry-run_db_run_"), out)
+		assert.Assert(t, strings.Contains(out, "Started"), out)
+		assert.Assert(t, !strings.Contains(res.Stdout(), "hello"), out)
+
+		res = c.RunDockerCmd(t, "ps", "--all", "--filter", "label=com.docker.compose.project="+projectName, "--filter", "label=com.docker.compose.oneoff=True", "--quiet")
+		assert.Equal(t, strings.TrimSpace(res.Stdout()), "")
+	})
+
+	t.Run("dry run exec", func(t *testing.T) {
+		res := c.RunDockerComposeCmd(t, "--dry-run", "--project-name", projectName, "exec", "db", "touch", "/tmp/dry-run")
+		assert.Assert(t, strings.Contains(res.Stderr(), `Would run "touch /tmp/dry-run" in container e2e-dry-run-db-1`), res.Stderr())
+
+		res = c.RunDockerComposeCmdNoCheck(t, "--project-name", projectName, "exec", "db", "test", "-e", "/tmp/dry-run")
+		res.Assert(t, icmd.Expected{ExitCode: 1})
+	})
+}
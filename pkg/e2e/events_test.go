@@ -0,0 +1,78 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package e2e
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/icmd"
+)
+
+func TestEventsFilter(t *testing.T) {
+	c := NewParallelCLI(t)
+	const projectName = "e2e-events-filter"
+
+	cmd := c.NewDockerComposeCmd(t, "-f", "./fixtures/events/compose.yaml", "--project-name", projectName,
+		"events", "--filter", "event=health_status", "--filter", "service=web")
+	res := icmd.StartCmd(cmd)
+	t.Cleanup(func() {
+		_ = res.Cmd.Process.Kill()
+		c.RunDockerComposeCmd(t, "--project-name", projectName, "down")
+	})
+
+	c.RunDockerComposeCmd(t, "-f", "./fixtures/events/compose.yaml", "--project-name", projectName, "up", "-d")
+
+	c.WaitForCondition(t, func() (bool, string) {
+		return strings.Contains(res.Stdout(), "health_status: healthy"), res.Combined()
+	}, 30*time.Second, 1*time.Second)
+
+	for _, line := range Lines(res.Stdout()) {
+		assert.Assert(t, strings.Contains(line, "container health_status"), res.Stdout())
+		assert.Assert(t, strings.Contains(line, projectName+"-web-1"), res.Stdout())
+	}
+}
+
+func TestEventsFormat(t *testing.T) {
+	c := NewParallelCLI(t)
+	const projectName = "e2e-events-format"
+
+	res := c.RunDockerComposeCmdNoCheck(t, "-f", "./fixtures/events/compose.yaml", "--project-name", projectName,
+		"events", "--format", "{{.Service")
+	res.Assert(t, icmd.Expected{ExitCode: 1, Err: "invalid format template"})
+
+	cmd := c.NewDockerComposeCmd(t, "-f", "./fixtures/events/compose.yaml", "--project-name", projectName,
+		"events", "--filter", "event=start", "--format", "{{.Service}} {{.Action}}")
+	res = icmd.StartCmd(cmd)
+	t.Cleanup(func() {
+		_ = res.Cmd.Process.Kill()
+		c.RunDockerComposeCmd(t, "--project-name", projectName, "down")
+	})
+
+	c.RunDockerComposeCmd(t, "-f", "./fixtures/events/compose.yaml", "--project-name", projectName, "up", "-d")
+
+	c.WaitForCondition(t, func() (bool, string) {
+		out := res.Stdout()
+		return strings.Contains(out, "web start") && strings.Contains(out, "db start"), res.Combined()
+	}, 30*time.Second, 1*time.Second)
+
+	for _, line := range Lines(res.Stdout()) {
+		assert.Assert(t, line == "web start" || line == "db start", res.Stdout())
+	}
+}
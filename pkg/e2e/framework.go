@@ -324,11 +324,15 @@ func (c *CLI) WaitForCmdResult(
 	t.Helper()
 	assert.Assert(t, timeout.Nanoseconds() > delay.Nanoseconds(), "timeout must be greater than delay")
 	var res *icmd.Result
+	cmd := strings.Join(command.Command, " ")
+	start := time.Now()
 	checkStopped := func(logt poll.LogT) poll.Result {
-		fmt.Printf("\t[%s] %s\n", t.Name(), strings.Join(command.Command, " "))
+		fmt.Printf("\t[%s] %s\n", t.Name(), cmd)
 		res = icmd.RunCmd(command)
 		if !predicate(res) {
-			return poll.Continue("Cmd output did not match requirement: %q", res.Combined())
+			// on timeout, poll reports the last message, so it holds the full output of the last attempt
+			return poll.Continue("`%s` output did not match requirement after %s, last attempt exited with code %d and output:\n%s",
+				cmd, time.Since(start).Round(time.Millisecond), res.ExitCode, res.Combined())
 		}
 		return poll.Success()
 	}
@@ -0,0 +1,39 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package e2e
+
+import (
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestLintVersion(t *testing.T) {
+	c := NewParallelCLI(t)
+
+	res := c.RunDockerComposeCmd(t, "-f", "./fixtures/lint-version/compose.yaml", "alpha", "lint-version")
+	out := res.Stdout()
+	assert.Assert(t, strings.Contains(out, "version: `version` is obsolete"), out)
+	assert.Assert(t, strings.Contains(out, "remove `version: \"2\"`, it is ignored"), out)
+	assert.Assert(t, strings.Contains(out, "services.web.links: `links` is legacy"), out)
+	assert.Assert(t, strings.Contains(out,
+		"replace `links` with `depends_on: [db]` to keep the start order, and add `database` to the network `aliases` of db"), out)
+
+	res = c.RunDockerComposeCmd(t, "-f", "./fixtures/simple-composefile/compose.yaml", "alpha", "lint-version")
+	assert.Equal(t, res.Stdout(), "")
+}
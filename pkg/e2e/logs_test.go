@@ -17,8 +17,13 @@
 package e2e
 
 import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"gotest.tools/v3/assert"
 
@@ -52,7 +57,182 @@ func TestLocalComposeLogs(t *testing.T) {
 		res.Assert(t, icmd.Expected{Out: `hello`})
 	})
 
+	t.Run("logs --grep", func(t *testing.T) {
+		res := c.RunDockerComposeCmd(t, "--project-name", projectName, "logs", "--no-color", "--grep", "^hel+o$")
+		assert.DeepEqual(t, Lines(res.Stdout()), []string{"hello-1  | hello"})
+
+		res = c.RunDockerComposeCmd(t, "--project-name", projectName, "logs", "--no-color", "--grep", "PING", "--grep-invert")
+		res.Assert(t, icmd.Expected{Out: "hello-1  | hello"})
+		assert.Assert(t, !strings.Contains(res.Stdout(), "PING"), res.Stdout())
+	})
+
+	t.Run("logs follow with tail 0", func(t *testing.T) {
+		res := icmd.StartCmd(c.NewDockerComposeCmd(t, "--project-name", projectName, "logs", "--follow", "--tail", "0", "hello"))
+		t.Cleanup(func() {
+			_ = res.Cmd.Process.Kill()
+		})
+		time.Sleep(time.Second)
+		assert.Assert(t, !strings.Contains(res.Stdout(), "| hello"), res.Combined())
+
+		c.RunDockerComposeCmd(t, "--project-name", projectName, "start", "hello")
+
+		c.WaitForCondition(t, func() (bool, string) {
+			return strings.Count(res.Stdout(), "| hello") == 1, res.Combined()
+		}, 10*time.Second, time.Second)
+	})
+
 	t.Run("down", func(t *testing.T) {
 		_ = c.RunDockerComposeCmd(t, "--project-name", projectName, "down")
 	})
 }
+
+func TestLogsIndex(t *testing.T) {
+	c := NewParallelCLI(t)
+
+	const projectName = "compose-e2e-logs-index"
+	t.Cleanup(func() {
+		c.RunDockerComposeCmd(t, "--project-name", projectName, "down")
+	})
+
+	c.RunDockerComposeCmd(t, "-f", "./fixtures/logs-output-dir/compose.yaml", "--project-name", projectName, "up")
+
+	res := c.RunDockerComposeCmd(t, "--project-name", projectName, "logs", "--no-color", "--index", "2", "scaled")
+	assert.DeepEqual(t, Lines(res.Stdout()), []string{"scaled-2  | scaled"})
+
+	res = c.RunDockerComposeCmdNoCheck(t, "--project-name", projectName, "logs", "--index", "3", "scaled")
+	res.Assert(t, icmd.Expected{ExitCode: 1, Err: "no container found with index 3"})
+}
+
+func TestLogsOutputDir(t *testing.T) {
+	c := NewParallelCLI(t)
+
+	const projectName = "compose-e2e-logs-output-dir"
+	t.Cleanup(func() {
+		c.RunDockerComposeCmd(t, "--project-name", projectName, "down")
+	})
+
+	c.RunDockerComposeCmd(t, "-f", "./fixtures/logs-output-dir/compose.yaml", "--project-name", projectName, "up")
+
+	dir := t.TempDir()
+	c.RunDockerComposeCmd(t, "--project-name", projectName, "logs", "--output-dir", dir)
+
+	for file, expected := range map[string]string{
+		"single.log":   "single",
+		"scaled-1.log": "scaled",
+		"scaled-2.log": "scaled",
+	} {
+		content, err := os.ReadFile(filepath.Join(dir, file))
+		assert.NilError(t, err)
+		assert.Equal(t, strings.TrimSpace(string(content)), expected)
+	}
+	_, err := os.Stat(filepath.Join(dir, "scaled.log"))
+	assert.Assert(t, os.IsNotExist(err))
+}
+
+func TestLogsPrefixWidth(t *testing.T) {
+	c := NewParallelCLI(t)
+	const projectName = "e2e-logs-prefix-width"
+	t.Cleanup(func() {
+		c.RunDockerComposeCmd(t, "--project-name", projectName, "down")
+	})
+
+	c.RunDockerComposeCmd(t, "-f", "./fixtures/logs-prefix-width/compose.yaml", "--project-name", projectName, "up", "-d")
+	c.WaitForCmdResult(t, c.NewDockerComposeCmd(t, "--project-name", projectName, "ps", "-a", "--status", "exited", "--services"),
+		func(res *icmd.Result) bool { return len(Lines(res.Stdout())) == 2 }, 10*time.Second, time.Second)
+
+	res := c.RunDockerComposeCmd(t, "--project-name", projectName, "logs", "--no-color", "--prefix-width", "50")
+	lines := Lines(res.Stdout())
+	assert.Equal(t, len(lines), 2, res.Stdout())
+	for _, line := range lines {
+		// prefixes are padded to 50 characters, followed by " | "
+		assert.Equal(t, strings.Index(line, "|"), 51, line)
+	}
+
+	res = c.RunDockerComposeCmdNoCheck(t, "--project-name", projectName, "logs", "--prefix-width", "-1")
+	res.Assert(t, icmd.Expected{ExitCode: 1, Err: "invalid --prefix-width -1"})
+}
+
+func TestLogsFormatJSON(t *testing.T) {
+	// sentences services publish fixed ports
+	c := NewCLI(t)
+	const projectName = "e2e-logs-json"
+	t.Cleanup(func() {
+		c.RunDockerComposeCmd(t, "--project-name", projectName, "down")
+	})
+
+	c.RunDockerComposeCmd(t, "-f", "./fixtures/sentences/compose.yaml", "--project-name", projectName, "up", "-d", "--wait")
+
+	type logLine struct {
+		Service   string `json:"service"`
+		Container string `json:"container"`
+		Message   string `json:"message"`
+		Timestamp string `json:"timestamp"`
+	}
+	parse := func(t *testing.T, out string) []logLine {
+		var lines []logLine
+		for _, l := range Lines(out) {
+			if l == "" {
+				continue
+			}
+			var line logLine
+			assert.NilError(t, json.Unmarshal([]byte(l), &line), l)
+			lines = append(lines, line)
+		}
+		return lines
+	}
+
+	t.Run("logs --format json", func(t *testing.T) {
+		c.WaitForCmdResult(t, c.NewDockerComposeCmd(t, "--project-name", projectName, "logs", "--format", "json"),
+			func(res *icmd.Result) bool {
+				services := map[string]bool{}
+				for _, line := range parse(t, res.Stdout()) {
+					services[line.Service] = true
+				}
+				return services["db"] && services["words"] && services["web"]
+			}, 20*time.Second, time.Second)
+
+		res := c.RunDockerComposeCmd(t, "--project-name", projectName, "logs", "--format", "json")
+		for _, line := range parse(t, res.Stdout()) {
+			assert.Equal(t, line.Container, projectName+"-"+line.Service+"-1")
+			_, err := time.Parse(time.RFC3339Nano, line.Timestamp)
+			assert.NilError(t, err, line.Timestamp)
+		}
+	})
+
+	t.Run("logs --format json --follow", func(t *testing.T) {
+		res := icmd.StartCmd(c.NewDockerComposeCmd(t, "--project-name", projectName, "logs", "--format", "json", "--follow", "--tail", "1", "words"))
+		t.Cleanup(func() {
+			_ = res.Cmd.Process.Kill()
+		})
+		c.WaitForCondition(t, func() (bool, string) {
+			lines := Lines(res.Stdout())
+			if len(lines) == 0 {
+				return false, res.Combined()
+			}
+			var line logLine
+			err := json.Unmarshal([]byte(lines[0]), &line)
+			return err == nil && line.Service == "words" && line.Container == projectName+"-words-1", res.Combined()
+		}, 10*time.Second, time.Second)
+	})
+}
+
+func TestLogsFlushInterval(t *testing.T) {
+	c := NewParallelCLI(t)
+	const projectName = "e2e-logs-flush"
+	t.Cleanup(func() {
+		c.RunDockerComposeCmd(t, "--project-name", projectName, "down")
+	})
+
+	c.RunDockerComposeCmd(t, "-f", "./fixtures/logs-flush/compose.yaml", "--project-name", projectName, "up", "--exit-code-from", "chatty")
+
+	// batched output keeps all the lines, in order
+	res := c.RunDockerComposeCmd(t, "--project-name", projectName, "logs", "--no-log-prefix", "--flush-interval", "100ms")
+	lines := Lines(res.Stdout())
+	assert.Equal(t, len(lines), 20000)
+	for i, line := range lines {
+		assert.Equal(t, line, strconv.Itoa(i+1))
+	}
+
+	res = c.RunDockerComposeCmdNoCheck(t, "--project-name", projectName, "logs", "--flush-interval", "-1s")
+	res.Assert(t, icmd.Expected{ExitCode: 1, Err: "invalid --flush-interval -1s"})
+}
@@ -159,3 +159,29 @@ func TestNetworkModes(t *testing.T) {
 		_ = c.RunDockerComposeCmd(t, "--project-name", projectName, "down")
 	})
 }
+
+func TestDownKeepNetworks(t *testing.T) {
+	c := NewParallelCLI(t)
+	const projectName = "compose-e2e-network-preserve"
+	const networkName = projectName + "_default"
+	const attached = projectName + "-attached"
+	t.Cleanup(func() {
+		c.RunDockerOrExitError(t, "rm", "-f", attached)
+		c.RunDockerOrExitError(t, "network", "rm", networkName)
+	})
+
+	c.RunDockerComposeCmd(t, "--project-directory", "fixtures/network-preserve", "--project-name", projectName, "up", "-d")
+	// a container not managed by compose relies on the project network
+	c.RunDockerCmd(t, "run", "-d", "--name", attached, "--network", networkName, "alpine", "sleep", "infinity")
+
+	c.RunDockerComposeCmd(t, "--project-directory", "fixtures/network-preserve", "--project-name", projectName, "down",
+		"-t", "0", "--remove-networks=false")
+
+	res := c.RunDockerCmd(t, "network", "ls", "--format", "{{.Name}}", "--filter", "label=com.docker.compose.project="+projectName)
+	assert.Equal(t, strings.TrimSpace(res.Stdout()), networkName)
+	res = c.RunDockerCmd(t, "inspect", "--format", "{{.State.Running}}", attached)
+	assert.Equal(t, strings.TrimSpace(res.Stdout()), "true")
+
+	res = c.RunDockerComposeCmd(t, "--project-name", projectName, "ps", "-a", "-q")
+	assert.Equal(t, strings.TrimSpace(res.Stdout()), "")
+}
@@ -20,9 +20,11 @@ import (
 	"encoding/json"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gotest.tools/v3/icmd"
 
 	"github.com/docker/compose/v2/pkg/api"
 )
@@ -79,6 +81,13 @@ func TestPs(t *testing.T) {
 						Protocol:      "tcp",
 					},
 				}, publishers)
+				assert.Equal(t, "busybox", service.Image)
+				assert.Equal(t, "running", service.State)
+				assert.True(t, service.Created > 0)
+				assert.Equal(t, []string{projectName + "_default"}, service.Networks)
+				assert.Equal(t, 1, len(service.Mounts))
+				assert.True(t, strings.HasSuffix(service.Mounts[0], "ps-test"), service.Mounts)
+				assert.Equal(t, "test", service.Labels["my-label"])
 				count++
 			}
 			if service.Name == "e2e-ps-nginx-1" {
@@ -94,4 +103,45 @@ func TestPs(t *testing.T) {
 		}
 		assert.Equal(t, 2, count, "Did not match both services:\n"+res.Combined())
 	})
+
+	t.Run("networks and labels", func(t *testing.T) {
+		res = c.RunDockerComposeCmd(t, "--project-name", projectName, "ps", "busybox",
+			"--format", "{{json .Networks}}\t{{json .Labels}}")
+		fields := strings.Split(strings.TrimSpace(res.Stdout()), "\t")
+		require.Equal(t, 2, len(fields), res.Stdout())
+
+		var networks []string
+		require.NoError(t, json.Unmarshal([]byte(fields[0]), &networks), fields[0])
+		assert.Equal(t, []string{projectName + "_default"}, networks)
+
+		var labels map[string]string
+		require.NoError(t, json.Unmarshal([]byte(fields[1]), &labels), fields[1])
+		assert.Equal(t, "test", labels["my-label"])
+		assert.Equal(t, "busybox", labels[api.ServiceLabel])
+	})
+}
+
+func TestPsWatch(t *testing.T) {
+	c := NewParallelCLI(t)
+	const projectName = "e2e-ps-watch"
+	t.Cleanup(func() {
+		_ = c.RunDockerComposeCmdNoCheck(t, "--project-name", projectName, "down")
+	})
+
+	c.RunDockerComposeCmd(t, "-f", "./fixtures/ps-test/watch.yaml", "--project-name", projectName, "up", "-d")
+
+	// the command runs until interrupted, so let it be killed after the container exited
+	cmd := c.NewDockerComposeCmd(t, "--project-name", projectName, "ps", "--all", "--watch", "--interval", "1s", "--format", "json")
+	res := icmd.RunCmd(cmd, icmd.WithTimeout(8*time.Second))
+
+	var states []string
+	for _, line := range strings.Split(strings.TrimSpace(res.Stdout()), "\n") {
+		var snapshot []api.ContainerSummary
+		require.NoError(t, json.Unmarshal([]byte(line), &snapshot), line)
+		require.Len(t, snapshot, 1, line)
+		if len(states) == 0 || states[len(states)-1] != snapshot[0].State {
+			states = append(states, snapshot[0].State)
+		}
+	}
+	assert.Equal(t, []string{"running", "exited"}, states, res.Stdout())
 }
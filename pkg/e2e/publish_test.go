@@ -0,0 +1,67 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package e2e
+
+import (
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/icmd"
+)
+
+func TestPublish(t *testing.T) {
+	c := NewParallelCLI(t)
+	const registry = "compose-e2e-publish-registry"
+
+	c.RunDockerCmd(t, "run", "-d", "--rm", "--name", registry, "-p", "127.0.0.1:5055:5000", "registry:2")
+	t.Cleanup(func() {
+		c.RunDockerOrExitError(t, "rm", "-f", registry)
+	})
+
+	t.Run("publish", func(t *testing.T) {
+		res := c.RunDockerComposeCmd(t, "-f", "./fixtures/publish/compose.yaml", "--project-name", "publish",
+			"publish", "localhost:5055/publish:v1")
+		assert.Assert(t, strings.Contains(res.Combined(), "Published"), res.Combined())
+	})
+
+	t.Run("up from the published project", func(t *testing.T) {
+		t.Cleanup(func() {
+			c.RunDockerComposeCmd(t, "-f", "oci://localhost:5055/publish:v1", "down")
+		})
+		res := c.RunDockerComposeCmd(t, "-f", "oci://localhost:5055/publish:v1", "up")
+		out := res.Combined()
+		assert.Assert(t, strings.Contains(out, "greeting=hello"), out)
+		assert.Assert(t, strings.Contains(out, "published config"), out)
+	})
+
+	t.Run("variables of the published project can be overridden", func(t *testing.T) {
+		t.Cleanup(func() {
+			c.RunDockerComposeCmd(t, "-f", "oci://localhost:5055/publish:v1", "down")
+		})
+		cmd := c.NewDockerComposeCmd(t, "-f", "oci://localhost:5055/publish:v1", "up")
+		cmd.Env = append(cmd.Env, "GREETING=bonjour")
+		res := icmd.RunCmd(cmd)
+		res.Assert(t, icmd.Success)
+		assert.Assert(t, strings.Contains(res.Combined(), "greeting=bonjour"), res.Combined())
+	})
+
+	t.Run("unknown tag", func(t *testing.T) {
+		res := c.RunDockerComposeCmdNoCheck(t, "-f", "oci://localhost:5055/publish:unknown", "config")
+		res.Assert(t, icmd.Expected{ExitCode: 1, Err: "resolving localhost:5055/publish:unknown"})
+	})
+}
@@ -0,0 +1,70 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package e2e
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/icmd"
+)
+
+func TestPushToLocalRegistry(t *testing.T) {
+	c := NewParallelCLI(t)
+	const projectName = "e2e-push"
+	var image string
+	t.Cleanup(func() {
+		if image != "" {
+			c.RunDockerOrExitError(t, "rmi", image)
+		}
+		c.RunDockerComposeCmd(t, "--project-name", projectName, "down", "-t", "0")
+	})
+
+	c.RunDockerComposeCmd(t, "-f", "fixtures/push/compose.yaml", "--project-name", projectName, "up", "-d", "registry")
+	res := c.RunDockerComposeCmd(t, "-f", "fixtures/push/compose.yaml", "--project-name", projectName, "port", "registry", "5000")
+	registry := strings.TrimSpace(res.Stdout())
+	image = registry + "/e2e-push/nginx:ci-123"
+
+	t.Run("push without image is skipped", func(t *testing.T) {
+		res := c.RunDockerComposeCmd(t, "-f", "fixtures/push/compose.yaml", "--project-name", projectName, "push", "nginx")
+		res.Assert(t, icmd.Expected{Err: "Skipped"})
+	})
+
+	t.Run("push built image", func(t *testing.T) {
+		c.RunDockerComposeCmd(t, "-f", "fixtures/push/compose.yaml", "--project-name", projectName,
+			"build", "--tag", "nginx="+image, "nginx")
+		res := c.RunDockerComposeCmd(t, "-f", "fixtures/push/compose.yaml", "--project-name", projectName,
+			"push", "--tag", "nginx="+image, "nginx")
+		res.Assert(t, icmd.Expected{Err: "Pushed"})
+
+		tags := HTTPGetWithRetry(t, fmt.Sprintf("http://%s/v2/e2e-push/nginx/tags/list", registry), http.StatusOK, time.Second, 10*time.Second)
+		assert.Assert(t, strings.Contains(tags, `"ci-123"`), tags)
+	})
+
+	t.Run("ignore push failures", func(t *testing.T) {
+		res := c.RunDockerComposeCmdNoCheck(t, "-f", "fixtures/push/compose.yaml", "--project-name", projectName,
+			"push", "--tag", "nginx=127.0.0.1:1/e2e-push/nginx:ci-123", "nginx")
+		res.Assert(t, icmd.Expected{ExitCode: 1})
+
+		c.RunDockerComposeCmd(t, "-f", "fixtures/push/compose.yaml", "--project-name", projectName,
+			"push", "--ignore-push-failures", "--tag", "nginx=127.0.0.1:1/e2e-push/nginx:ci-123", "nginx")
+	})
+}
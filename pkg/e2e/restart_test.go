@@ -24,6 +24,7 @@ import (
 
 	testify "github.com/stretchr/testify/assert"
 	"gotest.tools/v3/assert"
+	"gotest.tools/v3/icmd"
 )
 
 func TestRestart(t *testing.T) {
@@ -62,3 +63,54 @@ func TestRestart(t *testing.T) {
 		c.RunDockerComposeCmd(t, "--project-name", projectName, "down")
 	})
 }
+
+func TestRestartServicesFromFile(t *testing.T) {
+	c := NewParallelCLI(t)
+	const projectName = "e2e-restart-from-file"
+	t.Cleanup(func() {
+		c.RunDockerComposeCmd(t, "--project-name", projectName, "down", "-t", "0")
+	})
+
+	c.RunDockerComposeCmd(t, "-f", "./fixtures/restart-test/selection.yaml", "--project-name", projectName, "up", "-d")
+
+	startedAt := func(service string) string {
+		res := c.RunDockerCmd(t, "inspect", "--format", "{{.State.StartedAt}}", projectName+"-"+service+"-1")
+		return strings.TrimSpace(res.Stdout())
+	}
+	before := map[string]string{}
+	for _, service := range []string{"web", "worker", "db"} {
+		before[service] = startedAt(service)
+	}
+
+	c.RunDockerComposeCmd(t, "-f", "./fixtures/restart-test/selection.yaml", "--project-name", projectName,
+		"restart", "-t", "0", "--services-from-file", "./fixtures/restart-test/restart.txt")
+
+	assert.Assert(t, startedAt("web") != before["web"], "web should have been restarted")
+	assert.Assert(t, startedAt("worker") != before["worker"], "worker should have been restarted")
+	assert.Equal(t, startedAt("db"), before["db"], "db should not have been restarted")
+}
+
+func TestRestartForceRecreate(t *testing.T) {
+	c := NewParallelCLI(t)
+	const projectName = "e2e-restart-recreate"
+	t.Cleanup(func() {
+		c.RunDockerComposeCmd(t, "--project-name", projectName, "down", "-t", "0")
+	})
+
+	c.RunDockerComposeCmd(t, "-f", "./fixtures/restart-test/recreate.yaml", "--project-name", projectName, "up", "-d")
+
+	containerID := func() string {
+		res := c.RunDockerCmd(t, "inspect", "--format", "{{.Id}}", projectName+"-web-1")
+		return strings.TrimSpace(res.Stdout())
+	}
+	before := containerID()
+
+	cmd := c.NewDockerComposeCmd(t, "-f", "./fixtures/restart-test/recreate.yaml", "--project-name", projectName,
+		"restart", "-t", "0", "--force-recreate", "web")
+	cmd.Env = append(cmd.Env, "MESSAGE=after")
+	icmd.RunCmd(cmd).Assert(t, icmd.Success)
+
+	assert.Assert(t, containerID() != before, "web should have been recreated")
+	res := c.RunDockerComposeCmd(t, "--project-name", projectName, "exec", "web", "printenv", "MESSAGE")
+	assert.Equal(t, strings.TrimSpace(res.Stdout()), "after")
+}
@@ -0,0 +1,127 @@
+//go:build !windows
+// +build !windows
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package e2e
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/containerd/console"
+	"gotest.tools/v3/assert"
+)
+
+// ptyOutput collects what a command writes to its terminal
+type ptyOutput struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (o *ptyOutput) Write(p []byte) (int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.buf.Write(p)
+}
+
+func (o *ptyOutput) String() string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.buf.String()
+}
+
+func TestRunTTY(t *testing.T) {
+	c := NewParallelCLI(t)
+	const projectName = "e2e-run-tty"
+	t.Cleanup(func() {
+		c.RunDockerComposeCmd(t, "--project-name", projectName, "down", "-t", "0")
+	})
+	c.RunDockerCmd(t, "pull", "alpine")
+
+	ptmx, slave, err := console.NewPty()
+	assert.NilError(t, err)
+	defer ptmx.Close() // nolint: errcheck
+	assert.NilError(t, ptmx.Resize(console.WinSize{Height: 24, Width: 80}))
+	tty, err := os.OpenFile(slave, os.O_RDWR|syscall.O_NOCTTY, 0)
+	assert.NilError(t, err)
+	defer tty.Close() // nolint: errcheck
+
+	command := c.NewDockerComposeCmd(t, "-f", "./fixtures/run-test/tty.yaml", "--project-name", projectName,
+		"run", "--rm", "--detach-keys", "ctrl-x", "shell")
+	cmd := exec.Command(command.Command[0], command.Command[1:]...)
+	cmd.Env = command.Env
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = tty, tty, tty
+	// make the pty the controlling terminal of compose, so it gets SIGWINCH on resize
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true, Setctty: true}
+	assert.NilError(t, cmd.Start())
+
+	output := &ptyOutput{}
+	go func() {
+		_, _ = io.Copy(output, ptmx)
+	}()
+
+	waitFor := func(expected string) {
+		c.WaitForCondition(t, func() (bool, string) {
+			out := output.String()
+			return strings.Contains(out, expected), "'" + expected + "' not found in:\n" + out
+		}, 30*time.Second, 500*time.Millisecond)
+	}
+
+	waitFor("/ #")
+	_, err = ptmx.Write([]byte("stty size\r"))
+	assert.NilError(t, err)
+	waitFor("24 80")
+
+	t.Run("resize", func(t *testing.T) {
+		assert.NilError(t, ptmx.Resize(console.WinSize{Height: 40, Width: 100}))
+		c.WaitForCondition(t, func() (bool, string) {
+			_, err := ptmx.Write([]byte("stty size\r"))
+			assert.NilError(t, err)
+			out := output.String()
+			return strings.Contains(out, "40 100"), "terminal size not updated:\n" + out
+		}, 10*time.Second, time.Second)
+	})
+
+	t.Run("detach keys", func(t *testing.T) {
+		_, err := ptmx.Write([]byte{0x18}) // ctrl-x
+		assert.NilError(t, err)
+
+		done := make(chan error, 1)
+		go func() {
+			done <- cmd.Wait()
+		}()
+		select {
+		case err := <-done:
+			assert.NilError(t, err)
+		case <-time.After(10 * time.Second):
+			_ = cmd.Process.Kill()
+			t.Fatal("compose run didn't detach")
+		}
+
+		res := c.RunDockerCmd(t, "ps", "--format", "{{.Names}}",
+			"--filter", "label=com.docker.compose.project="+projectName, "--filter", "status=running")
+		assert.Assert(t, strings.Contains(res.Stdout(), projectName+"_shell_run_"), res.Stdout())
+	})
+}
@@ -17,8 +17,11 @@
 package e2e
 
 import (
+	"strings"
 	"testing"
+	"time"
 
+	"gotest.tools/v3/assert"
 	"gotest.tools/v3/icmd"
 )
 
@@ -31,3 +34,57 @@ func TestStartFail(t *testing.T) {
 
 	c.RunDockerComposeCmd(t, "--project-name", projectName, "down")
 }
+
+func TestStartFailRollback(t *testing.T) {
+	c := NewParallelCLI(t)
+	const projectName = "e2e-start-fail-rollback"
+	t.Cleanup(func() {
+		c.RunDockerComposeCmd(t, "--project-name", projectName, "down")
+	})
+
+	c.RunDockerComposeCmd(t, "-f", "fixtures/start-fail/rollback.yaml", "--project-name", projectName, "up", "-d", "stable")
+
+	res := c.RunDockerComposeCmdNoCheck(t, "-f", "fixtures/start-fail/rollback.yaml", "--project-name", projectName, "up", "-d", "--rollback")
+	res.Assert(t, icmd.Expected{ExitCode: 1})
+
+	res = c.RunDockerCmd(t, "ps", "-a", "--format", "{{.Names}}", "--filter", "label=com.docker.compose.project="+projectName)
+	assert.Equal(t, strings.TrimSpace(res.Stdout()), projectName+"-stable-1")
+}
+
+func TestUpDependsOnHealthy(t *testing.T) {
+	c := NewParallelCLI(t)
+	const projectName = "e2e-depends-on-healthy"
+	t.Cleanup(func() {
+		c.RunDockerComposeCmd(t, "--project-name", projectName, "down", "-t", "0")
+	})
+
+	inspectTime := func(format string, container string) time.Time {
+		res := c.RunDockerCmd(t, "inspect", "--format", format, projectName+"-"+container+"-1")
+		at, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(res.Stdout()))
+		assert.NilError(t, err, res.Stdout())
+		return at
+	}
+
+	t.Run("dependent starts once dependency is healthy", func(t *testing.T) {
+		c.RunDockerComposeCmd(t, "-f", "fixtures/dependencies/healthy.yaml", "--project-name", projectName, "up", "-d")
+
+		res := c.RunDockerCmd(t, "inspect", "--format", "{{.State.Health.Status}}", projectName+"-web-1")
+		assert.Equal(t, strings.TrimSpace(res.Stdout()), "healthy")
+		webStarted := inspectTime("{{.State.StartedAt}}", "web")
+		appStarted := inspectTime("{{.State.StartedAt}}", "app")
+		assert.Assert(t, appStarted.Sub(webStarted) >= 3*time.Second,
+			"app started %s after web, before web was healthy", appStarted.Sub(webStarted))
+
+		c.RunDockerComposeCmd(t, "--project-name", projectName, "down", "-t", "0")
+	})
+
+	t.Run("up fails when dependency is unhealthy", func(t *testing.T) {
+		cmd := c.NewDockerComposeCmd(t, "-f", "fixtures/dependencies/healthy.yaml", "--project-name", projectName, "up", "-d")
+		cmd.Env = append(cmd.Env, "HEALTH_FILE=never")
+		res := icmd.RunCmd(cmd)
+		res.Assert(t, icmd.Expected{ExitCode: 1, Err: `dependency failed to start: container for service "web" is unhealthy`})
+
+		res = c.RunDockerCmd(t, "inspect", "--format", "{{.State.Status}}", projectName+"-app-1")
+		assert.Equal(t, strings.TrimSpace(res.Stdout()), "created")
+	})
+}
@@ -18,6 +18,7 @@ package e2e
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"testing"
 
@@ -145,3 +146,121 @@ func TestStartStopWithDependencies(t *testing.T) {
 		_ = c.RunDockerComposeCmd(t, "--project-name", projectName, "down")
 	})
 }
+
+func TestUpNoStartMatchesCreate(t *testing.T) {
+	c := NewParallelCLI(t)
+	const projectName = "e2e-up-no-start"
+
+	getServiceRegx := func(service string, status string) string {
+		return fmt.Sprintf("%s-%s-1.+%s\\s+%s", projectName, service, service, status)
+	}
+
+	for _, args := range [][]string{{"create"}, {"up", "--no-start"}} {
+		args := args
+		t.Run(strings.Join(args, " "), func(t *testing.T) {
+			defer c.RunDockerComposeCmd(t, "--project-name", projectName, "down")
+
+			cmd := append([]string{"-f", "./fixtures/start-stop/compose.yaml", "--project-name", projectName}, args...)
+			res := c.RunDockerComposeCmd(t, cmd...)
+			assert.Assert(t, strings.Contains(res.Combined(), "Container e2e-up-no-start-simple-1  Created"), res.Combined())
+			assert.Assert(t, !strings.Contains(res.Combined(), "Started"), res.Combined())
+
+			res = c.RunDockerComposeCmd(t, "--project-name", projectName, "ps", "--all")
+			testify.Regexp(t, getServiceRegx("simple", "created"), res.Stdout())
+			testify.Regexp(t, getServiceRegx("another", "created"), res.Stdout())
+
+			res = c.RunDockerCmd(t, "network", "ls")
+			assert.Assert(t, strings.Contains(res.Stdout(), projectName+"_default"), res.Stdout())
+		})
+	}
+}
+
+func TestCreateRemoveOrphans(t *testing.T) {
+	c := NewParallelCLI(t)
+	const projectName = "e2e-create-remove-orphans"
+	t.Cleanup(func() {
+		c.RunDockerComposeCmd(t, "--project-name", projectName, "down", "--remove-orphans")
+	})
+
+	c.RunDockerComposeCmd(t, "-f", "./fixtures/orphans/compose.yaml", "--project-name", projectName, "create")
+
+	res := c.RunDockerComposeCmd(t, "-f", "./fixtures/orphans/renamed.yaml", "--project-name", projectName, "create")
+	assert.Assert(t, strings.Contains(res.Stderr(), "Found orphan containers"), res.Stderr())
+
+	c.RunDockerComposeCmd(t, "-f", "./fixtures/orphans/renamed.yaml", "--project-name", projectName, "create", "--remove-orphans")
+	res = c.RunDockerComposeCmd(t, "--project-name", projectName, "ps", "-a", "--services")
+	assert.Equal(t, strings.TrimSpace(res.Stdout()), "new")
+}
+
+func TestStopLabel(t *testing.T) {
+	c := NewParallelCLI(t)
+	const projectName = "e2e-stop-label"
+	t.Cleanup(func() {
+		c.RunDockerComposeCmd(t, "--project-name", projectName, "down", "-t", "0")
+	})
+
+	c.RunDockerComposeCmd(t, "-f", "./fixtures/stop-label/compose.yaml", "--project-name", projectName, "up", "-d")
+
+	c.RunDockerComposeCmd(t, "--project-name", projectName, "stop", "-t", "0", "--label", "tier=frontend")
+	res := c.RunDockerComposeCmd(t, "--project-name", projectName, "ps", "--services", "--status", "running")
+	assert.DeepEqual(t, Lines(res.Stdout()), []string{"db"})
+
+	res = c.RunDockerComposeCmd(t, "--project-name", projectName, "ps", "--services", "--status", "exited")
+	services := Lines(res.Stdout())
+	sort.Strings(services)
+	assert.DeepEqual(t, services, []string{"proxy", "web"})
+}
+
+func TestUpRemoveOrphansKeepsInactiveProfiles(t *testing.T) {
+	c := NewParallelCLI(t)
+	const projectName = "e2e-up-remove-orphans-profiles"
+	t.Cleanup(func() {
+		c.RunDockerComposeCmd(t, "-f", "./fixtures/orphans/profiled.yaml", "--project-name", projectName, "--profile", "debug",
+			"down", "--remove-orphans", "-t", "0")
+	})
+
+	c.RunDockerComposeCmd(t, "-f", "./fixtures/orphans/profiled.yaml", "--project-name", projectName, "--profile", "debug", "up", "-d")
+
+	res := c.RunDockerComposeCmd(t, "-f", "./fixtures/orphans/profiled.yaml", "--project-name", projectName, "up", "-d", "--remove-orphans")
+	assert.Assert(t, !strings.Contains(res.Combined(), "Found orphan containers"), res.Combined())
+
+	res = c.RunDockerComposeCmd(t, "--project-name", projectName, "ps", "-a", "--services")
+	services := Lines(res.Stdout())
+	sort.Strings(services)
+	assert.DeepEqual(t, services, []string{"debug", "new"})
+}
+
+func TestKillOrphansConfirmation(t *testing.T) {
+	c := NewParallelCLI(t)
+	const projectName = "e2e-kill-orphans"
+	t.Cleanup(func() {
+		c.RunDockerComposeCmd(t, "--project-name", projectName, "down", "--remove-orphans", "-t", "0")
+	})
+
+	c.RunDockerComposeCmd(t, "-f", "./fixtures/orphans/compose.yaml", "--project-name", projectName, "up", "-d")
+	c.RunDockerComposeCmd(t, "-f", "./fixtures/orphans/renamed.yaml", "--project-name", projectName, "up", "-d")
+
+	running := func() string {
+		res := c.RunDockerComposeCmd(t, "--project-name", projectName, "ps", "--services", "--status", "running")
+		return strings.Join(Lines(res.Stdout()), ",")
+	}
+
+	t.Run("kill only touches the project's services", func(t *testing.T) {
+		c.RunDockerComposeCmd(t, "-f", "./fixtures/orphans/renamed.yaml", "--project-name", projectName, "kill")
+		assert.Equal(t, running(), "old")
+		c.RunDockerComposeCmd(t, "-f", "./fixtures/orphans/renamed.yaml", "--project-name", projectName, "up", "-d")
+	})
+
+	t.Run("kill orphans requires confirmation", func(t *testing.T) {
+		res := c.RunDockerComposeCmdNoCheck(t, "-f", "./fixtures/orphans/renamed.yaml", "--project-name", projectName,
+			"kill", "--remove-orphans")
+		assert.Assert(t, res.ExitCode != 0, res.Combined())
+		assert.Equal(t, running(), "new,old")
+	})
+
+	t.Run("kill orphans with --yes", func(t *testing.T) {
+		c.RunDockerComposeCmd(t, "-f", "./fixtures/orphans/renamed.yaml", "--project-name", projectName,
+			"kill", "--remove-orphans", "--yes")
+		assert.Equal(t, strings.TrimSpace(running()), "")
+	})
+}
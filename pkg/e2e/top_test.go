@@ -0,0 +1,92 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package e2e
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/icmd"
+)
+
+func TestTopAggregate(t *testing.T) {
+	c := NewParallelCLI(t)
+	const projectName = "e2e-top-aggregate"
+	t.Cleanup(func() {
+		c.RunDockerComposeCmd(t, "--project-name", projectName, "down")
+	})
+
+	c.RunDockerComposeCmd(t, "-f", "fixtures/top/compose.yaml", "--project-name", projectName, "up", "-d")
+
+	res := c.RunDockerComposeCmd(t, "--project-name", projectName, "top", "--aggregate")
+	var found bool
+	for _, line := range Lines(res.Stdout()) {
+		fields := strings.Fields(line)
+		if len(fields) > 0 && fields[0] == "sleeper" {
+			found = true
+			assert.DeepEqual(t, fields[1:3], []string{"2", "2"})
+		}
+	}
+	assert.Assert(t, found, res.Stdout())
+}
+
+func TestTopSortCPU(t *testing.T) {
+	c := NewParallelCLI(t)
+	const projectName = "e2e-top-sort"
+	t.Cleanup(func() {
+		c.RunDockerComposeCmd(t, "--project-name", projectName, "down", "-t", "0")
+	})
+
+	c.RunDockerComposeCmd(t, "-f", "fixtures/top/sort.yaml", "--project-name", projectName, "up", "-d")
+
+	// first line is the container name, second one the titles, then processes
+	busyFirst := func(res *icmd.Result) bool {
+		lines := Lines(res.Stdout())
+		return len(lines) > 3 && strings.Contains(lines[2], "while true") && strings.Contains(lines[3], "sleep infinity")
+	}
+	cmd := c.NewDockerComposeCmd(t, "--project-name", projectName, "top", "--sort", "cpu")
+	c.WaitForCmdResult(t, cmd, busyFirst, 10*time.Second, time.Second)
+
+	res := c.RunDockerComposeCmd(t, "--project-name", projectName, "top", "--sort", "mem")
+	lines := Lines(res.Stdout())
+	assert.Assert(t, len(lines) > 1 && strings.Contains(lines[1], "%MEM"), res.Stdout())
+}
+
+func TestTopFollow(t *testing.T) {
+	c := NewParallelCLI(t)
+	const projectName = "e2e-top-follow"
+	t.Cleanup(func() {
+		c.RunDockerComposeCmd(t, "--project-name", projectName, "down", "-t", "0")
+	})
+
+	c.RunDockerComposeCmd(t, "-f", "fixtures/top/follow.yaml", "--project-name", projectName, "up", "-d")
+
+	// the command runs until interrupted, so let it be killed after a few refreshes
+	cmd := c.NewDockerComposeCmd(t, "--project-name", projectName, "top", "--follow", "--interval", "1s")
+	res := icmd.RunCmd(cmd, icmd.WithTimeout(5*time.Second))
+
+	refreshes := 0
+	for _, line := range Lines(res.Stdout()) {
+		if line == projectName+"-follower-1" {
+			refreshes++
+		}
+	}
+	assert.Assert(t, refreshes >= 2, res.Stdout())
+	assert.Assert(t, strings.Contains(res.Stdout(), "sleep infinity"), res.Stdout())
+}
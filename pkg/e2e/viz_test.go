@@ -0,0 +1,51 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package e2e
+
+import (
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/icmd"
+)
+
+func TestViz(t *testing.T) {
+	c := NewParallelCLI(t)
+
+	t.Run("dot", func(t *testing.T) {
+		res := c.RunDockerComposeCmd(t, "-f", "./fixtures/viz/compose.yaml", "--project-name", "viz", "alpha", "viz")
+		out := res.Stdout()
+		assert.Assert(t, strings.HasPrefix(out, `digraph "viz" {`), out)
+		assert.Assert(t, strings.Contains(out, `"service:web" -> "service:db" [label="depends_on: service_healthy"];`), out)
+		assert.Assert(t, !strings.Contains(out, "volume:data"), out)
+	})
+
+	t.Run("mermaid with networks and volumes", func(t *testing.T) {
+		res := c.RunDockerComposeCmd(t, "-f", "./fixtures/viz/compose.yaml", "--project-name", "viz", "alpha", "viz",
+			"--format", "mermaid", "--include-networks", "--include-volumes")
+		out := res.Stdout()
+		assert.Assert(t, strings.HasPrefix(out, "flowchart LR\n"), out)
+		assert.Assert(t, strings.Contains(out, `service_db -->|"/data"| volume_data`), out)
+		assert.Assert(t, strings.Contains(out, "service_web --> network_default"), out)
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		res := c.RunDockerComposeCmdNoCheck(t, "-f", "./fixtures/viz/compose.yaml", "alpha", "viz", "--format", "svg")
+		res.Assert(t, icmd.Expected{ExitCode: 1, Err: `unsupported format "svg", must be one of dot or mermaid`})
+	})
+}
@@ -119,3 +119,17 @@ func TestProjectVolumeBind(t *testing.T) {
 		assert.Assert(t, strings.Contains(ret.Stdout(), "SUCCESS"))
 	})
 }
+
+func TestDownPreserveVolume(t *testing.T) {
+	c := NewParallelCLI(t)
+	const projectName = "compose-e2e-volume-preserve"
+	t.Cleanup(func() {
+		c.RunDockerOrExitError(t, "volume", "rm", "-f", projectName+"_pgdata", projectName+"_cache")
+	})
+
+	c.RunDockerComposeCmd(t, "--project-directory", "fixtures/volume-preserve", "--project-name", projectName, "up", "-d")
+	c.RunDockerComposeCmd(t, "--project-directory", "fixtures/volume-preserve", "--project-name", projectName, "down", "--volumes", "--preserve", "pgdata")
+
+	res := c.RunDockerCmd(t, "volume", "ls", "--format", "{{.Name}}", "--filter", "label=com.docker.compose.project="+projectName)
+	assert.Equal(t, strings.TrimSpace(res.Stdout()), projectName+"_pgdata")
+}
@@ -0,0 +1,131 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package e2e
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/icmd"
+)
+
+func TestWatchSync(t *testing.T) {
+	c := NewParallelCLI(t)
+
+	const projectName = "compose-e2e-watch-sync"
+	t.Cleanup(func() {
+		c.RunDockerComposeCmd(t, "--project-name", projectName, "down", "-t", "0")
+	})
+
+	dir := t.TempDir()
+	composeFile := filepath.Join(dir, "compose.yaml")
+	CopyFile(t, "./fixtures/watch/compose.yaml", composeFile)
+	assert.NilError(t, os.Mkdir(filepath.Join(dir, "data"), 0o755))
+	hello := filepath.Join(dir, "data", "hello.txt")
+	assert.NilError(t, os.WriteFile(hello, []byte("hello"), 0o644))
+
+	c.RunDockerComposeCmd(t, "-f", composeFile, "--project-name", projectName, "up", "-d")
+
+	res := icmd.StartCmd(c.NewDockerComposeCmd(t, "-f", composeFile, "--project-name", projectName, "watch", "--interval", "100ms"))
+	t.Cleanup(func() {
+		_ = res.Cmd.Process.Kill()
+	})
+	c.WaitForCondition(t, func() (bool, string) {
+		return strings.Contains(res.Stderr(), "Watching"), res.Combined()
+	}, 10*time.Second, 100*time.Millisecond)
+
+	t.Run("sync changed file", func(t *testing.T) {
+		assert.NilError(t, os.WriteFile(hello, []byte("hello world"), 0o644))
+		c.WaitForCondition(t, func() (bool, string) {
+			out := c.RunDockerComposeCmdNoCheck(t, "--project-name", projectName, "exec", "app", "cat", "/data/hello.txt")
+			return strings.TrimSpace(out.Stdout()) == "hello world", res.Combined()
+		}, 10*time.Second, time.Second)
+	})
+
+	t.Run("sync added file", func(t *testing.T) {
+		assert.NilError(t, os.MkdirAll(filepath.Join(dir, "data", "sub"), 0o755))
+		assert.NilError(t, os.WriteFile(filepath.Join(dir, "data", "sub", "new.txt"), []byte("new"), 0o644))
+		c.WaitForCondition(t, func() (bool, string) {
+			out := c.RunDockerComposeCmdNoCheck(t, "--project-name", projectName, "exec", "app", "cat", "/data/sub/new.txt")
+			return strings.TrimSpace(out.Stdout()) == "new", res.Combined()
+		}, 10*time.Second, time.Second)
+	})
+
+	t.Run("sync removed file", func(t *testing.T) {
+		assert.NilError(t, os.Remove(hello))
+		c.WaitForCondition(t, func() (bool, string) {
+			out := c.RunDockerComposeCmdNoCheck(t, "--project-name", projectName, "exec", "app", "test", "-e", "/data/hello.txt")
+			return out.ExitCode == 1, res.Combined()
+		}, 10*time.Second, time.Second)
+	})
+}
+
+func TestWatchNoUp(t *testing.T) {
+	c := NewParallelCLI(t)
+
+	const projectName = "compose-e2e-watch-no-up"
+	t.Cleanup(func() {
+		c.RunDockerComposeCmd(t, "--project-name", projectName, "down", "-t", "0")
+	})
+
+	dir := t.TempDir()
+	composeFile := filepath.Join(dir, "compose.yaml")
+	CopyFile(t, "./fixtures/watch/no-up.yaml", composeFile)
+	assert.NilError(t, os.Mkdir(filepath.Join(dir, "data"), 0o755))
+	hello := filepath.Join(dir, "data", "hello.txt")
+	assert.NilError(t, os.WriteFile(hello, []byte("hello"), 0o644))
+
+	t.Run("fails without running containers", func(t *testing.T) {
+		res := c.RunDockerComposeCmdNoCheck(t, "-f", composeFile, "--project-name", projectName, "watch", "--no-up")
+		res.Assert(t, icmd.Expected{ExitCode: 1, Err: "no running container found for project"})
+	})
+
+	c.RunDockerComposeCmd(t, "-f", composeFile, "--project-name", projectName, "up", "-d")
+	before := c.RunDockerComposeCmd(t, "--project-name", projectName, "ps", "-q").Stdout()
+
+	res := icmd.StartCmd(c.NewDockerComposeCmd(t, "-f", composeFile, "--project-name", projectName, "watch", "--no-up", "--interval", "100ms"))
+	t.Cleanup(func() {
+		_ = res.Cmd.Process.Kill()
+	})
+	c.WaitForCondition(t, func() (bool, string) {
+		return strings.Contains(res.Stderr(), "Watching"), res.Combined()
+	}, 10*time.Second, 100*time.Millisecond)
+
+	t.Run("sync changed file", func(t *testing.T) {
+		assert.NilError(t, os.WriteFile(hello, []byte("hello world"), 0o644))
+		c.WaitForCondition(t, func() (bool, string) {
+			out := c.RunDockerComposeCmdNoCheck(t, "--project-name", projectName, "exec", "app", "cat", "/data/hello.txt")
+			return strings.TrimSpace(out.Stdout()) == "hello world", res.Combined()
+		}, 10*time.Second, time.Second)
+	})
+
+	t.Run("containers are not recreated", func(t *testing.T) {
+		after := c.RunDockerComposeCmd(t, "--project-name", projectName, "ps", "-q").Stdout()
+		assert.Equal(t, after, before)
+	})
+}
+
+func TestWatchNoTrigger(t *testing.T) {
+	c := NewParallelCLI(t)
+
+	res := c.RunDockerComposeCmdNoCheck(t, "-f", "./fixtures/logs-output-dir/compose.yaml", "--project-name", "compose-e2e-watch-none", "watch")
+	res.Assert(t, icmd.Expected{ExitCode: 1, Err: "none of the selected services is configured for watch"})
+}
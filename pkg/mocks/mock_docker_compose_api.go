@@ -93,6 +93,36 @@ func (mr *MockServiceMockRecorder) Create(ctx, project, options interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockService)(nil).Create), ctx, project, options)
 }
 
+// Diff mocks base method.
+func (m *MockService) Diff(ctx context.Context, project *types.Project, options api.DiffOptions) ([]api.DiffSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Diff", ctx, project, options)
+	ret0, _ := ret[0].([]api.DiffSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Diff indicates an expected call of Diff.
+func (mr *MockServiceMockRecorder) Diff(ctx, project, options interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Diff", reflect.TypeOf((*MockService)(nil).Diff), ctx, project, options)
+}
+
+// DiskUsage mocks base method.
+func (m *MockService) DiskUsage(ctx context.Context, projectName string, options api.DiskUsageOptions) ([]api.DiskUsageSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiskUsage", ctx, projectName, options)
+	ret0, _ := ret[0].([]api.DiskUsageSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiskUsage indicates an expected call of DiskUsage.
+func (mr *MockServiceMockRecorder) DiskUsage(ctx, projectName, options interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiskUsage", reflect.TypeOf((*MockService)(nil).DiskUsage), ctx, projectName, options)
+}
+
 // Down mocks base method.
 func (m *MockService) Down(ctx context.Context, projectName string, options api.DownOptions) error {
 	m.ctrl.T.Helper()
@@ -239,6 +269,20 @@ func (mr *MockServiceMockRecorder) Ps(ctx, projectName, options interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ps", reflect.TypeOf((*MockService)(nil).Ps), ctx, projectName, options)
 }
 
+// Publish mocks base method.
+func (m *MockService) Publish(ctx context.Context, project *types.Project, repository string, options api.PublishOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", ctx, project, repository, options)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockServiceMockRecorder) Publish(ctx, project, repository, options interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockService)(nil).Publish), ctx, project, repository, options)
+}
+
 // Pull mocks base method.
 func (m *MockService) Pull(ctx context.Context, project *types.Project, options api.PullOptions) error {
 	m.ctrl.T.Helper()
@@ -339,18 +383,18 @@ func (mr *MockServiceMockRecorder) Stop(ctx, projectName, options interface{}) *
 }
 
 // Top mocks base method.
-func (m *MockService) Top(ctx context.Context, projectName string, services []string) ([]api.ContainerProcSummary, error) {
+func (m *MockService) Top(ctx context.Context, projectName string, options api.TopOptions) ([]api.ContainerProcSummary, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Top", ctx, projectName, services)
+	ret := m.ctrl.Call(m, "Top", ctx, projectName, options)
 	ret0, _ := ret[0].([]api.ContainerProcSummary)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Top indicates an expected call of Top.
-func (mr *MockServiceMockRecorder) Top(ctx, projectName, services interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) Top(ctx, projectName, options interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Top", reflect.TypeOf((*MockService)(nil).Top), ctx, projectName, services)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Top", reflect.TypeOf((*MockService)(nil).Top), ctx, projectName, options)
 }
 
 // UnPause mocks base method.
@@ -381,6 +425,20 @@ func (mr *MockServiceMockRecorder) Up(ctx, project, options interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Up", reflect.TypeOf((*MockService)(nil).Up), ctx, project, options)
 }
 
+// Watch mocks base method.
+func (m *MockService) Watch(ctx context.Context, project *types.Project, services []string, options api.WatchOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Watch", ctx, project, services, options)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Watch indicates an expected call of Watch.
+func (mr *MockServiceMockRecorder) Watch(ctx, project, services, options interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Watch", reflect.TypeOf((*MockService)(nil).Watch), ctx, project, services, options)
+}
+
 // MockLogConsumer is a mock of LogConsumer interface.
 type MockLogConsumer struct {
 	ctrl     *gomock.Controller
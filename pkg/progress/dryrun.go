@@ -0,0 +1,48 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package progress
+
+// DryRun marks the events reported by writers as the ones of operations which are not actually run
+var DryRun = false
+
+// DryRunPrefix prefixes the events reported in dry run mode
+const DryRunPrefix = "DRY-RUN "
+
+// dryRunWriter prefixes the events of the writer it wraps, so they can't be mistaken for the ones of a real run
+type dryRunWriter struct {
+	Writer
+}
+
+func (w *dryRunWriter) Event(e Event) {
+	w.Writer.Event(dryRunEvent(e))
+}
+
+func (w *dryRunWriter) Events(events []Event) {
+	marked := make([]Event, len(events))
+	for i, e := range events {
+		marked[i] = dryRunEvent(e)
+	}
+	w.Writer.Events(marked)
+}
+
+func dryRunEvent(e Event) Event {
+	e.ID = DryRunPrefix + e.ID
+	if e.ParentID != "" {
+		e.ParentID = DryRunPrefix + e.ParentID
+	}
+	return e
+}
@@ -97,13 +97,26 @@ const (
 	ModeTTY = "tty"
 	// ModePlain dump raw events to output
 	ModePlain = "plain"
+	// ModeQuiet don't display events
+	ModeQuiet = "quiet"
 )
 
-// Mode define how progress should be rendered, either as ModePlain or ModeTTY
+// Mode define how progress should be rendered, either as ModePlain or ModeTTY, or not at all with ModeQuiet
 var Mode = ModeAuto
 
 // NewWriter returns a new multi-progress writer
 func NewWriter(out console.File) (Writer, error) {
+	w, err := newWriter(out)
+	if err != nil || !DryRun {
+		return w, err
+	}
+	return &dryRunWriter{Writer: w}, nil
+}
+
+func newWriter(out console.File) (Writer, error) {
+	if Mode == ModeQuiet {
+		return &noopWriter{}, nil
+	}
 	_, isTerminal := term.GetFdInfo(out)
 	if Mode == ModeAuto && isTerminal {
 		return newTTYWriter(out)
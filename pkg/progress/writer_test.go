@@ -17,7 +17,9 @@
 package progress
 
 import (
+	"bytes"
 	"context"
+	"os"
 	"testing"
 
 	"gotest.tools/v3/assert"
@@ -29,3 +31,51 @@ func TestNoopWriter(t *testing.T) {
 
 	assert.Equal(t, writer, &noopWriter{})
 }
+
+func TestQuietWriter(t *testing.T) {
+	mode := Mode
+	defer func() {
+		Mode = mode
+	}()
+	Mode = ModeQuiet
+
+	writer, err := NewWriter(os.Stderr)
+	assert.NilError(t, err)
+	_, ok := writer.(*noopWriter)
+	assert.Assert(t, ok)
+}
+
+func TestPlainWriterOneLinePerEvent(t *testing.T) {
+	out := &bytes.Buffer{}
+	w := &plainWriter{
+		out:  out,
+		done: make(chan bool),
+	}
+	w.Event(CreatedEvent("Container foo-1"))
+	w.Events([]Event{StartedEvent("Container foo-1"), StartedEvent("Container bar-1")})
+
+	assert.Equal(t, out.String(), "Container foo-1  Created\nContainer foo-1  Started\nContainer bar-1  Started\n")
+}
+
+func TestDryRunWriter(t *testing.T) {
+	dryRun := DryRun
+	defer func() {
+		DryRun = dryRun
+	}()
+	DryRun = true
+
+	out := &bytes.Buffer{}
+	w := &dryRunWriter{Writer: &plainWriter{
+		out:  out,
+		done: make(chan bool),
+	}}
+	w.Event(CreatedEvent("Container foo-1"))
+	w.Events([]Event{StartedEvent("Container foo-1")})
+
+	assert.Equal(t, out.String(), "DRY-RUN Container foo-1  Created\nDRY-RUN Container foo-1  Started\n")
+
+	writer, err := NewWriter(os.Stderr)
+	assert.NilError(t, err)
+	_, ok := writer.(*dryRunWriter)
+	assert.Assert(t, ok)
+}
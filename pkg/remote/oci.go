@@ -0,0 +1,301 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/remotes"
+	"github.com/distribution/distribution/v3/reference"
+	"github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+)
+
+const (
+	// OCIPrefix is the prefix of a Compose file path referencing a project published as an OCI artifact
+	OCIPrefix = "oci://"
+
+	// ComposeProjectMediaType is the media type of the config of an OCI artifact holding a Compose project
+	ComposeProjectMediaType = "application/vnd.docker.compose.project"
+	// ComposeYAMLMediaType is the media type of the Compose file of a published project
+	ComposeYAMLMediaType = "application/vnd.docker.compose.file+yaml"
+	// ComposeEnvFileMediaType is the media type of the env file defining the default values of the project variables
+	ComposeEnvFileMediaType = "application/vnd.docker.compose.envfile"
+	// ComposeLocalFileMediaType is the media type of the local files referenced by the Compose file
+	ComposeLocalFileMediaType = "application/vnd.docker.compose.file"
+)
+
+// File is a file of a published Compose project, its Path being relative to the project directory
+type File struct {
+	Path      string
+	MediaType string
+	Content   []byte
+}
+
+// IsOCI tells whether a Compose file path references a project published as an OCI artifact
+func IsOCI(path string) bool {
+	return strings.HasPrefix(path, OCIPrefix)
+}
+
+// ParseReference parses the reference of a published project, with or without the oci:// prefix. The latest tag is
+// used when none is set.
+func ParseReference(ref string) (reference.Named, error) {
+	named, err := reference.ParseNormalizedNamed(strings.TrimPrefix(ref, OCIPrefix))
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid reference %q", ref)
+	}
+	return reference.TagNameOnly(named), nil
+}
+
+// Artifact is the OCI artifact a Compose project is published as, each of its files being a layer
+type Artifact struct {
+	// Layers are the descriptors of the files, in the order of the files
+	Layers []ocispec.Descriptor
+	// Config is the descriptor of the artifact config, identifying it as a Compose project
+	Config ocispec.Descriptor
+	// Manifest is the descriptor of the artifact manifest
+	Manifest ocispec.Descriptor
+
+	config   []byte
+	manifest []byte
+}
+
+// NewArtifact computes the layers and the manifest of the artifact holding the files of a Compose project
+func NewArtifact(files []File) (Artifact, error) {
+	var artifact Artifact
+	for _, file := range files {
+		artifact.Layers = append(artifact.Layers, ocispec.Descriptor{
+			MediaType: file.MediaType,
+			Digest:    digest.FromBytes(file.Content),
+			Size:      int64(len(file.Content)),
+			Annotations: map[string]string{
+				ocispec.AnnotationTitle: file.Path,
+			},
+		})
+	}
+
+	artifact.config = []byte("{}")
+	artifact.Config = ocispec.Descriptor{
+		MediaType: ComposeProjectMediaType,
+		Digest:    digest.FromBytes(artifact.config),
+		Size:      int64(len(artifact.config)),
+	}
+
+	manifest, err := json.Marshal(ocispec.Manifest{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageManifest,
+		Config:    artifact.Config,
+		Layers:    artifact.Layers,
+	})
+	if err != nil {
+		return Artifact{}, err
+	}
+	artifact.manifest = manifest
+	artifact.Manifest = ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Digest:    digest.FromBytes(manifest),
+		Size:      int64(len(manifest)),
+	}
+	return artifact, nil
+}
+
+// Push pushes the files of a Compose project to a registry as an OCI artifact, each file being a layer of the
+// artifact manifest, and returns the descriptor of that manifest
+func Push(ctx context.Context, resolver remotes.Resolver, ref reference.Named, files []File) (ocispec.Descriptor, error) {
+	artifact, err := NewArtifact(files)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	for i, file := range files {
+		if err := pushBlob(ctx, resolver, ref, artifact.Layers[i], file.Content); err != nil {
+			return ocispec.Descriptor{}, errors.Wrapf(err, "pushing %s", file.Path)
+		}
+	}
+	if err := pushBlob(ctx, resolver, ref, artifact.Config, artifact.config); err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	return artifact.Manifest, pushBlob(ctx, resolver, ref, artifact.Manifest, artifact.manifest)
+}
+
+func pushBlob(ctx context.Context, resolver remotes.Resolver, ref reference.Named, descriptor ocispec.Descriptor, content []byte) error {
+	pusher, err := resolver.Pusher(ctx, ref.String())
+	if err != nil {
+		return err
+	}
+	writer, err := pusher.Push(ctx, descriptor)
+	if err != nil {
+		if errdefs.IsAlreadyExists(err) {
+			return nil
+		}
+		return err
+	}
+	defer writer.Close() //nolint:errcheck
+	if _, err := writer.Write(content); err != nil {
+		return err
+	}
+	err = writer.Commit(ctx, descriptor.Size, descriptor.Digest)
+	if errdefs.IsAlreadyExists(err) {
+		return nil
+	}
+	return err
+}
+
+// Load fetches a Compose project published as an OCI artifact into the cache directory, unless it already has been,
+// and returns the path of its Compose file. The project is stored in a directory named after the repository, so that
+// the default project name and the paths relative to the Compose file are the ones of the published project.
+func Load(ctx context.Context, resolver remotes.Resolver, ref string, cacheDir string) (string, error) {
+	named, err := ParseReference(ref)
+	if err != nil {
+		return "", err
+	}
+	_, descriptor, err := resolver.Resolve(ctx, named.String())
+	if err != nil {
+		return "", errors.Wrapf(err, "resolving %s", reference.FamiliarString(named))
+	}
+	manifest, err := fetchManifest(ctx, resolver, named, descriptor)
+	if err != nil {
+		return "", err
+	}
+
+	dir := filepath.Join(cacheDir, descriptor.Digest.Encoded(), path.Base(reference.Path(named)))
+	composeFile := ""
+	for _, layer := range manifest.Layers {
+		if layer.MediaType == ComposeYAMLMediaType {
+			composeFile = filepath.Join(dir, filepath.FromSlash(layer.Annotations[ocispec.AnnotationTitle]))
+			break
+		}
+	}
+	if composeFile == "" {
+		return "", fmt.Errorf("%s has no Compose file", reference.FamiliarString(named))
+	}
+	// the cache is keyed by the manifest digest, so the files of a project already fetched can't have changed
+	if _, err := os.Stat(composeFile); err == nil {
+		return composeFile, nil
+	}
+
+	var files []File
+	for _, layer := range manifest.Layers {
+		content, err := fetchBlob(ctx, resolver, named, layer)
+		if err != nil {
+			return "", errors.Wrapf(err, "fetching %s", layer.Annotations[ocispec.AnnotationTitle])
+		}
+		files = append(files, File{
+			Path:      layer.Annotations[ocispec.AnnotationTitle],
+			MediaType: layer.MediaType,
+			Content:   content,
+		})
+	}
+	return composeFile, writeFiles(dir, files)
+}
+
+// fetchManifest fetches the manifest of a published project, checking it is one and that the paths of its files stay
+// in the project directory
+func fetchManifest(ctx context.Context, resolver remotes.Resolver, ref reference.Named, descriptor ocispec.Descriptor) (ocispec.Manifest, error) {
+	var manifest ocispec.Manifest
+	if descriptor.MediaType != ocispec.MediaTypeImageManifest {
+		return manifest, fmt.Errorf("%s is not a Compose project: unexpected media type %s", reference.FamiliarString(ref), descriptor.MediaType)
+	}
+	content, err := fetchBlob(ctx, resolver, ref, descriptor)
+	if err != nil {
+		return manifest, err
+	}
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return manifest, err
+	}
+	if manifest.Config.MediaType != ComposeProjectMediaType {
+		return manifest, fmt.Errorf("%s is not a Compose project: unexpected config media type %s", reference.FamiliarString(ref), manifest.Config.MediaType)
+	}
+	for _, layer := range manifest.Layers {
+		if err := checkPath(layer.Annotations[ocispec.AnnotationTitle]); err != nil {
+			return manifest, err
+		}
+	}
+	return manifest, nil
+}
+
+// fetchBlob fetches a blob of a published project and checks its content matches its digest
+func fetchBlob(ctx context.Context, resolver remotes.Resolver, ref reference.Named, descriptor ocispec.Descriptor) ([]byte, error) {
+	fetcher, err := resolver.Fetcher(ctx, ref.String())
+	if err != nil {
+		return nil, err
+	}
+	reader, err := fetcher.Fetch(ctx, descriptor)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close() //nolint:errcheck
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	if actual := digest.FromBytes(content); actual != descriptor.Digest {
+		return nil, fmt.Errorf("digest mismatch: expected %s, got %s", descriptor.Digest, actual)
+	}
+	return content, nil
+}
+
+// checkPath checks the path of a file of a published project is relative and stays in the project directory
+func checkPath(p string) error {
+	if p == "" {
+		return errors.New("file of the Compose project has no path")
+	}
+	clean := path.Clean(p)
+	if path.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, "../") {
+		return fmt.Errorf("file %q of the Compose project is outside of the project directory", p)
+	}
+	return nil
+}
+
+// writeFiles writes the files of a published project to its directory. They are written to a temporary directory
+// first, renamed once complete, so that an interrupted fetch doesn't leave an incomplete project in the cache.
+func writeFiles(dir string, files []File) error {
+	if err := os.MkdirAll(filepath.Dir(dir), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.MkdirTemp(filepath.Dir(dir), ".fetch-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp) //nolint:errcheck
+	for _, file := range files {
+		target := filepath.Join(tmp, filepath.FromSlash(file.Path))
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(target, file.Content, 0o644); err != nil {
+			return err
+		}
+	}
+	if err := os.Rename(tmp, dir); err != nil {
+		// the project may have been fetched concurrently
+		if _, statErr := os.Stat(dir); statErr == nil {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
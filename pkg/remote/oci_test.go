@@ -0,0 +1,176 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package remote
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/remotes"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"gotest.tools/v3/assert"
+)
+
+// registry is an in-memory registry, its blobs being indexed by digest and its manifests by reference
+type registry struct {
+	blobs     map[digest.Digest][]byte
+	manifests map[string]ocispec.Descriptor
+}
+
+func newRegistry() *registry {
+	return &registry{
+		blobs:     map[digest.Digest][]byte{},
+		manifests: map[string]ocispec.Descriptor{},
+	}
+}
+
+func (r *registry) Resolve(_ context.Context, ref string) (string, ocispec.Descriptor, error) {
+	descriptor, ok := r.manifests[ref]
+	if !ok {
+		return "", ocispec.Descriptor{}, errdefs.ErrNotFound
+	}
+	return ref, descriptor, nil
+}
+
+func (r *registry) Fetcher(context.Context, string) (remotes.Fetcher, error) {
+	return remotes.FetcherFunc(func(_ context.Context, descriptor ocispec.Descriptor) (io.ReadCloser, error) {
+		blob, ok := r.blobs[descriptor.Digest]
+		if !ok {
+			return nil, errdefs.ErrNotFound
+		}
+		return io.NopCloser(bytes.NewReader(blob)), nil
+	}), nil
+}
+
+func (r *registry) Pusher(_ context.Context, ref string) (remotes.Pusher, error) {
+	return remotes.PusherFunc(func(_ context.Context, descriptor ocispec.Descriptor) (content.Writer, error) {
+		if _, ok := r.blobs[descriptor.Digest]; ok && descriptor.MediaType != ocispec.MediaTypeImageManifest {
+			return nil, errdefs.ErrAlreadyExists
+		}
+		return &blobWriter{registry: r, ref: ref, descriptor: descriptor}, nil
+	}), nil
+}
+
+type blobWriter struct {
+	bytes.Buffer
+	registry   *registry
+	ref        string
+	descriptor ocispec.Descriptor
+}
+
+func (w *blobWriter) Close() error {
+	return nil
+}
+
+func (w *blobWriter) Digest() digest.Digest {
+	return digest.FromBytes(w.Bytes())
+}
+
+func (w *blobWriter) Commit(_ context.Context, _ int64, expected digest.Digest, _ ...content.Opt) error {
+	if w.Digest() != expected {
+		return errdefs.ErrFailedPrecondition
+	}
+	w.registry.blobs[expected] = w.Bytes()
+	if w.descriptor.MediaType == ocispec.MediaTypeImageManifest {
+		w.registry.manifests[w.ref] = w.descriptor
+	}
+	return nil
+}
+
+func (w *blobWriter) Status() (content.Status, error) {
+	return content.Status{}, nil
+}
+
+func (w *blobWriter) Truncate(int64) error {
+	w.Reset()
+	return nil
+}
+
+func TestParseReference(t *testing.T) {
+	named, err := ParseReference("oci://registry.example.com/team/app")
+	assert.NilError(t, err)
+	assert.Equal(t, named.String(), "registry.example.com/team/app:latest")
+
+	named, err = ParseReference("team/app:v1")
+	assert.NilError(t, err)
+	assert.Equal(t, named.String(), "docker.io/team/app:v1")
+
+	_, err = ParseReference("oci://Invalid")
+	assert.ErrorContains(t, err, `invalid reference "oci://Invalid"`)
+}
+
+func TestPushLoad(t *testing.T) {
+	ctx := context.Background()
+	resolver := newRegistry()
+	named, err := ParseReference("registry.example.com/team/app:v1")
+	assert.NilError(t, err)
+
+	files := []File{
+		{Path: "compose.yaml", MediaType: ComposeYAMLMediaType, Content: []byte("services:\n  web:\n    image: nginx\n")},
+		{Path: ".env", MediaType: ComposeEnvFileMediaType, Content: []byte("TAG=1.23\n")},
+		{Path: "conf/nginx.conf", MediaType: ComposeLocalFileMediaType, Content: []byte("server {}\n")},
+	}
+	descriptor, err := Push(ctx, resolver, named, files)
+	assert.NilError(t, err)
+	assert.Equal(t, descriptor.MediaType, ocispec.MediaTypeImageManifest)
+
+	// the manifest pushed is the one computed without pushing, as by publish in dry run mode
+	artifact, err := NewArtifact(files)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, artifact.Manifest, descriptor)
+
+	cacheDir := t.TempDir()
+	composeFile, err := Load(ctx, resolver, "oci://registry.example.com/team/app:v1", cacheDir)
+	assert.NilError(t, err)
+	assert.Equal(t, composeFile, filepath.Join(cacheDir, descriptor.Digest.Encoded(), "app", "compose.yaml"))
+	for _, file := range files {
+		content, err := os.ReadFile(filepath.Join(filepath.Dir(composeFile), filepath.FromSlash(file.Path)))
+		assert.NilError(t, err)
+		assert.Equal(t, string(content), string(file.Content))
+	}
+
+	// a project already fetched is loaded from the cache
+	delete(resolver.blobs, digest.FromBytes(files[2].Content))
+	_, err = Load(ctx, resolver, "oci://registry.example.com/team/app:v1", cacheDir)
+	assert.NilError(t, err)
+
+	_, err = Load(ctx, resolver, "oci://registry.example.com/team/app:v2", cacheDir)
+	assert.ErrorContains(t, err, "resolving registry.example.com/team/app:v2")
+}
+
+func TestLoadRejectsPathsOutOfProject(t *testing.T) {
+	ctx := context.Background()
+	resolver := newRegistry()
+	named, err := ParseReference("registry.example.com/team/app")
+	assert.NilError(t, err)
+
+	_, err = Push(ctx, resolver, named, []File{
+		{Path: "compose.yaml", MediaType: ComposeYAMLMediaType, Content: []byte("services: {}\n")},
+		{Path: "../../etc/profile", MediaType: ComposeLocalFileMediaType, Content: []byte("evil\n")},
+	})
+	assert.NilError(t, err)
+
+	_, err = Load(ctx, resolver, "oci://registry.example.com/team/app", t.TempDir())
+	assert.ErrorContains(t, err, `file "../../etc/profile" of the Compose project is outside of the project directory`)
+}